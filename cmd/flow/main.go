@@ -23,6 +23,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/internal/accounts"
+	"github.com/onflow/flow-cli/internal/audit"
 	"github.com/onflow/flow-cli/internal/blocks"
 	"github.com/onflow/flow-cli/internal/cadence"
 	"github.com/onflow/flow-cli/internal/collections"
@@ -49,6 +50,8 @@ func main() {
 	// quick commands
 	quick.InitCommand.AddToParent(cmd)
 	status.Command.AddToParent(cmd)
+	quick.RunCommand.AddToParent(cmd)
+	quick.BenchCommand.AddToParent(cmd)
 
 	// structured commands
 	cmd.AddCommand(cadence.Cmd)
@@ -63,6 +66,7 @@ func main() {
 	cmd.AddCommand(collections.Cmd)
 	cmd.AddCommand(project.Cmd)
 	cmd.AddCommand(config.Cmd)
+	cmd.AddCommand(audit.Cmd)
 
 	command.InitFlags(cmd)
 
@@ -18,7 +18,9 @@ require (
 	github.com/spf13/cobra v1.1.3
 	github.com/stretchr/testify v1.7.0
 	github.com/thoas/go-funk v0.7.0
+	golang.org/x/sys v0.0.0-20210603125802-9665404d3644
 	golang.org/x/tools v0.1.4 // indirect
 	gonum.org/v1/gonum v0.6.1
 	google.golang.org/grpc v1.37.0
+	gopkg.in/yaml.v2 v2.4.0
 )
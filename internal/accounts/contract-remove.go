@@ -24,12 +24,14 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/transactions"
 	"github.com/onflow/flow-cli/pkg/flowkit/services"
 )
 
 type flagsRemoveContract struct {
 	Signer  string   `default:"emulator-account" flag:"signer" info:"Account name from configuration used to sign the transaction"`
 	Include []string `default:"" flag:"include" info:"Fields to include in the output"`
+	NoWait  bool     `default:"false" flag:"no-wait" info:"Return the transaction ID right after submission without waiting for the result"`
 }
 
 var flagsRemove = flagsRemoveContract{}
@@ -59,6 +61,15 @@ func removeContract(
 		return nil, err
 	}
 
+	if flagsRemove.NoWait {
+		tx, err := services.Accounts.SubmitRemoveContract(from, contractName)
+		if err != nil {
+			return nil, err
+		}
+
+		return transactions.NewSubmittedResult(tx), nil
+	}
+
 	account, err := services.Accounts.RemoveContract(from, contractName)
 	if err != nil {
 		return nil, err
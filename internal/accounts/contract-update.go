@@ -26,12 +26,14 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/transactions"
 	"github.com/onflow/flow-cli/pkg/flowkit/services"
 )
 
 type flagsUpdateContract struct {
 	Signer  string   `default:"emulator-account" flag:"signer" info:"Account name from configuration used to sign the transaction"`
 	Include []string `default:"" flag:"include" info:"Fields to include in the output"`
+	NoWait  bool     `default:"false" flag:"no-wait" info:"Return the transaction ID right after submission without waiting for the result"`
 }
 
 var updateFlags = flagsUpdateContract{}
@@ -67,6 +69,15 @@ func updateContract(
 		return nil, err
 	}
 
+	if updateFlags.NoWait {
+		tx, err := services.Accounts.SubmitAddContract(to, name, code, true)
+		if err != nil {
+			return nil, err
+		}
+
+		return transactions.NewSubmittedResult(tx), nil
+	}
+
 	account, err := services.Accounts.AddContract(to, name, code, true)
 	if err != nil {
 		return nil, err
@@ -20,35 +20,45 @@ package accounts
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/onflow/flow-cli/pkg/flowkit"
 
+	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
 
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/transactions"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
 	"github.com/onflow/flow-cli/pkg/flowkit/services"
 )
 
 type flagsCreate struct {
 	Signer    string   `default:"emulator-account" flag:"signer" info:"Account name from configuration used to sign the transaction"`
-	Keys      []string `flag:"key" info:"Public keys to attach to account"`
+	Keys      []string `flag:"key" info:"Public keys to attach to account in hex, RLP or PEM format"`
 	Weights   []int    `flag:"key-weight" info:"Weight for the key"`
 	SigAlgo   string   `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm used to generate the keys"`
 	HashAlgo  string   `default:"SHA3_256" flag:"hash-algo" info:"Hash used for the digest"`
 	Contracts []string `flag:"contract" info:"Contract to be deployed during account creation. <name:filename>"`
 	Include   []string `default:"" flag:"include" info:"Fields to include in the output"`
+	Name      string   `default:"" flag:"name" info:"Name of the account saved to the configuration"`
+	Save      bool     `default:"false" flag:"save" info:"Generate a key for the new account and save the account to the configuration"`
+	KeyFile   string   `default:"" flag:"key-file" info:"File the generated private key is saved to instead of the configuration, used with the save flag"`
+	NoWait    bool     `default:"false" flag:"no-wait" info:"Return the transaction ID right after submission without waiting for the result"`
 }
 
 var createFlags = flagsCreate{}
 
 var CreateCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "create",
-		Short:   "Create a new account on network",
-		Example: `flow accounts create --key d651f1931a2...8745`,
+		Use:   "create",
+		Short: "Create a new account on network",
+		Example: `flow accounts create --key d651f1931a2...8745
+
+flow accounts create --name alice --save
+
+flow accounts create --name alice --save --key-file alice.pkey`,
 	},
 	Flags: &createFlags,
 	RunS:  create,
@@ -57,7 +67,7 @@ var CreateCommand = &command.Command{
 func create(
 	_ []string,
 	_ flowkit.ReaderWriter,
-	_ command.GlobalFlags,
+	globalFlags command.GlobalFlags,
 	services *services.Services,
 	state *flowkit.State,
 ) (command.Result, error) {
@@ -76,25 +86,62 @@ func create(
 		return nil, fmt.Errorf("invalid hash algorithm: %s", createFlags.HashAlgo)
 	}
 
+	if createFlags.Save {
+		if createFlags.NoWait {
+			return nil, fmt.Errorf("no-wait flag can not be used together with save flag, the address of the new account is needed to save it")
+		}
+
+		return createAndSave(services, signer, sigAlgo, hashAlgo, globalFlags.ConfigPaths)
+	}
+
+	if createFlags.Name != "" || createFlags.KeyFile != "" {
+		return nil, fmt.Errorf("name and key file can only be used together with the save flag")
+	}
+
 	keyWeights := createFlags.Weights
 
-	// decode public keys
-	var pubKeys []crypto.PublicKey
-	for _, k := range createFlags.Keys {
-		k = strings.TrimPrefix(k, "0x") // clear possible prefix
-		key, err := crypto.DecodePublicKeyHex(sigAlgo, k)
+	// if more than one key is provided and at least one weight is specified, make sure there isn't a mismatch
+	if len(keyWeights) > 0 && len(createFlags.Keys) != len(keyWeights) {
+		return nil, fmt.Errorf(
+			"number of keys and weights provided must match, number of provided keys: %d, number of provided key weights: %d",
+			len(createFlags.Keys),
+			len(keyWeights),
+		)
+	}
+
+	// decode public keys in hex, RLP or PEM format
+	var accountKeys []*flow.AccountKey
+	for i, k := range createFlags.Keys {
+		accountKey, err := services.Keys.ParsePublicKey(k, sigAlgo)
 		if err != nil {
-			return nil, fmt.Errorf("failed decoding public key: %s with error: %w", key, err)
+			return nil, fmt.Errorf("failed decoding public key: %s with error: %w", k, err)
+		}
+
+		// RLP encoded keys include algorithms and weight, flags are used for other formats
+		if accountKey.HashAlgo == crypto.UnknownHashAlgorithm {
+			accountKey.HashAlgo = hashAlgo
 		}
-		pubKeys = append(pubKeys, key)
+		if len(keyWeights) > i {
+			accountKey.Weight = keyWeights[i]
+		} else if accountKey.Weight < 0 {
+			accountKey.Weight = flow.AccountKeyWeightThreshold
+		}
+
+		accountKeys = append(accountKeys, accountKey)
 	}
 
-	account, err := services.Accounts.Create(
+	if createFlags.NoWait {
+		tx, err := services.Accounts.SubmitCreate(signer, accountKeys, createFlags.Contracts)
+		if err != nil {
+			return nil, err
+		}
+
+		return transactions.NewSubmittedResult(tx), nil
+	}
+
+	account, err := services.Accounts.CreateWithAccountKeys(
 		signer,
-		pubKeys,
-		keyWeights,
-		sigAlgo,
-		hashAlgo,
+		accountKeys,
 		createFlags.Contracts,
 	)
 
@@ -107,3 +154,66 @@ func create(
 		include: createFlags.Include,
 	}, nil
 }
+
+// createAndSave creates an account with a newly generated key and saves it to the configuration.
+//
+// The configuration is only changed after the account was created on the network.
+func createAndSave(
+	services *services.Services,
+	signer *flowkit.Account,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+	configPaths []string,
+) (command.Result, error) {
+	if len(createFlags.Keys) > 0 {
+		return nil, fmt.Errorf("can not save account created with provided keys, private keys are not known")
+	}
+
+	weight := flow.AccountKeyWeightThreshold
+	if len(createFlags.Weights) > 0 {
+		weight = createFlags.Weights[0]
+	}
+
+	account, err := services.Accounts.CreateAndSave(
+		signer,
+		createFlags.Name,
+		createFlags.KeyFile,
+		weight,
+		sigAlgo,
+		hashAlgo,
+		createFlags.Contracts,
+		configPaths,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SavedAccountResult{
+		AccountResult: &AccountResult{
+			Account: account,
+			include: createFlags.Include,
+		},
+		name: createFlags.Name,
+	}, nil
+}
+
+// SavedAccountResult is the result of an account created and saved to the configuration.
+type SavedAccountResult struct {
+	*AccountResult
+	name string
+}
+
+func (r *SavedAccountResult) JSON() interface{} {
+	result := r.AccountResult.JSON().(map[string]interface{})
+	result["name"] = r.name
+	return result
+}
+
+func (r *SavedAccountResult) String() string {
+	return fmt.Sprintf(
+		"%s\n\n%s Account %s saved to the configuration",
+		r.AccountResult.String(),
+		output.SaveEmoji(),
+		r.name,
+	)
+}
@@ -33,7 +33,9 @@ import (
 	"github.com/onflow/flow-cli/pkg/flowkit/util"
 )
 
-type flagsStakingInfo struct{}
+type flagsStakingInfo struct {
+	CadenceJSON string `default:"plain" flag:"cadence-json" info:"Encoding of Cadence values in the JSON output (full, plain)"`
+}
 
 var stakingFlags = flagsStakingInfo{}
 
@@ -54,6 +56,11 @@ func stakingInfo(
 	_ command.GlobalFlags,
 	services *services.Services,
 ) (command.Result, error) {
+	err := command.ValidateCadenceJSON(stakingFlags.CadenceJSON)
+	if err != nil {
+		return nil, err
+	}
+
 	address := flow.HexToAddress(args[0])
 
 	staking, delegation, err := services.Accounts.StakingInfo(address)
@@ -61,22 +68,33 @@ func stakingInfo(
 		return nil, err
 	}
 
-	return &StakingResult{*staking, *delegation}, nil
+	return &StakingResult{*staking, *delegation, stakingFlags.CadenceJSON}, nil
 }
 
 type StakingResult struct {
-	staking    cadence.Value
-	delegation cadence.Value
+	staking     cadence.Value
+	delegation  cadence.Value
+	cadenceJSON string // encoding of the staking info values in the JSON output, full or plain
 }
 
 func (r *StakingResult) JSON() interface{} {
 	result := make(map[string]interface{})
-	result["staking"] = flowkit.NewStakingInfoFromValue(r.staking)
-	result["delegation"] = flowkit.NewStakingInfoFromValue(r.delegation)
+	result["staking"] = r.stakingInfoJSON(r.staking)
+	result["delegation"] = r.stakingInfoJSON(r.delegation)
 
 	return result
 }
 
+// stakingInfoJSON converts the staking info values to the JSON output format.
+func (r *StakingResult) stakingInfoJSON(value cadence.Value) map[string]interface{} {
+	info := make(map[string]interface{})
+	for key, value := range flowkit.NewStakingInfoFromValue(value) {
+		info[key] = command.CadenceJSON(value.(cadence.Value), r.cadenceJSON)
+	}
+
+	return info
+}
+
 func (r *StakingResult) String() string {
 	var b bytes.Buffer
 	writer := util.CreateTabWriter(&b)
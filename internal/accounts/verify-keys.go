@@ -0,0 +1,149 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+type flagsVerifyKeys struct{}
+
+var verifyKeysFlags = flagsVerifyKeys{}
+
+var VerifyKeysCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "verify-keys [name]",
+		Short:   "Verify configured account keys match the keys on the network",
+		Example: "flow accounts verify-keys alice --network testnet",
+		Args:    cobra.MaximumNArgs(1),
+	},
+	Flags: &verifyKeysFlags,
+	RunS:  verifyKeys,
+}
+
+func verifyKeys(
+	args []string,
+	_ flowkit.ReaderWriter,
+	_ command.GlobalFlags,
+	services *services.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	accounts := *state.Accounts()
+	if len(args) == 1 {
+		account, err := state.Accounts().ByName(args[0])
+		if err != nil {
+			return nil, err
+		}
+		accounts = []flowkit.Account{*account}
+	}
+
+	verifyAccounts := make([]*flowkit.Account, 0, len(accounts))
+	for i := range accounts {
+		verifyAccounts = append(verifyAccounts, &accounts[i])
+	}
+
+	return &VerifyKeysResult{
+		verifications: services.Accounts.VerifyKeys(verifyAccounts),
+	}, nil
+}
+
+type VerifyKeysResult struct {
+	verifications []services.KeyVerification
+}
+
+func (r *VerifyKeysResult) JSON() interface{} {
+	result := make([]interface{}, 0, len(r.verifications))
+	for _, v := range r.verifications {
+		mismatches := make([]interface{}, 0, len(v.Mismatches))
+		for _, m := range v.Mismatches {
+			mismatches = append(mismatches, map[string]string{
+				"field":      m.Field,
+				"configured": m.Configured,
+				"onChain":    m.OnChain,
+			})
+		}
+
+		account := map[string]interface{}{
+			"name":       v.Account.Name(),
+			"address":    v.Account.Address().String(),
+			"keyIndex":   v.Account.Key().Index(),
+			"valid":      v.Valid(),
+			"mismatches": mismatches,
+		}
+		if v.Err != nil {
+			account["error"] = v.Err.Error()
+		}
+
+		result = append(result, account)
+	}
+
+	return result
+}
+
+func (r *VerifyKeysResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	for _, v := range r.verifications {
+		status := fmt.Sprintf("%s Valid", output.OkEmoji())
+		if !v.Valid() {
+			status = fmt.Sprintf("%s Invalid", output.ErrorEmoji())
+		}
+
+		_, _ = fmt.Fprintf(writer, "Account\t %s\n", v.Account.Name())
+		_, _ = fmt.Fprintf(writer, "Address\t 0x%s\n", v.Account.Address())
+		_, _ = fmt.Fprintf(writer, "Key Index\t %d\n", v.Account.Key().Index())
+		_, _ = fmt.Fprintf(writer, "Status\t %s\n", status)
+
+		if v.Err != nil {
+			_, _ = fmt.Fprintf(writer, "Error\t %s\n", v.Err)
+		}
+
+		for _, m := range v.Mismatches {
+			_, _ = fmt.Fprintf(writer, "\t%s\t configured: %s\n", m.Field, m.Configured)
+			_, _ = fmt.Fprintf(writer, "\t\t on-chain: %s\n", m.OnChain)
+		}
+
+		_, _ = fmt.Fprintf(writer, "\n")
+	}
+
+	_ = writer.Flush()
+
+	return b.String()
+}
+
+func (r *VerifyKeysResult) Oneliner() string {
+	invalid := 0
+	for _, v := range r.verifications {
+		if !v.Valid() {
+			invalid++
+		}
+	}
+
+	return fmt.Sprintf("Verified: %d, Invalid: %d", len(r.verifications), invalid)
+}
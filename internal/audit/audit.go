@@ -0,0 +1,81 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audit
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+var Cmd = &cobra.Command{
+	Use:              "audit",
+	Short:            "Utilities to inspect the signing audit log",
+	TraverseChildren: true,
+}
+
+func init() {
+	VerifyCommand.AddToParent(Cmd)
+}
+
+type VerifyResult struct {
+	path     string
+	entries  []*flowkit.AuditEntry
+	lastHash string
+}
+
+// JSON converts result to a JSON.
+func (r *VerifyResult) JSON() interface{} {
+	result := make(map[string]interface{})
+	result["path"] = r.path
+	result["entries"] = len(r.entries)
+	result["lastHash"] = r.lastHash
+	result["valid"] = true
+
+	return result
+}
+
+// String converts result to a string.
+func (r *VerifyResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Audit Log\t%s\n", r.path)
+	_, _ = fmt.Fprintf(writer, "Entries\t%d\n", len(r.entries))
+	if len(r.entries) > 0 {
+		first := r.entries[0]
+		last := r.entries[len(r.entries)-1]
+		_, _ = fmt.Fprintf(writer, "First Entry\t%s\n", first.Timestamp.Format("2006-01-02 15:04:05 MST"))
+		_, _ = fmt.Fprintf(writer, "Last Entry\t%s\n", last.Timestamp.Format("2006-01-02 15:04:05 MST"))
+		_, _ = fmt.Fprintf(writer, "Last Hash\t%s\n", r.lastHash)
+	}
+	_, _ = fmt.Fprintf(writer, "\nAudit log chain is valid.\n")
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+// Oneliner returns result as one liner grep friendly.
+func (r *VerifyResult) Oneliner() string {
+	return fmt.Sprintf("Entries: %d, Last Hash: %s", len(r.entries), r.lastHash)
+}
@@ -0,0 +1,82 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audit
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+type flagsVerify struct{}
+
+var verifyFlags = flagsVerify{}
+
+var VerifyCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "verify [<audit log file>]",
+		Short:   "Verify the hash chain of the signing audit log",
+		Example: "flow audit verify ./audit.log",
+		Args:    cobra.MaximumNArgs(1),
+	},
+	Flags: &verifyFlags,
+	Run:   verify,
+}
+
+func verify(
+	args []string,
+	readerWriter flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	_ *services.Services,
+) (command.Result, error) {
+	path := ""
+	if len(args) == 1 {
+		path = args[0]
+	} else {
+		state, err := flowkit.Load(globalFlags.ConfigPaths, readerWriter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load configuration for audit log path: %w", err)
+		}
+		path = state.Config().AuditLog
+	}
+
+	if path == "" {
+		return nil, fmt.Errorf("audit log is not configured, provide the audit log file or set auditLog in the configuration")
+	}
+
+	data, err := readerWriter.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	entries, lastHash, err := flowkit.VerifyAuditLog(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VerifyResult{
+		path:     path,
+		entries:  entries,
+		lastHash: lastHash,
+	}, nil
+}
@@ -94,6 +94,15 @@ func (c Command) AddToParent(parent *cobra.Command) {
 		host, err := resolveHost(state, Flags.Host, Flags.Network)
 		handleError("Host Error", err)
 
+		// record the network used for signing in the audit log if configured
+		if state != nil && state.AuditLog() != nil {
+			network := Flags.Network
+			if Flags.Host != "" {
+				network = Flags.Host
+			}
+			state.AuditLog().SetNetwork(network)
+		}
+
 		clientGateway, err := createGateway(host)
 		handleError("Gateway Error", err)
 
@@ -118,8 +127,14 @@ func (c Command) AddToParent(parent *cobra.Command) {
 			panic("command implementation needs to provide run functionality")
 		}
 
+		handleExecutionErrorJSON(err, Flags.Save, Flags.Format, Flags.Filter)
 		handleError("Command Error", err)
 
+		// commands streaming their results while running have already written the output
+		if result == nil {
+			return
+		}
+
 		// format output result
 		formattedResult, err := formatResult(result, Flags.Filter, Flags.Format)
 		handleError("Result", err)
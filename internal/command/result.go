@@ -25,9 +25,12 @@ import (
 	"os"
 	"strings"
 
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"github.com/onflow/flow-go-sdk/client"
 	"github.com/spf13/afero"
 
+	"github.com/onflow/flow-cli/pkg/flowkit"
 	"github.com/onflow/flow-cli/pkg/flowkit/config"
 	"github.com/onflow/flow-cli/pkg/flowkit/output"
 )
@@ -53,6 +56,32 @@ func ContainsFlag(flags []string, field string) bool {
 	return false
 }
 
+// Values of the cadence-json flag selecting how Cadence values are encoded in the JSON output.
+const (
+	CadenceJSONFull  = "full"
+	CadenceJSONPlain = "plain"
+)
+
+// ValidateCadenceJSON checks the value of the cadence-json flag.
+func ValidateCadenceJSON(format string) error {
+	switch strings.ToLower(format) {
+	case "", CadenceJSONFull, CadenceJSONPlain:
+		return nil
+	}
+
+	return fmt.Errorf("invalid cadence-json value %s, valid values are: %s, %s", format, CadenceJSONFull, CadenceJSONPlain)
+}
+
+// CadenceJSON encodes the Cadence value for the JSON output, in JSON-Cadence format
+// or as plain JSON without type information if the format is plain.
+func CadenceJSON(value cadence.Value, format string) interface{} {
+	if strings.ToLower(format) == CadenceJSONPlain {
+		return flowkit.PlainJSON(value)
+	}
+
+	return json.RawMessage(jsoncdc.MustEncode(value))
+}
+
 // formatResult formats a result for printing.
 func formatResult(result Result, filterFlag string, formatFlag string) (string, error) {
 	if result == nil {
@@ -60,6 +89,11 @@ func formatResult(result Result, filterFlag string, formatFlag string) (string,
 	}
 
 	if filterFlag != "" {
+		// values already encoded as JSON, like the transaction envelope, are output as they are
+		if value, ok := rawJSONValue(result, filterFlag); ok {
+			return string(value), nil
+		}
+
 		value, err := filterResultValue(result, filterFlag)
 		if err != nil {
 			return "", err
@@ -128,6 +162,61 @@ func filterResultValue(result Result, filter string) (interface{}, error) {
 	return value, nil
 }
 
+// rawJSONValue returns a value by its name if the result values contain it encoded as JSON.
+func rawJSONValue(result Result, filter string) (json.RawMessage, bool) {
+	values, ok := result.JSON().(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	value, ok := values[filter].(json.RawMessage)
+	if !ok {
+		value, ok = values[strings.ToLower(filter)].(json.RawMessage)
+	}
+
+	return value, ok
+}
+
+// executionErrorResult is the result of a command failing with Cadence errors.
+type executionErrorResult struct {
+	err          error
+	executionErr *flowkit.ExecutionError
+}
+
+func (r *executionErrorResult) JSON() interface{} {
+	errorsJSON, _ := json.MarshalIndent(r.executionErr.Errors, "", "\t")
+
+	return map[string]interface{}{
+		"error":  r.err.Error(),
+		"errors": json.RawMessage(errorsJSON),
+	}
+}
+
+func (r *executionErrorResult) String() string {
+	return r.err.Error()
+}
+
+func (r *executionErrorResult) Oneliner() string {
+	return r.err.Error()
+}
+
+// handleExecutionErrorJSON outputs Cadence errors as JSON when JSON format is selected, so they can be processed by tools.
+//
+// The errors are output like a command result, so the save and filter flags are applied.
+func handleExecutionErrorJSON(err error, saveFlag string, formatFlag string, filterFlag string) {
+	var executionErr *flowkit.ExecutionError
+	if !errors.As(err, &executionErr) || strings.ToLower(formatFlag) != formatJSON {
+		return
+	}
+
+	formattedResult, err := formatResult(&executionErrorResult{err, executionErr}, filterFlag, formatFlag)
+	handleError("Result", err)
+
+	err = outputResult(formattedResult, saveFlag, formatFlag, filterFlag)
+	handleError("Output Error", err)
+	os.Exit(1)
+}
+
 // handleError handle errors returned from command execution, try to understand why error happens and offer help to the user.
 func handleError(description string, err error) {
 	if err == nil {
@@ -140,12 +229,16 @@ func handleError(description string, err error) {
 	case *client.RPCError:
 		_, _ = fmt.Fprintf(os.Stderr, "%s Grpc Error: %s \n", output.ErrorEmoji(), t.GRPCStatus().Err().Error())
 	default:
+		var keyMismatchErr *flowkit.KeyMismatchError
 		if errors.Is(err, config.ErrOutdatedFormat) {
 			_, _ = fmt.Fprintf(os.Stderr, "%s Config Error: %s \n", output.ErrorEmoji(), err.Error())
 			_, _ = fmt.Fprintf(os.Stderr, "%s Please reset configuration using: 'flow init --reset'. Read more about new configuration here: https://github.com/onflow/flow-cli/releases/tag/v0.17.0", output.TryEmoji())
 		} else if errors.Is(err, config.ErrDoesNotExist) {
 			_, _ = fmt.Fprintf(os.Stderr, "%s Config Error: %s \n", output.ErrorEmoji(), err.Error())
 			_, _ = fmt.Fprintf(os.Stderr, "%s Please create configuration using: flow init", output.TryEmoji())
+		} else if errors.As(err, &keyMismatchErr) {
+			_, _ = fmt.Fprintf(os.Stderr, "%s %s: %s \n", output.ErrorEmoji(), description, err)
+			_, _ = fmt.Fprintf(os.Stderr, "%s Check the account key in the configuration, you can use: 'flow accounts verify-keys %s'", output.TryEmoji(), keyMismatchErr.Account)
 		} else if strings.Contains(err.Error(), "transport:") {
 			_, _ = fmt.Fprintf(os.Stderr, "%s %s \n", output.ErrorEmoji(), strings.Split(err.Error(), "transport:")[1])
 			_, _ = fmt.Fprintf(os.Stderr, "%s Make sure your emulator is running or connection address is correct.", output.TryEmoji())
@@ -20,14 +20,13 @@ package events
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
 	"io"
 
+	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/pkg/flowkit/util"
 
 	"github.com/onflow/cadence"
-	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/client"
 	"github.com/spf13/cobra"
@@ -46,6 +45,7 @@ func init() {
 type EventResult struct {
 	BlockEvents []client.BlockEvents
 	Events      []flow.Event
+	CadenceJSON string // encoding of the event values in the JSON output, full or plain
 }
 
 func (e *EventResult) JSON() interface{} {
@@ -59,9 +59,7 @@ func (e *EventResult) JSON() interface{} {
 					"index":         event.EventIndex,
 					"type":          event.Type,
 					"transactionId": event.TransactionID.String(),
-					"values": json.RawMessage(
-						jsoncdc.MustEncode(event.Value),
-					),
+					"values":        command.CadenceJSON(event.Value, e.CadenceJSON),
 				})
 			}
 		}
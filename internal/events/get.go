@@ -30,11 +30,12 @@ import (
 )
 
 type flagsEvents struct {
-	Start   uint64 `flag:"start" info:"Start block height"`
-	End     uint64 `flag:"end" info:"End block height"`
-	Last    uint64 `default:"10" flag:"last" info:"Fetch number of blocks relative to the last block. Ignored if the start flag is set. Used as a default if no flags are provided"`
-	Workers int    `default:"10" flag:"workers" info:"Number of workers to use when fetching events in parallel"`
-	Batch   uint64 `default:"250" flag:"batch" info:"Number of blocks each worker will fetch"`
+	Start       uint64 `flag:"start" info:"Start block height"`
+	End         uint64 `flag:"end" info:"End block height"`
+	Last        uint64 `default:"10" flag:"last" info:"Fetch number of blocks relative to the last block. Ignored if the start flag is set. Used as a default if no flags are provided"`
+	Workers     int    `default:"10" flag:"workers" info:"Number of workers to use when fetching events in parallel"`
+	Batch       uint64 `default:"250" flag:"batch" info:"Number of blocks each worker will fetch"`
+	CadenceJSON string `default:"full" flag:"cadence-json" info:"Encoding of Cadence values in the JSON output (full, plain)"`
 }
 
 var eventsFlags = flagsEvents{}
@@ -67,7 +68,11 @@ func get(
 	_ command.GlobalFlags,
 	services *services.Services,
 ) (command.Result, error) {
-	var err error
+	err := command.ValidateCadenceJSON(eventsFlags.CadenceJSON)
+	if err != nil {
+		return nil, err
+	}
+
 	start := eventsFlags.Start
 	end := eventsFlags.End
 	last := eventsFlags.Last
@@ -119,5 +124,5 @@ func get(
 		return nil, err
 	}
 
-	return &EventResult{BlockEvents: events}, nil
+	return &EventResult{BlockEvents: events, CadenceJSON: eventsFlags.CadenceJSON}, nil
 }
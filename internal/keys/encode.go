@@ -0,0 +1,177 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+type flagsEncode struct {
+	SigAlgo  string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm of the public key"`
+	HashAlgo string `default:"SHA3_256" flag:"hash-algo" info:"Hash algorithm used with the key"`
+	Weight   int    `default:"1000" flag:"weight" info:"Weight of the account key"`
+	FromFile string `default:"" flag:"from-file" info:"Load key from file"`
+}
+
+var encodeFlags = flagsEncode{}
+
+var EncodeCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:       "encode <rlp|pem> <public key>",
+		Short:     "Encode a public key",
+		Args:      cobra.RangeArgs(1, 2),
+		ValidArgs: []string{"rlp", "pem"},
+		Example:   "flow keys encode rlp 858a7d978b25d61f...c7765117 --weight 500",
+	},
+	Flags: &encodeFlags,
+	Run:   encode,
+}
+
+func encode(
+	args []string,
+	readerWriter flowkit.ReaderWriter,
+	_ command.GlobalFlags,
+	services *services.Services,
+) (command.Result, error) {
+	encoding := strings.ToLower(args[0])
+
+	var key string
+	if len(args) > 1 {
+		key = args[1]
+	}
+
+	if key != "" && encodeFlags.FromFile != "" {
+		return nil, fmt.Errorf("can not pass both command argument and from file flag")
+	}
+	if key == "" && encodeFlags.FromFile == "" {
+		return nil, fmt.Errorf("provide argument for public key or use from file flag")
+	}
+
+	if encodeFlags.FromFile != "" {
+		k, err := readerWriter.ReadFile(encodeFlags.FromFile)
+		if err != nil {
+			return nil, err
+		}
+		key = string(k)
+	}
+
+	sigAlgo := crypto.StringToSignatureAlgorithm(encodeFlags.SigAlgo)
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("invalid signature algorithm: %s", encodeFlags.SigAlgo)
+	}
+
+	hashAlgo := crypto.StringToHashAlgorithm(encodeFlags.HashAlgo)
+	if hashAlgo == crypto.UnknownHashAlgorithm {
+		return nil, fmt.Errorf("invalid hash algorithm: %s", encodeFlags.HashAlgo)
+	}
+
+	accountKey, err := services.Keys.ParsePublicKey(key, sigAlgo)
+	if err != nil {
+		return nil, err
+	}
+
+	// keys decoded from RLP already contain algorithms and weight
+	if accountKey.HashAlgo == crypto.UnknownHashAlgorithm {
+		accountKey.HashAlgo = hashAlgo
+	}
+	if accountKey.Weight < 0 {
+		accountKey.Weight = encodeFlags.Weight
+	}
+
+	var encoded string
+	switch encoding {
+	case "rlp":
+		rlp, err := services.Keys.EncodeRLP(accountKey)
+		if err != nil {
+			return nil, err
+		}
+		encoded = hex.EncodeToString(rlp)
+	case "pem":
+		p, err := services.Keys.EncodePEM(accountKey.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		encoded = string(p)
+	default:
+		return nil, fmt.Errorf("encoding type not supported. Valid encoding: RLP and PEM")
+	}
+
+	return &EncodeResult{
+		accountKey: accountKey,
+		encoding:   encoding,
+		encoded:    encoded,
+	}, nil
+}
+
+type EncodeResult struct {
+	accountKey *flow.AccountKey
+	encoding   string
+	encoded    string
+}
+
+func (e *EncodeResult) JSON() interface{} {
+	result := map[string]interface{}{
+		"public":   hex.EncodeToString(e.accountKey.PublicKey.Encode()),
+		"encoding": e.encoding,
+		"encoded":  e.encoded,
+	}
+
+	if e.encoding == "rlp" {
+		result["sigAlgo"] = e.accountKey.SigAlgo.String()
+		result["hashAlgo"] = e.accountKey.HashAlgo.String()
+		result["weight"] = e.accountKey.Weight
+	}
+
+	return result
+}
+
+func (e *EncodeResult) String() string {
+	if e.encoding == "pem" {
+		return e.encoded
+	}
+
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Public Key \t %x\n", e.accountKey.PublicKey.Encode())
+	_, _ = fmt.Fprintf(writer, "Signature algorithm \t %s\n", e.accountKey.SigAlgo)
+	_, _ = fmt.Fprintf(writer, "Hash algorithm \t %s\n", e.accountKey.HashAlgo)
+	_, _ = fmt.Fprintf(writer, "Weight \t %d\n", e.accountKey.Weight)
+	_, _ = fmt.Fprintf(writer, "RLP Encoded \t %s\n", e.encoded)
+
+	_ = writer.Flush()
+
+	return b.String()
+}
+
+func (e *EncodeResult) Oneliner() string {
+	return e.encoded
+}
@@ -0,0 +1,150 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+type flagsInspect struct {
+	SigAlgo  string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm of the private key"`
+	HashAlgo string `default:"SHA3_256" flag:"hash-algo" info:"Hash algorithm used with the key"`
+	FromFile string `default:"" flag:"from-file" info:"Load private key from file"`
+	Account  string `default:"" flag:"account" info:"Account name from configuration which key is inspected"`
+	KMS      string `default:"" flag:"kms" info:"Google KMS key resource ID"`
+}
+
+var inspectFlags = flagsInspect{}
+
+var InspectCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "inspect [<private key>]",
+		Short: "Derive the public key and algorithms of a key",
+		Example: `flow keys inspect 5b54a8e2...f9d2
+
+flow keys inspect --account alice
+
+flow keys inspect --kms projects/my-project/locations/global/keyRings/flow/cryptoKeys/my-key/cryptoKeyVersions/1`,
+		Args: cobra.MaximumNArgs(1),
+	},
+	Flags: &inspectFlags,
+	Run:   inspect,
+}
+
+func inspect(
+	args []string,
+	readerWriter flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	services *services.Services,
+) (command.Result, error) {
+	sources := 0
+	for _, source := range []bool{
+		len(args) == 1, inspectFlags.FromFile != "", inspectFlags.Account != "", inspectFlags.KMS != "",
+	} {
+		if source {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return nil, fmt.Errorf("provide exactly one of: private key argument, --from-file, --account or --kms flag")
+	}
+
+	key, err := inspectedKey(args, readerWriter, globalFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	accountKey, err := services.Keys.Inspect(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyResult{
+		publicKey:  accountKey.PublicKey,
+		accountKey: accountKey,
+	}, nil
+}
+
+// inspectedKey creates the account key from the source provided as argument or flag.
+func inspectedKey(
+	args []string,
+	readerWriter flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+) (flowkit.AccountKey, error) {
+	if inspectFlags.Account != "" {
+		state, err := flowkit.Load(globalFlags.ConfigPaths, readerWriter)
+		if err != nil {
+			return nil, err
+		}
+
+		account, err := state.Accounts().ByName(inspectFlags.Account)
+		if err != nil {
+			return nil, err
+		}
+
+		return account.Key(), nil
+	}
+
+	if inspectFlags.KMS != "" {
+		// Google KMS only supports ECDSA_P256 with SHA2_256 keys
+		return flowkit.NewAccountKey(config.AccountKey{
+			Type:       config.KeyTypeGoogleKMS,
+			SigAlgo:    crypto.ECDSA_P256,
+			HashAlgo:   crypto.SHA2_256,
+			ResourceID: inspectFlags.KMS,
+		})
+	}
+
+	sigAlgo := crypto.StringToSignatureAlgorithm(inspectFlags.SigAlgo)
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("invalid signature algorithm: %s", inspectFlags.SigAlgo)
+	}
+
+	hashAlgo := crypto.StringToHashAlgorithm(inspectFlags.HashAlgo)
+	if hashAlgo == crypto.UnknownHashAlgorithm {
+		return nil, fmt.Errorf("invalid hash algorithm: %s", inspectFlags.HashAlgo)
+	}
+
+	var privateKey string
+	if inspectFlags.FromFile != "" {
+		k, err := readerWriter.ReadFile(inspectFlags.FromFile)
+		if err != nil {
+			return nil, err
+		}
+		privateKey = string(k)
+	} else {
+		privateKey = args[0]
+	}
+
+	pk, err := crypto.DecodePrivateKeyHex(sigAlgo, strings.TrimPrefix(strings.TrimSpace(privateKey), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode private key: %w", err)
+	}
+
+	return flowkit.NewHexAccountKeyFromPrivateKey(0, hashAlgo, pk), nil
+}
@@ -24,6 +24,7 @@ import (
 	"fmt"
 
 	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
 
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
@@ -41,6 +42,10 @@ var Cmd = &cobra.Command{
 func init() {
 	GenerateCommand.AddToParent(Cmd)
 	DecodeCommand.AddToParent(Cmd)
+	SignMessageCommand.AddToParent(Cmd)
+	VerifySignatureCommand.AddToParent(Cmd)
+	EncodeCommand.AddToParent(Cmd)
+	InspectCommand.AddToParent(Cmd)
 }
 
 type KeyResult struct {
@@ -51,12 +56,17 @@ type KeyResult struct {
 
 func (k *KeyResult) JSON() interface{} {
 	result := make(map[string]string)
-	result["public"] = hex.EncodeToString(k.privateKey.PublicKey().Encode())
+	result["public"] = hex.EncodeToString(k.publicKey.Encode())
 
 	if k.privateKey != nil {
 		result["private"] = hex.EncodeToString(k.privateKey.Encode())
 	}
 
+	if k.accountKey != nil {
+		result["sigAlgo"] = k.accountKey.SigAlgo.String()
+		result["hashAlgo"] = k.accountKey.HashAlgo.String()
+	}
+
 	return result
 }
 
@@ -95,3 +105,117 @@ func (k *KeyResult) Oneliner() string {
 
 	return result
 }
+
+type SignatureResult struct {
+	address   flow.Address
+	keyIndex  int
+	message   []byte
+	signature []byte
+}
+
+func (s *SignatureResult) JSON() interface{} {
+	return map[string]interface{}{
+		"address":   s.address.String(),
+		"keyIndex":  s.keyIndex,
+		"message":   hex.EncodeToString(s.message),
+		"signature": hex.EncodeToString(s.signature),
+	}
+}
+
+func (s *SignatureResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Address \t 0x%s\n", s.address)
+	_, _ = fmt.Fprintf(writer, "Key Index \t %d\n", s.keyIndex)
+	_, _ = fmt.Fprintf(writer, "Message \t %x\n", s.message)
+	_, _ = fmt.Fprintf(writer, "Signature \t %x\n", s.signature)
+
+	_ = writer.Flush()
+
+	return b.String()
+}
+
+func (s *SignatureResult) Oneliner() string {
+	return fmt.Sprintf("%d:%x", s.keyIndex, s.signature)
+}
+
+type VerificationResult struct {
+	verification *services.SignatureVerification
+	publicKey    crypto.PublicKey
+	valid        bool
+}
+
+func (v *VerificationResult) JSON() interface{} {
+	result := make(map[string]interface{})
+	result["valid"] = v.valid
+
+	if v.publicKey != nil {
+		result["publicKey"] = hex.EncodeToString(v.publicKey.Encode())
+	}
+
+	if v.verification != nil {
+		result["address"] = v.verification.Address.String()
+		result["weight"] = v.verification.Weight
+		result["threshold"] = flow.AccountKeyWeightThreshold
+
+		signatures := make([]interface{}, 0, len(v.verification.Signatures))
+		for _, sig := range v.verification.Signatures {
+			s := map[string]interface{}{
+				"keyIndex":  sig.KeyIndex,
+				"signature": hex.EncodeToString(sig.Signature),
+				"weight":    sig.Weight,
+				"revoked":   sig.Revoked,
+				"valid":     sig.Valid,
+			}
+			if sig.Error != nil {
+				s["error"] = sig.Error.Error()
+			}
+			signatures = append(signatures, s)
+		}
+		result["signatures"] = signatures
+	}
+
+	return result
+}
+
+func (v *VerificationResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	status := fmt.Sprintf("%s Invalid", output.ErrorEmoji())
+	if v.valid {
+		status = fmt.Sprintf("%s Valid", output.OkEmoji())
+	}
+	_, _ = fmt.Fprintf(writer, "Signature \t %s\n", status)
+
+	if v.publicKey != nil {
+		_, _ = fmt.Fprintf(writer, "Public Key \t %x\n", v.publicKey.Encode())
+	}
+
+	if v.verification != nil {
+		_, _ = fmt.Fprintf(writer, "Address \t 0x%s\n", v.verification.Address)
+		_, _ = fmt.Fprintf(writer, "Weight \t %d/%d\n", v.verification.Weight, flow.AccountKeyWeightThreshold)
+
+		for _, sig := range v.verification.Signatures {
+			_, _ = fmt.Fprintf(writer, "\nKey %d\tValid \t %t\n", sig.KeyIndex, sig.Valid)
+			_, _ = fmt.Fprintf(writer, "\tWeight \t %d\n", sig.Weight)
+			_, _ = fmt.Fprintf(writer, "\tRevoked \t %t\n", sig.Revoked)
+			if sig.Error != nil {
+				_, _ = fmt.Fprintf(writer, "\tError \t %s\n", sig.Error)
+			}
+		}
+	}
+
+	_ = writer.Flush()
+
+	return b.String()
+}
+
+func (v *VerificationResult) Oneliner() string {
+	if v.verification != nil {
+		return fmt.Sprintf("Valid: %t, Weight: %d", v.valid, v.verification.Weight)
+	}
+
+	return fmt.Sprintf("Valid: %t", v.valid)
+}
@@ -0,0 +1,93 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+type flagsSignMessage struct {
+	Signer string `default:"emulator-account" flag:"signer" info:"name of the account used to sign"`
+	Hex    bool   `default:"false" flag:"hex" info:"Message is hex encoded"`
+}
+
+var signMessageFlags = flagsSignMessage{}
+
+var SignMessageCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "sign-message <message>",
+		Short:   "Sign a message with the account key",
+		Example: `flow keys sign-message "I own this account" --signer alice`,
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &signMessageFlags,
+	RunS:  signMessage,
+}
+
+func signMessage(
+	args []string,
+	_ flowkit.ReaderWriter,
+	_ command.GlobalFlags,
+	services *services.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	message, err := decodeMessage(args[0], signMessageFlags.Hex)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := state.Accounts().ByName(signMessageFlags.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := services.Keys.SignMessage(signer, message)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignatureResult{
+		address:   signer.Address(),
+		keyIndex:  signer.Key().Index(),
+		message:   message,
+		signature: signature,
+	}, nil
+}
+
+// decodeMessage returns message bytes from the message or hex encoded message.
+func decodeMessage(message string, isHex bool) ([]byte, error) {
+	if !isHex {
+		return []byte(message), nil
+	}
+
+	decoded, err := hex.DecodeString(strings.TrimPrefix(message, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode hex message: %w", err)
+	}
+
+	return decoded, nil
+}
@@ -0,0 +1,151 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+type flagsVerifySignature struct {
+	Address   string `default:"" flag:"address" info:"Address of the account which keys are used for verification"`
+	PublicKey string `default:"" flag:"public-key" info:"Public key used for verification instead of the account keys"`
+	SigAlgo   string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm of the public key"`
+	HashAlgo  string `default:"SHA3_256" flag:"hash-algo" info:"Hash algorithm of the public key"`
+	Hex       bool   `default:"false" flag:"hex" info:"Message is hex encoded"`
+}
+
+var verifySignatureFlags = flagsVerifySignature{}
+
+var VerifySignatureCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "verify-signature <message> <signature> [<signature> ...]",
+		Short: "Verify message signatures",
+		Example: `flow keys verify-signature "I own this account" 0:a1b2...e3f4 1:c5d6...a7b8 --address 0xf8d6e0586b0a20c7
+
+flow keys verify-signature "I own this account" a1b2...e3f4 --public-key 8a73...1dfe`,
+		Args: cobra.MinimumNArgs(2),
+	},
+	Flags: &verifySignatureFlags,
+	Run:   verifySignature,
+}
+
+func verifySignature(
+	args []string,
+	_ flowkit.ReaderWriter,
+	_ command.GlobalFlags,
+	services *services.Services,
+) (command.Result, error) {
+	message, err := decodeMessage(args[0], verifySignatureFlags.Hex)
+	if err != nil {
+		return nil, err
+	}
+
+	signatures, err := parseKeySignatures(args[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	if verifySignatureFlags.PublicKey != "" {
+		if len(signatures) != 1 {
+			return nil, fmt.Errorf("only one signature can be verified with a public key")
+		}
+
+		sigAlgo := crypto.StringToSignatureAlgorithm(verifySignatureFlags.SigAlgo)
+		if sigAlgo == crypto.UnknownSignatureAlgorithm {
+			return nil, fmt.Errorf("invalid signature algorithm: %s", verifySignatureFlags.SigAlgo)
+		}
+
+		hashAlgo := crypto.StringToHashAlgorithm(verifySignatureFlags.HashAlgo)
+		if hashAlgo == crypto.UnknownHashAlgorithm {
+			return nil, fmt.Errorf("invalid hash algorithm: %s", verifySignatureFlags.HashAlgo)
+		}
+
+		publicKey, err := crypto.DecodePublicKeyHex(sigAlgo, strings.TrimPrefix(verifySignatureFlags.PublicKey, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("failed decoding public key: %w", err)
+		}
+
+		valid, err := services.Keys.VerifySignature(publicKey, hashAlgo, message, signatures[0].Signature)
+		if err != nil {
+			return nil, err
+		}
+
+		return &VerificationResult{
+			publicKey: publicKey,
+			valid:     valid,
+		}, nil
+	}
+
+	address, valid := util.ParseAddress(verifySignatureFlags.Address)
+	if !valid {
+		return nil, fmt.Errorf("provide a valid account address or public key used for verification")
+	}
+
+	verification, err := services.Keys.VerifyAccountSignatures(address, message, signatures)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VerificationResult{
+		verification: verification,
+		valid:        verification.Valid(),
+	}, nil
+}
+
+// parseKeySignatures parses signatures in <key index>:<signature> format, key index defaults to 0.
+func parseKeySignatures(inputs []string) ([]services.KeySignature, error) {
+	signatures := make([]services.KeySignature, 0, len(inputs))
+
+	for _, in := range inputs {
+		index := 0
+		sig := in
+
+		if parts := strings.SplitN(in, ":", 2); len(parts) == 2 {
+			var err error
+			index, err = strconv.Atoi(parts[0])
+			if err != nil {
+				return nil, fmt.Errorf("invalid key index in signature %s, correct format is: <key index>:<signature>", in)
+			}
+			sig = parts[1]
+		}
+
+		decoded, err := hex.DecodeString(strings.TrimPrefix(sig, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode signature %s: %w", in, err)
+		}
+
+		signatures = append(signatures, services.KeySignature{
+			KeyIndex:  index,
+			Signature: decoded,
+		})
+	}
+
+	return signatures, nil
+}
@@ -0,0 +1,289 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package quick
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+// benchPercentiles are the latency percentiles included in the benchmark result.
+var benchPercentiles = []float64{50, 90, 95, 99}
+
+type flagsBench struct {
+	ArgsJSON    string        `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
+	Signers     []string      `default:"emulator-account" flag:"signer" info:"Account names from configuration used to sign the transactions in turns"`
+	GasLimit    uint64        `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+	Count       int           `default:"100" flag:"count" info:"Number of transactions to send"`
+	Concurrency int           `default:"10" flag:"concurrency" info:"Maximum number of transactions in flight at the same time"`
+	Rate        float64       `default:"0" flag:"rate" info:"Maximum number of transactions started per second, unlimited if zero"`
+	Computation bool          `default:"false" flag:"computation" info:"Estimate the computation used on an in-process emulator, only for accounts on the emulator network"`
+	Timeout     time.Duration `default:"10m" flag:"timeout" info:"Maximum time to wait for each transaction to be sealed, the default is about the transaction expiry window"`
+}
+
+var benchFlags = flagsBench{}
+
+var BenchCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "bench <code filename> [<argument> <argument> ...]",
+		Short: "Send a transaction many times and measure latency and throughput",
+		Example: `flow bench tx.cdc "Hello" --count 500 --concurrency 20
+
+flow bench tx.cdc --signer alice --signer bob --rate 10 --network testnet -o json`,
+		Args: cobra.MinimumNArgs(1),
+	},
+	Flags: &benchFlags,
+	RunS:  bench,
+}
+
+func bench(
+	args []string,
+	readerWriter flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	services *services.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	codeFilename := args[0]
+
+	code, err := readerWriter.ReadFile(codeFilename)
+	if err != nil {
+		return nil, fmt.Errorf("error loading transaction file: %w", err)
+	}
+
+	addresses := state.AddressResolver(globalFlags.Network)
+	var txArgs []cadence.Value
+	if benchFlags.ArgsJSON != "" {
+		txArgs, err = flowkit.ParseArgumentsWithResolver(nil, benchFlags.ArgsJSON, addresses)
+	} else {
+		txArgs, err = flowkit.NewArgumentParserWithReader(codeFilename, code, readerWriter).
+			SetAddressResolver(addresses).
+			Parse(args[1:])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing transaction arguments: %w", err)
+	}
+
+	signers := make([]*flowkit.Account, 0, len(benchFlags.Signers))
+	for _, name := range benchFlags.Signers {
+		signer, err := state.Accounts().ByName(name)
+		if err != nil {
+			return nil, err
+		}
+		signers = append(signers, signer)
+	}
+
+	result, err := services.Transactions.Bench(
+		signers,
+		code,
+		codeFilename,
+		benchFlags.GasLimit,
+		txArgs,
+		globalFlags.Network,
+		benchFlags.Count,
+		benchFlags.Concurrency,
+		benchFlags.Rate,
+		benchFlags.Timeout,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	benchResult := &BenchResult{
+		result:  result,
+		signers: benchFlags.Signers,
+	}
+
+	if benchFlags.Computation {
+		benchResult.computationUsed, benchResult.computationErr = estimateComputation(
+			services, signers[0], code, codeFilename, txArgs, globalFlags.Network,
+		)
+	}
+
+	return benchResult, nil
+}
+
+// estimateComputation estimates the computation used by the transaction on an in-process emulator
+// with the copied signer account, the same way as the estimate gas flag of the send command.
+func estimateComputation(
+	services *services.Services,
+	signer *flowkit.Account,
+	code []byte,
+	codeFilename string,
+	args []cadence.Value,
+	network string,
+) (uint64, error) {
+	fork, roles, err := services.Transactions.Fork(
+		flowkit.NewTransactionSingleAccountRole(signer),
+		code,
+		codeFilename,
+		network,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	dryRun, err := fork.DryRun(
+		roles,
+		code,
+		codeFilename,
+		benchFlags.GasLimit,
+		args,
+		network,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	if dryRun.ComputationUsed == 0 {
+		return 0, fmt.Errorf("transaction failed on the emulator: %w", dryRun.Result.Error)
+	}
+
+	return dryRun.ComputationUsed, nil
+}
+
+// BenchResult represents the measurements of a benchmark.
+type BenchResult struct {
+	result          *services.BenchResult
+	signers         []string
+	computationUsed uint64
+	computationErr  error
+}
+
+// latencyStats returns the percentiles, mean and maximum of the sorted latencies in milliseconds.
+func latencyStats(latencies []time.Duration) map[string]float64 {
+	stats := make(map[string]float64)
+	for _, p := range benchPercentiles {
+		stats[fmt.Sprintf("p%.0f", p)] = milliseconds(services.LatencyPercentile(latencies, p))
+	}
+
+	var total time.Duration
+	for _, l := range latencies {
+		total += l
+	}
+
+	stats["mean"], stats["max"] = 0, 0
+	if len(latencies) > 0 {
+		stats["mean"] = milliseconds(total / time.Duration(len(latencies)))
+		stats["max"] = milliseconds(latencies[len(latencies)-1])
+	}
+
+	return stats
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func (r *BenchResult) JSON() interface{} {
+	result := make(map[string]interface{})
+	result["transactions"] = len(r.result.Samples)
+	result["succeeded"] = len(r.result.Samples) - r.result.Failed()
+	result["failed"] = r.result.Failed()
+	result["durationMs"] = milliseconds(r.result.Duration)
+	result["throughput"] = r.result.Throughput()
+	result["signers"] = r.signers
+	result["proposalKeys"] = r.result.ProposalKeys
+	result["submitLatencyMs"] = latencyStats(r.result.SubmitLatencies())
+	result["sealLatencyMs"] = latencyStats(r.result.SealLatencies())
+	result["failures"] = r.result.Failures()
+
+	if r.computationUsed > 0 {
+		result["computationUsed"] = r.computationUsed
+	} else {
+		result["computationUsed"] = nil
+	}
+
+	return result
+}
+
+func (r *BenchResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Transactions\t%d\n", len(r.result.Samples))
+	_, _ = fmt.Fprintf(writer, "Succeeded\t%d\n", len(r.result.Samples)-r.result.Failed())
+	_, _ = fmt.Fprintf(writer, "Failed\t%d\n", r.result.Failed())
+	_, _ = fmt.Fprintf(writer, "Duration\t%s\n", r.result.Duration.Round(time.Millisecond))
+	_, _ = fmt.Fprintf(writer, "Throughput\t%.2f tx/s\n", r.result.Throughput())
+	_, _ = fmt.Fprintf(writer, "Proposal Keys\t%d\n", r.result.ProposalKeys)
+
+	if r.computationUsed > 0 {
+		_, _ = fmt.Fprintf(writer, "Computation Used\t%d\n", r.computationUsed)
+	} else if r.computationErr != nil {
+		_, _ = fmt.Fprintf(writer, "Computation Used\tunknown, %s\n", r.computationErr)
+	}
+
+	_, _ = fmt.Fprintf(writer, "\nLatency\tp50\tp90\tp95\tp99\tmax\n")
+	for _, l := range []struct {
+		name      string
+		latencies []time.Duration
+	}{
+		{"Submit", r.result.SubmitLatencies()},
+		{"Seal", r.result.SealLatencies()},
+	} {
+		_, _ = fmt.Fprintf(writer, "%s", l.name)
+		for _, p := range benchPercentiles {
+			_, _ = fmt.Fprintf(writer, "\t%s", services.LatencyPercentile(l.latencies, p).Round(time.Millisecond))
+		}
+
+		max := time.Duration(0)
+		if len(l.latencies) > 0 {
+			max = l.latencies[len(l.latencies)-1]
+		}
+		_, _ = fmt.Fprintf(writer, "\t%s\n", max.Round(time.Millisecond))
+	}
+
+	failures := r.result.Failures()
+	if len(failures) > 0 {
+		reasons := make([]string, 0, len(failures))
+		for reason := range failures {
+			reasons = append(reasons, reason)
+		}
+		sort.Strings(reasons)
+
+		_, _ = fmt.Fprintf(writer, "\nFailures:\n")
+		for _, reason := range reasons {
+			_, _ = fmt.Fprintf(writer, "    %d\t%s\n", failures[reason], reason)
+		}
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *BenchResult) Oneliner() string {
+	return fmt.Sprintf(
+		"Transactions: %d, Failed: %d, Throughput: %.2f tx/s, Seal p50: %s, Seal p99: %s",
+		len(r.result.Samples),
+		r.result.Failed(),
+		r.result.Throughput(),
+		services.LatencyPercentile(r.result.SealLatencies(), 50).Round(time.Millisecond),
+		services.LatencyPercentile(r.result.SealLatencies(), 99).Round(time.Millisecond),
+	)
+}
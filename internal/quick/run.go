@@ -0,0 +1,289 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package quick
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/scripts"
+	"github.com/onflow/flow-cli/internal/transactions"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+// defaultRunGasLimit is used for named transactions without a configured gas limit.
+const defaultRunGasLimit = 1000
+
+type flagsRun struct {
+	List     bool     `default:"false" flag:"list" info:"List the transactions and scripts defined in the configuration"`
+	ArgsJSON string   `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
+	Signer   string   `default:"" flag:"signer" info:"Account name from configuration used to sign the transaction, overrides the configured signer"`
+	GasLimit uint64   `default:"0" flag:"gas-limit" info:"transaction gas limit, overrides the configured gas limit"`
+	Include  []string `default:"" flag:"include" info:"Fields to include in the output"`
+	Exclude  []string `default:"" flag:"exclude" info:"Fields to exclude from the output (events)"`
+}
+
+var runFlags = flagsRun{}
+
+var RunCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "run <name> [<argument> <argument> ...]",
+		Short:   "Run a transaction or a script defined in the configuration",
+		Example: "flow run transfer 10.0 0x01cf0e2f2f715450\nflow run --list",
+		Args:    cobra.ArbitraryArgs,
+	},
+	Flags: &runFlags,
+	RunS:  run,
+}
+
+func run(
+	args []string,
+	readerWriter flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	services *services.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	if runFlags.List {
+		return listRunnable(readerWriter, state)
+	}
+
+	if len(args) == 0 {
+		return nil, fmt.Errorf("provide the name of a transaction or a script, use --list to list them")
+	}
+	name := args[0]
+
+	if tx, err := state.Config().Transactions.ByName(name); err == nil {
+		code, err := readerWriter.ReadFile(tx.Source)
+		if err != nil {
+			return nil, fmt.Errorf("error loading transaction file: %w", err)
+		}
+
+		txArgs, err := runArguments(
+			tx.Source,
+			code,
+			args[1:],
+			tx.ArgsByNetwork(globalFlags.Network),
+			readerWriter,
+			state.AddressResolver(globalFlags.Network),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing transaction arguments: %w", err)
+		}
+
+		signerName := config.DefaultEmulatorServiceAccountName
+		if runFlags.Signer != "" {
+			signerName = runFlags.Signer
+		} else if tx.Signer != "" {
+			signerName = tx.Signer
+		}
+
+		signer, err := state.Accounts().ByName(signerName)
+		if err != nil {
+			return nil, err
+		}
+
+		gasLimit := uint64(defaultRunGasLimit)
+		if runFlags.GasLimit != 0 {
+			gasLimit = runFlags.GasLimit
+		} else if tx.GasLimit != 0 {
+			gasLimit = tx.GasLimit
+		}
+
+		sentTx, result, err := services.Transactions.Send(signer, code, tx.Source, gasLimit, txArgs, globalFlags.Network)
+		if err != nil {
+			return nil, err
+		}
+
+		return transactions.NewTransactionResult(sentTx, result, runFlags.Include, runFlags.Exclude), nil
+	}
+
+	script, err := state.Config().Scripts.ByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("transaction or script named %s does not exist in configuration", name)
+	}
+
+	code, err := readerWriter.ReadFile(script.Source)
+	if err != nil {
+		return nil, fmt.Errorf("error loading script file: %w", err)
+	}
+
+	scriptArgs, err := runArguments(
+		script.Source,
+		code,
+		args[1:],
+		script.ArgsByNetwork(globalFlags.Network),
+		readerWriter,
+		state.AddressResolver(globalFlags.Network),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing script arguments: %w", err)
+	}
+
+	value, err := services.Scripts.Execute(code, scriptArgs, script.Source, globalFlags.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	return &scripts.ScriptResult{Value: value}, nil
+}
+
+// runArguments parses the command or JSON arguments, if none are provided the default arguments are used.
+func runArguments(
+	filename string,
+	code []byte,
+	args []string,
+	defaults []cadence.Value,
+	readerWriter flowkit.ReaderWriter,
+	addresses flowkit.AddressResolver,
+) ([]cadence.Value, error) {
+	if runFlags.ArgsJSON != "" {
+		return flowkit.ParseArgumentsWithResolver(nil, runFlags.ArgsJSON, addresses)
+	}
+
+	if len(args) == 0 && defaults != nil {
+		return defaults, nil
+	}
+
+	return flowkit.NewArgumentParserWithReader(filename, code, readerWriter).SetAddressResolver(addresses).Parse(args)
+}
+
+// listRunnable lists the transactions and scripts from the configuration with their parameters.
+func listRunnable(readerWriter flowkit.ReaderWriter, state *flowkit.State) (command.Result, error) {
+	entries := make([]*runnable, 0)
+
+	parameters := func(source string) []string {
+		code, err := readerWriter.ReadFile(source)
+		if err != nil {
+			return nil
+		}
+
+		params, err := flowkit.ParameterDeclarations(code)
+		if err != nil {
+			return nil
+		}
+
+		return params
+	}
+
+	for _, tx := range state.Config().Transactions {
+		entries = append(entries, &runnable{
+			Name:       tx.Name,
+			Kind:       "transaction",
+			Source:     tx.Source,
+			Signer:     tx.Signer,
+			GasLimit:   tx.GasLimit,
+			Parameters: parameters(tx.Source),
+			Networks:   argNetworks(tx.Args),
+		})
+	}
+
+	for _, script := range state.Config().Scripts {
+		entries = append(entries, &runnable{
+			Name:       script.Name,
+			Kind:       "script",
+			Source:     script.Source,
+			Parameters: parameters(script.Source),
+			Networks:   argNetworks(script.Args),
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Kind != entries[j].Kind {
+			return entries[i].Kind == "transaction"
+		}
+		return entries[i].Name < entries[j].Name
+	})
+
+	return &RunListResult{entries}, nil
+}
+
+// argNetworks returns the networks with default arguments.
+func argNetworks(args map[string][]cadence.Value) []string {
+	networks := make([]string, 0, len(args))
+	for network := range args {
+		networks = append(networks, network)
+	}
+	sort.Strings(networks)
+
+	return networks
+}
+
+type runnable struct {
+	Name       string   `json:"name"`
+	Kind       string   `json:"kind"`
+	Source     string   `json:"source"`
+	Signer     string   `json:"signer,omitempty"`
+	GasLimit   uint64   `json:"gasLimit,omitempty"`
+	Parameters []string `json:"parameters"`
+	Networks   []string `json:"defaultArgs"`
+}
+
+// RunListResult represents the transactions and scripts defined in the configuration.
+type RunListResult struct {
+	entries []*runnable
+}
+
+func (r *RunListResult) JSON() interface{} {
+	return r.entries
+}
+
+func (r *RunListResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	if len(r.entries) == 0 {
+		_, _ = fmt.Fprintf(writer, "No transactions or scripts defined in the configuration\n")
+	}
+
+	for _, e := range r.entries {
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\n", e.Name, e.Kind, e.Source)
+		_, _ = fmt.Fprintf(writer, "\tParameters\t(%s)\n", strings.Join(e.Parameters, ", "))
+		if e.Signer != "" {
+			_, _ = fmt.Fprintf(writer, "\tSigner\t%s\n", e.Signer)
+		}
+		if e.GasLimit != 0 {
+			_, _ = fmt.Fprintf(writer, "\tGas Limit\t%d\n", e.GasLimit)
+		}
+		if len(e.Networks) > 0 {
+			_, _ = fmt.Fprintf(writer, "\tDefault Arguments\t%s\n", strings.Join(e.Networks, ", "))
+		}
+		_, _ = fmt.Fprintf(writer, "\n")
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *RunListResult) Oneliner() string {
+	names := make([]string, 0, len(r.entries))
+	for _, e := range r.entries {
+		names = append(names, e.Name)
+	}
+
+	return strings.Join(names, ", ")
+}
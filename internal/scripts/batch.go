@@ -0,0 +1,364 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scripts
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+const (
+	formatCSV    = "csv"
+	formatNDJSON = "ndjson"
+)
+
+// argumentRow is a set of script arguments read from a line of the arguments file.
+type argumentRow struct {
+	line   int
+	fields []string        // arguments as read from CSV or formatted as Cadence values
+	raw    json.RawMessage // arguments as read from NDJSON
+	args   []cadence.Value
+}
+
+// executeBatch executes the script with every set of arguments in the arguments file
+// and streams the results to the output as they become available.
+func executeBatch(
+	code []byte,
+	filename string,
+	readerWriter flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	services *services.Services,
+) (command.Result, error) {
+	inputFormat, err := argsFileFormat(scriptFlags.ArgsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	outputFormat := strings.ToLower(scriptFlags.ResultsFormat)
+	if outputFormat == "" {
+		outputFormat = inputFormat
+	}
+	if outputFormat != formatCSV && outputFormat != formatNDJSON {
+		return nil, fmt.Errorf("invalid results format %s, valid formats are: csv, ndjson", scriptFlags.ResultsFormat)
+	}
+
+	if globalFlags.Filter != "" {
+		return nil, fmt.Errorf("filter flag can not be used together with a CSV or NDJSON arguments file")
+	}
+
+	data, err := readerWriter.ReadFile(scriptFlags.ArgsFile)
+	if err != nil {
+		return nil, fmt.Errorf("error loading arguments file: %w", err)
+	}
+
+	parser := flowkit.NewArgumentParserWithReader(filename, code, readerWriter).
+		SetAddressResolver(services.Project.AddressResolver(globalFlags.Network))
+
+	var rows []*argumentRow
+	if inputFormat == formatCSV {
+		rows, err = readCSVRows(data, parser)
+	} else {
+		rows, err = readNDJSONRows(data, parser)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing arguments file: %w", err)
+	}
+
+	args := make([][]cadence.Value, 0, len(rows))
+	for _, row := range rows {
+		args = append(args, row.args)
+	}
+
+	results, err := services.Scripts.ExecuteBatch(code, args, filename, globalFlags.Network, scriptFlags.Concurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	// rows are written to the save file instead of the output, the summary is written to stderr
+	// so it isn't mixed with the rows
+	out := os.Stdout
+	if globalFlags.Save != "" {
+		out, err = os.Create(globalFlags.Save)
+		if err != nil {
+			return nil, fmt.Errorf("error creating results file: %w", err)
+		}
+		defer out.Close()
+	}
+
+	writer := newRowWriter(out, outputFormat, parser.Names())
+	executed, failed := 0, 0
+	for result := range results {
+		executed++
+		if result.Err != nil {
+			failed++
+		}
+
+		err = writer.write(rows[result.Index], result)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, _ = fmt.Fprintf(os.Stderr, "Executed %d scripts, %d failed\n", executed, failed)
+	if globalFlags.Save != "" {
+		_, _ = fmt.Fprintf(os.Stderr, "%s results saved to: %s\n", output.SaveEmoji(), globalFlags.Save)
+	}
+
+	return nil, nil
+}
+
+// isArgumentSets returns whether the JSON data is an array of argument sets, like an NDJSON arguments file
+// written as a JSON array, rather than a single set of arguments.
+func isArgumentSets(data []byte) bool {
+	var sets []json.RawMessage
+	err := json.Unmarshal(data, &sets)
+	if err != nil || len(sets) == 0 {
+		return false
+	}
+
+	for _, set := range sets {
+		set = bytes.TrimSpace(set)
+		if len(set) == 0 || (set[0] != '[' && set[0] != '{') {
+			return false
+		}
+
+		// JSON-Cadence values are objects with a type
+		var value struct {
+			Type string `json:"type"`
+		}
+		if set[0] == '{' && json.Unmarshal(set, &value) == nil && value.Type != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// argsFileFormat returns the format of the arguments file by its extension.
+func argsFileFormat(filename string) (string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return formatCSV, nil
+	case ".ndjson", ".jsonl":
+		return formatNDJSON, nil
+	}
+
+	return "", fmt.Errorf("unsupported arguments file %s, use a .json, .csv or .ndjson file", filename)
+}
+
+// readCSVRows reads a set of arguments from each CSV record, a first record equal to the parameter names is skipped.
+//
+// Records are split by lines, joining lines inside quoted fields, so the line the record starts on is known.
+func readCSVRows(data []byte, parser *flowkit.ArgumentParser) ([]*argumentRow, error) {
+	names := parser.Names()
+	rows := make([]*argumentRow, 0)
+
+	lines := strings.SplitAfter(string(data), "\n")
+	record, start := "", 0
+	for i, text := range lines {
+		if record == "" {
+			if strings.TrimRight(text, "\r\n") == "" { // empty lines are skipped like the CSV reader does
+				continue
+			}
+			start = i + 1
+		}
+
+		record += text
+		if strings.Count(record, `"`)%2 != 0 && i < len(lines)-1 { // the record continues in a quoted field
+			continue
+		}
+
+		fields, err := readCSVRecord(record, start)
+		record = ""
+		if err != nil {
+			return nil, err
+		}
+
+		if len(rows) == 0 && start == 1 && equalFields(fields, names) {
+			continue
+		}
+
+		args, err := parser.Parse(fields)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", start, err)
+		}
+
+		rows = append(rows, &argumentRow{
+			line:   start,
+			fields: fields,
+			args:   args,
+		})
+	}
+
+	return rows, nil
+}
+
+// readCSVRecord reads the fields of a single CSV record starting on the line.
+func readCSVRecord(record string, line int) ([]string, error) {
+	reader := csv.NewReader(strings.NewReader(record))
+	reader.FieldsPerRecord = -1
+
+	fields, err := reader.Read()
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		parseErr.StartLine += line - 1
+		parseErr.Line += line - 1
+	}
+
+	return fields, err
+}
+
+// readNDJSONRows reads a set of arguments from each non-empty line, in the JSON format of ArgumentParser.ParseJSON.
+func readNDJSONRows(data []byte, parser *flowkit.ArgumentParser) ([]*argumentRow, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+
+	rows := make([]*argumentRow, 0)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		args, err := parser.ParseJSON([]byte(text))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		fields := make([]string, 0, len(args))
+		for _, arg := range args {
+			fields = append(fields, arg.String())
+		}
+
+		rows = append(rows, &argumentRow{
+			line:   line,
+			fields: fields,
+			raw:    json.RawMessage(text),
+			args:   args,
+		})
+	}
+
+	return rows, scanner.Err()
+}
+
+func equalFields(fields []string, names []string) bool {
+	if len(fields) != len(names) || len(names) == 0 {
+		return false
+	}
+
+	for i := range fields {
+		if strings.TrimSpace(fields[i]) != names[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// rowWriter writes the result of each set of arguments together with the arguments in CSV or NDJSON format.
+type rowWriter struct {
+	format string
+	names  []string
+	csv    *csv.Writer
+	out    io.Writer
+	header bool
+}
+
+func newRowWriter(out io.Writer, format string, names []string) *rowWriter {
+	return &rowWriter{
+		format: format,
+		names:  names,
+		csv:    csv.NewWriter(out),
+		out:    out,
+	}
+}
+
+func (w *rowWriter) write(row *argumentRow, result *services.ScriptBatchResult) error {
+	if w.format == formatCSV {
+		return w.writeCSV(row, result)
+	}
+
+	return w.writeNDJSON(row, result)
+}
+
+func (w *rowWriter) writeCSV(row *argumentRow, result *services.ScriptBatchResult) error {
+	if !w.header {
+		w.header = true
+		header := append([]string{"line"}, w.names...)
+		if err := w.csv.Write(append(header, "value", "error")); err != nil {
+			return err
+		}
+	}
+
+	value, errMessage := "", ""
+	if result.Err != nil {
+		errMessage = result.Err.Error()
+	} else if result.Value != nil {
+		value = result.Value.String()
+	}
+
+	record := append([]string{strconv.Itoa(row.line)}, row.fields...)
+	if err := w.csv.Write(append(record, value, errMessage)); err != nil {
+		return err
+	}
+
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+func (w *rowWriter) writeNDJSON(row *argumentRow, result *services.ScriptBatchResult) error {
+	var input interface{} = row.fields
+	if row.raw != nil {
+		input = row.raw
+	}
+
+	record := map[string]interface{}{
+		"line":  row.line,
+		"input": input,
+	}
+	if result.Err != nil {
+		record["error"] = result.Err.Error()
+	} else if result.Value != nil {
+		record["value"] = command.CadenceJSON(result.Value, scriptFlags.CadenceJSON)
+	}
+
+	out, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w.out, "%s\n", out)
+	return err
+}
@@ -20,6 +20,9 @@ package scripts
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/onflow/cadence"
 	"github.com/spf13/cobra"
@@ -30,8 +33,15 @@ import (
 )
 
 type flagsScripts struct {
-	ArgsJSON string   `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
-	Arg      []string `default:"" flag:"arg" info:"⚠️  Deprecated: use command arguments"`
+	ArgsJSON      string        `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
+	Arg           []string      `default:"" flag:"arg" info:"⚠️  Deprecated: use command arguments"`
+	ArgsFile      string        `default:"" flag:"args-file" info:"JSON file with the script arguments, or CSV or NDJSON file with a set of arguments on each line to execute the script for every set"`
+	Concurrency   int           `default:"10" flag:"concurrency" info:"Maximum number of scripts executed at the same time with an arguments file"`
+	ResultsFormat string        `default:"" flag:"results-format" info:"Format of the results streamed with an arguments file (csv, ndjson), defaults to the arguments file format"`
+	Watch         bool          `default:"false" flag:"watch" info:"Execute the script again on every new sealed block or change of the script and imported files, printing value changes"`
+	WatchInterval time.Duration `default:"1s" flag:"watch-interval" info:"Interval of checking for new blocks and file changes in watch mode"`
+	InProcess     bool          `default:"false" flag:"in-process" info:"Execute the script on an in-process emulator with the imported contracts copied from the network, showing the script logs"`
+	CadenceJSON   string        `default:"full" flag:"cadence-json" info:"Encoding of Cadence values in the JSON output (full, plain)"`
 }
 
 var scriptFlags = flagsScripts{}
@@ -60,22 +70,67 @@ func execute(
 		return nil, fmt.Errorf("error loading script file: %w", err)
 	}
 
+	err = command.ValidateCadenceJSON(scriptFlags.CadenceJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	if scriptFlags.ArgsFile != "" && scriptFlags.Watch {
+		return nil, fmt.Errorf("args-file flag can not be used together with watch flag")
+	}
+
+	if scriptFlags.InProcess && scriptFlags.Watch {
+		return nil, fmt.Errorf("in-process flag can not be used together with watch flag")
+	}
+
+	var argsFile []byte
+	if scriptFlags.ArgsFile != "" {
+		if len(args) > 1 || scriptFlags.ArgsJSON != "" || len(scriptFlags.Arg) != 0 {
+			return nil, fmt.Errorf("args-file flag can not be used together with other script arguments")
+		}
+
+		if strings.ToLower(filepath.Ext(scriptFlags.ArgsFile)) != ".json" {
+			if scriptFlags.InProcess {
+				return nil, fmt.Errorf("in-process flag can not be used together with a CSV or NDJSON arguments file")
+			}
+			return executeBatch(code, filename, readerWriter, globalFlags, services)
+		}
+
+		argsFile, err = readerWriter.ReadFile(scriptFlags.ArgsFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading arguments file: %w", err)
+		}
+	}
+
 	if len(scriptFlags.Arg) != 0 {
 		fmt.Println("⚠️  DEPRECATION WARNING: use script arguments as command arguments: execute <filename> [<argument> <argument> ...]")
 	}
 
-	var scriptArgs []cadence.Value
-	if scriptFlags.ArgsJSON != "" || len(scriptFlags.Arg) != 0 {
-		scriptArgs, err = flowkit.ParseArguments(scriptFlags.Arg, scriptFlags.ArgsJSON)
-	} else {
-		scriptArgs, err = flowkit.ParseArgumentsWithoutType(filename, code, args[1:])
+	if scriptFlags.Watch {
+		return watch(code, filename, args[1:], readerWriter, globalFlags, services)
 	}
 
+	scriptArgs, err := parseScriptArgs(
+		filename,
+		code,
+		args[1:],
+		argsFile,
+		readerWriter,
+		services.Project.AddressResolver(globalFlags.Network),
+	)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing script arguments: %w", err)
+		return nil, err
 	}
 
-	value, err := services.Scripts.Execute(
+	scripts := services.Scripts
+	if scriptFlags.InProcess {
+		scripts, err = services.Scripts.Fork(code, filename, globalFlags.Network)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	value, logs, err := scripts.ExecuteWithLogs(
 		code,
 		scriptArgs,
 		filename,
@@ -85,5 +140,38 @@ func execute(
 		return nil, err
 	}
 
-	return &ScriptResult{value}, nil
+	return &ScriptResult{Value: value, logs: logs, cadenceJSON: scriptFlags.CadenceJSON}, nil
+}
+
+// parseScriptArgs parses the script arguments from the arguments file, the flags or the command arguments.
+func parseScriptArgs(
+	filename string,
+	code []byte,
+	args []string,
+	argsFile []byte,
+	readerWriter flowkit.ReaderWriter,
+	addresses flowkit.AddressResolver,
+) ([]cadence.Value, error) {
+	parser := flowkit.NewArgumentParserWithReader(filename, code, readerWriter).SetAddressResolver(addresses)
+
+	var scriptArgs []cadence.Value
+	var err error
+	if argsFile != nil {
+		scriptArgs, err = parser.ParseJSON(argsFile)
+		if err != nil && isArgumentSets(argsFile) {
+			return nil, fmt.Errorf(
+				"JSON arguments file contains many sets of arguments, use a CSV or NDJSON arguments file to execute the script for every set",
+			)
+		}
+	} else if scriptFlags.ArgsJSON != "" || len(scriptFlags.Arg) != 0 {
+		scriptArgs, err = flowkit.ParseArgumentsWithResolver(scriptFlags.Arg, scriptFlags.ArgsJSON, addresses)
+	} else {
+		scriptArgs, err = parser.Parse(args)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error parsing script arguments: %w", err)
+	}
+
+	return scriptArgs, nil
 }
@@ -20,13 +20,13 @@ package scripts
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/onflow/cadence"
-	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"github.com/spf13/cobra"
 
+	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/pkg/flowkit/util"
 )
 
@@ -42,12 +42,23 @@ func init() {
 
 type ScriptResult struct {
 	cadence.Value
+	logs        []string
+	cadenceJSON string // encoding of the value in the JSON output, full or plain
 }
 
+// JSON returns the value in JSON-Cadence or plain JSON format, if logs were captured the value is
+// returned together with the logs.
 func (r *ScriptResult) JSON() interface{} {
-	return json.RawMessage(
-		jsoncdc.MustEncode(r.Value),
-	)
+	value := command.CadenceJSON(r.Value, r.cadenceJSON)
+
+	if r.logs == nil {
+		return value
+	}
+
+	return map[string]interface{}{
+		"value": value,
+		"logs":  r.logs,
+	}
 }
 
 func (r *ScriptResult) String() string {
@@ -56,6 +67,10 @@ func (r *ScriptResult) String() string {
 
 	_, _ = fmt.Fprintf(writer, "Result: %s\n", r.Value)
 
+	if r.logs != nil {
+		_, _ = fmt.Fprintf(writer, "\nLogs:\t%s\n", logsString(r.logs))
+	}
+
 	_ = writer.Flush()
 
 	return b.String()
@@ -64,3 +79,17 @@ func (r *ScriptResult) String() string {
 func (r *ScriptResult) Oneliner() string {
 	return r.Value.String()
 }
+
+// logsString formats the logs with one log per line.
+func logsString(logs []string) string {
+	if len(logs) == 0 {
+		return "None"
+	}
+
+	var b strings.Builder
+	for _, log := range logs {
+		_, _ = fmt.Fprintf(&b, "\n    %s", log)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,247 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scripts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/contracts"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+// watcher executes the script again when a new block is sealed or the watched files change.
+type watcher struct {
+	filename     string
+	args         []string
+	readerWriter flowkit.ReaderWriter
+	network      string
+	format       string
+	services     *services.Services
+
+	code       []byte
+	scriptArgs []cadence.Value
+	files      map[string]string // watched file path to its content
+	height     uint64
+	output     string // value or error of the last execution
+	executions int
+	changes    int
+}
+
+// watch executes the script on every new sealed block or file change until interrupted,
+// printing the value each time it changes.
+//
+// Errors getting the latest block or reading the files, for example while a file is replaced
+// by an editor, are printed and watching continues.
+func watch(
+	code []byte,
+	filename string,
+	args []string,
+	readerWriter flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	services *services.Services,
+) (command.Result, error) {
+	w := &watcher{
+		filename:     filename,
+		args:         args,
+		readerWriter: readerWriter,
+		network:      globalFlags.Network,
+		format:       strings.ToLower(globalFlags.Format),
+		services:     services,
+		files:        make(map[string]string),
+	}
+
+	_, err := w.loadFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	ticker := time.NewTicker(scriptFlags.WatchInterval)
+	defer ticker.Stop()
+
+	for {
+		err := w.poll()
+		if err != nil {
+			w.printError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return &WatchResult{executions: w.executions, changes: w.changes}, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll executes the script if it wasn't executed yet, a new block was sealed or the files changed.
+func (w *watcher) poll() error {
+	height, err := w.services.Blocks.GetLatestBlockHeight()
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+
+	changed, err := w.loadFiles()
+	if err != nil {
+		return err
+	}
+
+	if w.executions == 0 || changed || height != w.height {
+		w.height = height
+		w.execute()
+	}
+
+	return nil
+}
+
+// loadFiles reads the script and the files it imports, including files imported by imported files,
+// and returns true if any of them changed since they were last read.
+func (w *watcher) loadFiles() (bool, error) {
+	files := make(map[string]string)
+	queue := []string{w.filename}
+
+	for len(queue) > 0 {
+		path := queue[0]
+		queue = queue[1:]
+		if _, ok := files[path]; ok {
+			continue
+		}
+
+		content, err := w.readerWriter.ReadFile(path)
+		if err != nil {
+			return false, fmt.Errorf("error loading file: %w", err)
+		}
+		files[path] = string(content)
+
+		resolver, err := contracts.NewResolver(content)
+		if err != nil {
+			// keep watching until a syntax error in the file is fixed, the execution reports the error
+			continue
+		}
+		queue = append(queue, resolver.ImportedFiles(path)...)
+	}
+
+	changed := len(files) != len(w.files)
+	for path, content := range files {
+		if w.files[path] != content {
+			changed = true
+		}
+	}
+	w.files = files
+
+	if changed {
+		w.code = []byte(files[w.filename])
+		w.scriptArgs = nil
+	}
+
+	return changed, nil
+}
+
+// execute runs the script and prints the value or the error if it changed since the last execution.
+func (w *watcher) execute() {
+	w.executions++
+
+	var value cadence.Value
+	var err error
+	if w.scriptArgs == nil {
+		w.scriptArgs, err = parseScriptArgs(
+			w.filename,
+			w.code,
+			w.args,
+			nil,
+			w.readerWriter,
+			w.services.Project.AddressResolver(w.network),
+		)
+	}
+	if err == nil {
+		value, err = w.services.Scripts.Execute(w.code, w.scriptArgs, w.filename, w.network)
+	}
+
+	output := ""
+	if err != nil {
+		output = "error: " + err.Error()
+	} else {
+		output = value.String()
+	}
+
+	if output == w.output && w.executions > 1 {
+		return
+	}
+	w.output = output
+	w.changes++
+
+	timestamp := time.Now().UTC().Format(time.RFC3339)
+
+	if w.format == "json" {
+		record := map[string]interface{}{
+			"timestamp": timestamp,
+			"height":    w.height,
+		}
+		if err != nil {
+			record["error"] = err.Error()
+		} else {
+			record["value"] = command.CadenceJSON(value, scriptFlags.CadenceJSON)
+		}
+
+		out, _ := json.Marshal(record)
+		fmt.Printf("%s\n", out)
+		return
+	}
+
+	fmt.Printf("[%s] Block %d: %s\n", timestamp, w.height, output)
+}
+
+// printError prints an error that didn't stop watching to the standard error,
+// so it isn't mixed with the values.
+func (w *watcher) printError(err error) {
+	timestamp := time.Now().UTC().Format(time.RFC3339)
+	_, _ = fmt.Fprintf(os.Stderr, "[%s] %s, still watching\n", timestamp, err.Error())
+}
+
+// WatchResult is the summary of a script executed in watch mode.
+type WatchResult struct {
+	executions int
+	changes    int
+}
+
+func (r *WatchResult) JSON() interface{} {
+	return map[string]interface{}{
+		"executions": r.executions,
+		"changes":    r.changes,
+	}
+}
+
+func (r *WatchResult) String() string {
+	return fmt.Sprintf("Stopped watching after %d executions with %d value changes", r.executions, r.changes)
+}
+
+func (r *WatchResult) Oneliner() string {
+	return r.String()
+}
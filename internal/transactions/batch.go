@@ -0,0 +1,201 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+type flagsBatch struct {
+	Signer          string `default:"emulator-account" flag:"signer" info:"Account name from configuration used to sign transactions without a signer in the manifest"`
+	GasLimit        uint64 `default:"1000" flag:"gas-limit" info:"Gas limit of transactions without a gas limit in the manifest"`
+	Concurrency     int    `default:"4" flag:"concurrency" info:"Maximum number of transactions sent at the same time"`
+	ContinueOnError bool   `default:"false" flag:"continue-on-error" info:"Keep sending transactions not depending on a failed transaction"`
+}
+
+var batchFlags = flagsBatch{}
+
+var BatchCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "batch <manifest filename>",
+		Short: "Send transactions listed in a manifest",
+		Example: `flow transactions batch fixtures.yml --network testnet
+
+flow transactions batch fixtures.json --concurrency 8 --continue-on-error -o json --save report.json`,
+		Args: cobra.ExactArgs(1),
+	},
+	Flags: &batchFlags,
+	RunS:  batch,
+}
+
+func batch(
+	args []string,
+	readerWriter flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	services *services.Services,
+	_ *flowkit.State,
+) (command.Result, error) {
+	filename := args[0]
+
+	data, err := readerWriter.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error loading manifest: %w", err)
+	}
+
+	manifest, err := flowkit.ParseTransactionManifest(data, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tx := range manifest.Transactions {
+		if tx.Signer == "" {
+			tx.Signer = batchFlags.Signer
+		}
+		if tx.GasLimit == 0 {
+			tx.GasLimit = batchFlags.GasLimit
+		}
+	}
+
+	results, err := services.Transactions.SendManifest(
+		manifest,
+		globalFlags.Network,
+		batchFlags.Concurrency,
+		batchFlags.ContinueOnError,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ManifestResult{results: results}, nil
+}
+
+// ManifestResult represents the results of transactions sent from a manifest.
+type ManifestResult struct {
+	results []services.ManifestResult
+}
+
+func (r *ManifestResult) JSON() interface{} {
+	result := make([]interface{}, 0, len(r.results))
+
+	for _, res := range r.results {
+		item := make(map[string]interface{})
+		item["name"] = res.Name
+		item["skipped"] = res.Skipped
+		if res.Tx != nil {
+			item["id"] = res.Tx.ID().String()
+		}
+		if res.Result != nil {
+			item["status"] = res.Result.Status.String()
+			item["events"] = eventsJSON(res.Result.Events, "")
+			if res.Result.Error != nil {
+				item["error"] = res.Result.Error.Error()
+			}
+		}
+		if res.Err != nil {
+			item["error"] = res.Err.Error()
+		}
+
+		result = append(result, item)
+	}
+
+	return result
+}
+
+func (r *ManifestResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	failed, skipped := 0, 0
+	_, _ = fmt.Fprintf(writer, "Name\tID\tStatus\tEvents\n")
+	for _, res := range r.results {
+		id, status, events := "-", "-", "-"
+		if res.Tx != nil {
+			id = res.Tx.ID().String()
+		}
+		if res.Result != nil {
+			status = res.Result.Status.String()
+			events = fmt.Sprintf("%d", len(res.Result.Events))
+		}
+
+		errMsg := ""
+		if res.Err != nil {
+			errMsg = res.Err.Error()
+		} else if res.Result != nil && res.Result.Error != nil {
+			errMsg = res.Result.Error.Error()
+		}
+
+		if res.Skipped {
+			skipped++
+			status = "SKIPPED"
+		} else if res.Failed() {
+			failed++
+			status = fmt.Sprintf("%s %s", output.ErrorEmoji(), status)
+		} else if res.Result != nil && res.Result.Status == flow.TransactionStatusSealed {
+			status = fmt.Sprintf("%s %s", output.OkEmoji(), status)
+		}
+
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", res.Name, id, status, events)
+		if errMsg != "" {
+			_, _ = fmt.Fprintf(writer, "\t%s\n", errMsg)
+		}
+	}
+
+	_, _ = fmt.Fprintf(
+		writer,
+		"\nSent %d transactions, %d failed, %d skipped\n",
+		len(r.results)-skipped,
+		failed,
+		skipped,
+	)
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *ManifestResult) Oneliner() string {
+	result := ""
+	for _, res := range r.results {
+		result += fmt.Sprintf("Name: %s", res.Name)
+		if res.Tx != nil {
+			result += fmt.Sprintf(", ID: %s", res.Tx.ID())
+		}
+		if res.Result != nil {
+			result += fmt.Sprintf(", Status: %s", res.Result.Status)
+		}
+		if res.Skipped {
+			result += ", Skipped"
+		}
+		if res.Err != nil {
+			result += fmt.Sprintf(", Error: %s", res.Err)
+		}
+		result += "; "
+	}
+
+	return result
+}
@@ -33,22 +33,29 @@ import (
 
 type flagsBuild struct {
 	ArgsJSON         string   `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
+	ArgsFile         string   `default:"" flag:"args-file" info:"JSON file with the transaction arguments in JSON-Cadence format or plain JSON"`
 	Args             []string `default:"" flag:"arg" info:"⚠️  Deprecated: use command arguments"`
 	Proposer         string   `default:"emulator-account" flag:"proposer" info:"transaction proposer"`
 	ProposerKeyIndex int      `default:"0" flag:"proposer-key-index" info:"proposer key index"`
 	Payer            string   `default:"emulator-account" flag:"payer" info:"transaction payer"`
 	Authorizer       []string `default:"emulator-account" flag:"authorizer" info:"transaction authorizer"`
 	GasLimit         uint64   `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+	Offline          bool     `default:"false" flag:"offline" info:"Build the transaction without network access using the provided context"`
+	Context          string   `default:"" flag:"context" info:"File with transaction context exported by the context command, used with offline flag"`
+	ReferenceBlock   string   `default:"" flag:"reference-block" info:"Reference block ID, used with offline flag"`
+	SequenceNumber   uint64   `default:"0" flag:"sequence-number" info:"Proposer key sequence number, used with offline flag"`
 }
 
 var buildFlags = flagsBuild{}
 
 var BuildCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "build <code filename>  [<argument> <argument> ...]",
-		Short:   "Build an unsigned transaction",
-		Example: `flow transactions build ./transaction.cdc "Hello" --proposer alice --authorizer alice --payer bob`,
-		Args:    cobra.MinimumNArgs(1),
+		Use:   "build <code filename>  [<argument> <argument> ...]",
+		Short: "Build an unsigned transaction",
+		Example: `flow transactions build ./transaction.cdc "Hello" --proposer alice --authorizer alice --payer bob --filter envelope --save built.json
+
+flow transactions build ./transaction.cdc "Hello" --proposer alice --authorizer alice --payer bob --offline --context context.json`,
+		Args: cobra.MinimumNArgs(1),
 	},
 	Flags: &buildFlags,
 	RunS:  build,
@@ -91,38 +98,110 @@ func build(
 		fmt.Println("⚠️  DEPRECATION WARNING: use transaction arguments as command arguments: send <code filename> [<argument> <argument> ...]")
 	}
 
+	addresses := state.AddressResolver(globalFlags.Network)
 	var transactionArgs []cadence.Value
-	if buildFlags.ArgsJSON != "" || len(buildFlags.Args) != 0 {
-		transactionArgs, err = flowkit.ParseArguments(buildFlags.Args, buildFlags.ArgsJSON)
+	if buildFlags.ArgsFile != "" {
+		if len(args) > 1 || buildFlags.ArgsJSON != "" || len(buildFlags.Args) != 0 {
+			return nil, fmt.Errorf("args-file flag can not be used together with other transaction arguments")
+		}
+
+		var argsFile []byte
+		argsFile, err = readerWriter.ReadFile(buildFlags.ArgsFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading arguments file: %w", err)
+		}
+
+		transactionArgs, err = flowkit.NewArgumentParserWithReader(filename, code, readerWriter).
+			SetAddressResolver(addresses).
+			ParseJSON(argsFile)
+	} else if buildFlags.ArgsJSON != "" || len(buildFlags.Args) != 0 {
+		transactionArgs, err = flowkit.ParseArgumentsWithResolver(buildFlags.Args, buildFlags.ArgsJSON, addresses)
 	} else {
-		transactionArgs, err = flowkit.ParseArgumentsWithoutType(filename, code, args[1:])
+		transactionArgs, err = flowkit.NewArgumentParserWithReader(filename, code, readerWriter).
+			SetAddressResolver(addresses).
+			Parse(args[1:])
 	}
 
 	if err != nil {
 		return nil, fmt.Errorf("error parsing transaction arguments: %w", err)
 	}
 
-	build, err := services.Transactions.Build(
-		proposer,
-		authorizers,
-		payer,
-		buildFlags.ProposerKeyIndex,
-		code,
-		filename,
-		buildFlags.GasLimit,
-		transactionArgs,
-		globalFlags.Network,
-	)
-	if err != nil {
-		return nil, err
+	var build *flowkit.Transaction
+	if buildFlags.Offline {
+		ctx, err := offlineContext(proposer, readerWriter)
+		if err != nil {
+			return nil, err
+		}
+
+		build, err = services.Transactions.BuildOffline(
+			ctx,
+			authorizers,
+			payer,
+			code,
+			filename,
+			buildFlags.GasLimit,
+			transactionArgs,
+			globalFlags.Network,
+		)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		build, err = services.Transactions.Build(
+			proposer,
+			authorizers,
+			payer,
+			buildFlags.ProposerKeyIndex,
+			code,
+			filename,
+			buildFlags.GasLimit,
+			transactionArgs,
+			globalFlags.Network,
+		)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return &TransactionResult{
-		tx:      build.FlowTransaction(),
-		include: []string{"code", "payload", "signatures"},
+		tx:       build.FlowTransaction(),
+		envelope: flowkit.NewTransactionEnvelope(build, filename, globalFlags.Network),
+		include:  []string{"code", "payload", "signatures"},
 	}, nil
 }
 
+// offlineContext creates the transaction context from the context file or from the flags.
+func offlineContext(proposer flow.Address, readerWriter flowkit.ReaderWriter) (*flowkit.TransactionContext, error) {
+	if buildFlags.Context == "" {
+		if buildFlags.ReferenceBlock == "" {
+			return nil, fmt.Errorf("offline build requires a context file or reference block flag")
+		}
+
+		return &flowkit.TransactionContext{
+			ReferenceBlockID: buildFlags.ReferenceBlock,
+			Proposer:         proposer,
+			KeyIndex:         buildFlags.ProposerKeyIndex,
+			SequenceNumber:   buildFlags.SequenceNumber,
+		}, nil
+	}
+
+	data, err := readerWriter.ReadFile(buildFlags.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transaction context from %s: %w", buildFlags.Context, err)
+	}
+
+	ctx, err := flowkit.ParseTransactionContext(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx.Proposer != proposer {
+		return nil, fmt.Errorf("transaction context proposer %s doesn't match the proposer %s", ctx.Proposer, proposer)
+	}
+
+	return ctx, nil
+}
+
 func getAddress(address string, state *flowkit.State) (flow.Address, error) {
 	addr, valid := util.ParseAddress(address)
 	if valid {
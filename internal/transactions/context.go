@@ -0,0 +1,98 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+type flagsContext struct {
+	Proposer         string `default:"emulator-account" flag:"proposer" info:"transaction proposer"`
+	ProposerKeyIndex int    `default:"0" flag:"proposer-key-index" info:"proposer key index"`
+}
+
+var contextFlags = flagsContext{}
+
+var ContextCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "context",
+		Short:   "Export the context needed to build a transaction offline",
+		Example: `flow transactions context --proposer alice --output json --save context.json`,
+		Args:    cobra.NoArgs,
+	},
+	Flags: &contextFlags,
+	RunS:  exportContext,
+}
+
+func exportContext(
+	_ []string,
+	_ flowkit.ReaderWriter,
+	_ command.GlobalFlags,
+	services *services.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	proposer, err := getAddress(contextFlags.Proposer, state)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := services.Transactions.Context(proposer, contextFlags.ProposerKeyIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ContextResult{ctx}, nil
+}
+
+// ContextResult represents the context needed to build a transaction offline.
+type ContextResult struct {
+	*flowkit.TransactionContext
+}
+
+func (r *ContextResult) JSON() interface{} {
+	return r.TransactionContext
+}
+
+func (r *ContextResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Reference Block\t%s\n", r.ReferenceBlockID)
+	_, _ = fmt.Fprintf(writer, "Proposer\t%s\n", r.Proposer)
+	_, _ = fmt.Fprintf(writer, "Key Index\t%d\n", r.KeyIndex)
+	_, _ = fmt.Fprintf(writer, "Sequence Number\t%d\n", r.SequenceNumber)
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *ContextResult) Oneliner() string {
+	return fmt.Sprintf(
+		"Reference Block: %s, Proposer: %s, Key Index: %d, Sequence Number: %d",
+		r.ReferenceBlockID, r.Proposer, r.KeyIndex, r.SequenceNumber,
+	)
+}
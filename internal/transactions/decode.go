@@ -0,0 +1,262 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+type flagsDecode struct {
+	Verify  bool     `default:"false" flag:"verify" info:"Verify signatures against the on-chain account keys on the network"`
+	Include []string `default:"" flag:"include" info:"Fields to include in the output"`
+}
+
+var decodeFlags = flagsDecode{}
+
+var DecodeCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "decode <transaction filename>",
+		Short: "Decode a built or signed transaction",
+		Example: `flow transactions decode ./built.json
+
+flow transactions decode ./signed.rlp --verify --network testnet`,
+		Args: cobra.ExactArgs(1),
+	},
+	Flags: &decodeFlags,
+	Run:   decode,
+}
+
+func decode(
+	args []string,
+	readerWriter flowkit.ReaderWriter,
+	_ command.GlobalFlags,
+	services *services.Services,
+) (command.Result, error) {
+	filename := args[0]
+	payload, err := readerWriter.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transaction from %s: %v", filename, err)
+	}
+
+	envelope, err := flowkit.ParseTransactionEnvelope(payload)
+	if err != nil {
+		return nil, err
+	}
+	tx := envelope.Transaction().FlowTransaction()
+
+	arguments := make([]cadence.Value, 0, len(tx.Arguments))
+	for i, arg := range tx.Arguments {
+		value, err := jsoncdc.Decode(arg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode argument %d: %w", i, err)
+		}
+		arguments = append(arguments, value)
+	}
+
+	result := &DecodeResult{
+		tx:        tx,
+		envelope:  envelope,
+		arguments: arguments,
+		include:   decodeFlags.Include,
+	}
+
+	if decodeFlags.Verify {
+		result.verifications = services.Transactions.VerifySignatures(tx)
+	}
+
+	return result, nil
+}
+
+// DecodeResult represents a decoded transaction with optionally verified signatures.
+type DecodeResult struct {
+	tx            *flow.Transaction
+	envelope      *flowkit.TransactionEnvelope
+	arguments     []cadence.Value
+	verifications []*services.TransactionSignatureVerification
+	include       []string
+}
+
+// verification returns the verification of the signature if signatures were verified.
+func (r *DecodeResult) verification(signatureType string, signature flow.TransactionSignature) *services.TransactionSignatureVerification {
+	for _, v := range r.verifications {
+		if v.Type == signatureType &&
+			v.Signature.Address == signature.Address &&
+			v.Signature.KeyIndex == signature.KeyIndex &&
+			bytes.Equal(v.Signature.Signature, signature.Signature) {
+			return v
+		}
+	}
+
+	return nil
+}
+
+func (r *DecodeResult) signaturesJSON(signatureType string, signatures []flow.TransactionSignature) []interface{} {
+	result := make([]interface{}, 0, len(signatures))
+	for _, s := range signatures {
+		item := map[string]interface{}{
+			"address":   s.Address.String(),
+			"keyIndex":  s.KeyIndex,
+			"signature": fmt.Sprintf("%x", s.Signature),
+		}
+
+		if v := r.verification(signatureType, s); v != nil {
+			item["valid"] = v.Valid
+			if v.Err != nil {
+				item["error"] = v.Err.Error()
+			}
+		}
+
+		result = append(result, item)
+	}
+
+	return result
+}
+
+func (r *DecodeResult) JSON() interface{} {
+	result := make(map[string]interface{})
+	result["id"] = r.tx.ID().String()
+	result["payload"] = fmt.Sprintf("%x", r.tx.Encode())
+	result["script"] = string(r.tx.Script)
+	result["scriptHash"] = flowkit.ScriptHash(r.tx.Script)
+	result["authorizers"] = fmt.Sprintf("%s", r.tx.Authorizers)
+	result["payer"] = r.tx.Payer.String()
+	result["referenceBlockId"] = r.tx.ReferenceBlockID.String()
+	result["gasLimit"] = r.tx.GasLimit
+	result["proposalKey"] = map[string]interface{}{
+		"address":        r.tx.ProposalKey.Address.String(),
+		"keyIndex":       r.tx.ProposalKey.KeyIndex,
+		"sequenceNumber": r.tx.ProposalKey.SequenceNumber,
+	}
+
+	arguments := make([]interface{}, 0, len(r.arguments))
+	for _, arg := range r.arguments {
+		arguments = append(arguments, json.RawMessage(jsoncdc.MustEncode(arg)))
+	}
+	result["arguments"] = arguments
+
+	result["payloadSignatures"] = r.signaturesJSON(flowkit.SignaturePayload, r.tx.PayloadSignatures)
+	result["envelopeSignatures"] = r.signaturesJSON(flowkit.SignatureEnvelope, r.tx.EnvelopeSignatures)
+
+	if r.envelope.Source != "" {
+		result["source"] = r.envelope.Source
+	}
+	if r.envelope.Network != "" {
+		result["network"] = r.envelope.Network
+	}
+
+	return result
+}
+
+func (r *DecodeResult) writeSignatures(
+	writer *bytes.Buffer,
+	name string,
+	signatureType string,
+	signatures []flow.TransactionSignature,
+) {
+	tabWriter := util.CreateTabWriter(writer)
+
+	if len(signatures) == 0 {
+		_, _ = fmt.Fprintf(tabWriter, "\nNo %s Signatures\n", name)
+	}
+
+	for i, s := range signatures {
+		_, _ = fmt.Fprintf(tabWriter, "\n%s Signature %v:\n", name, i)
+		_, _ = fmt.Fprintf(tabWriter, "    Address\t%s\n", s.Address)
+		_, _ = fmt.Fprintf(tabWriter, "    Key Index\t%d\n", s.KeyIndex)
+		if command.ContainsFlag(r.include, "signatures") {
+			_, _ = fmt.Fprintf(tabWriter, "    Signature\t%x\n", s.Signature)
+		}
+
+		if v := r.verification(signatureType, s); v != nil {
+			status := fmt.Sprintf("%s valid", output.OkEmoji())
+			if v.Err != nil {
+				status = fmt.Sprintf("%s %s", output.ErrorEmoji(), v.Err)
+			} else if !v.Valid {
+				status = fmt.Sprintf("%s invalid", output.ErrorEmoji())
+			}
+			_, _ = fmt.Fprintf(tabWriter, "    Verified\t%s\n", status)
+		}
+	}
+
+	_ = tabWriter.Flush()
+}
+
+func (r *DecodeResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "ID\t%s\n", r.tx.ID())
+	if r.envelope.Source != "" {
+		_, _ = fmt.Fprintf(writer, "Source\t%s\n", r.envelope.Source)
+	}
+	if r.envelope.Network != "" {
+		_, _ = fmt.Fprintf(writer, "Network\t%s\n", r.envelope.Network)
+	}
+	_, _ = fmt.Fprintf(writer, "Payer\t%s\n", r.tx.Payer.Hex())
+	_, _ = fmt.Fprintf(writer, "Authorizers\t%s\n", r.tx.Authorizers)
+	_, _ = fmt.Fprintf(writer, "Reference Block\t%s\n", r.tx.ReferenceBlockID)
+	_, _ = fmt.Fprintf(writer, "Gas Limit\t%d\n", r.tx.GasLimit)
+
+	_, _ = fmt.Fprintf(writer,
+		"\nProposal Key:\t\n    Address\t%s\n    Index\t%v\n    Sequence\t%v\n",
+		r.tx.ProposalKey.Address, r.tx.ProposalKey.KeyIndex, r.tx.ProposalKey.SequenceNumber,
+	)
+	_ = writer.Flush()
+
+	r.writeSignatures(&b, "Payload", flowkit.SignaturePayload, r.tx.PayloadSignatures)
+	r.writeSignatures(&b, "Envelope", flowkit.SignatureEnvelope, r.tx.EnvelopeSignatures)
+
+	if !command.ContainsFlag(r.include, "signatures") {
+		_, _ = fmt.Fprintf(writer, "\nSignatures (minimized, use --include signatures)\n")
+	}
+
+	if len(r.arguments) == 0 {
+		_, _ = fmt.Fprintf(writer, "\nArguments\tNo arguments\n")
+	} else {
+		_, _ = fmt.Fprintf(writer, "\nArguments (%d):\n", len(r.arguments))
+		for i, arg := range r.arguments {
+			_, _ = fmt.Fprintf(writer, "    - Argument %d (%s): %s\n", i, arg.Type().ID(), arg)
+		}
+	}
+
+	_, _ = fmt.Fprintf(writer, "\nCode (hash %s)\n\n%s\n", flowkit.ScriptHash(r.tx.Script), r.tx.Script)
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *DecodeResult) Oneliner() string {
+	return fmt.Sprintf(
+		"ID: %s, Payer: %s, Authorizers: %s, Proposer: %s, Gas Limit: %d",
+		r.tx.ID(), r.tx.Payer, r.tx.Authorizers, r.tx.ProposalKey.Address, r.tx.GasLimit,
+	)
+}
@@ -31,9 +31,10 @@ import (
 )
 
 type flagsGet struct {
-	Sealed  bool     `default:"true" flag:"sealed" info:"Wait for a sealed result"`
-	Include []string `default:"" flag:"include" info:"Fields to include in the output"`
-	Exclude []string `default:"" flag:"exclude" info:"Fields to exclude from the output (events)"`
+	Sealed      bool     `default:"true" flag:"sealed" info:"Wait for a sealed result"`
+	Include     []string `default:"" flag:"include" info:"Fields to include in the output"`
+	Exclude     []string `default:"" flag:"exclude" info:"Fields to exclude from the output (events)"`
+	CadenceJSON string   `default:"full" flag:"cadence-json" info:"Encoding of Cadence values in the JSON output (full, plain)"`
 }
 
 var getFlags = flagsGet{}
@@ -56,6 +57,11 @@ func get(
 	_ command.GlobalFlags,
 	services *services.Services,
 ) (command.Result, error) {
+	err := command.ValidateCadenceJSON(getFlags.CadenceJSON)
+	if err != nil {
+		return nil, err
+	}
+
 	id := flow.HexToID(strings.TrimPrefix(args[0], "0x"))
 
 	tx, result, err := services.Transactions.GetStatus(id, getFlags.Sealed)
@@ -64,9 +70,10 @@ func get(
 	}
 
 	return &TransactionResult{
-		result:  result,
-		tx:      tx,
-		include: getFlags.Include,
-		exclude: getFlags.Exclude,
+		result:      result,
+		tx:          tx,
+		include:     getFlags.Include,
+		exclude:     getFlags.Exclude,
+		cadenceJSON: getFlags.CadenceJSON,
 	}, nil
 }
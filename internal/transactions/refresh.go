@@ -0,0 +1,118 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+type flagsRefresh struct {
+	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: signatures, code, payload, envelope."`
+}
+
+var refreshFlags = flagsRefresh{}
+
+var RefreshCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "refresh <built transaction filename>",
+		Short:   "Rebuild an expired transaction with a new reference block",
+		Example: "flow transactions refresh ./built.json --filter envelope --save ./built.json",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &refreshFlags,
+	Run:   refresh,
+}
+
+func refresh(
+	args []string,
+	readerWriter flowkit.ReaderWriter,
+	_ command.GlobalFlags,
+	services *services.Services,
+) (command.Result, error) {
+	filename := args[0]
+
+	payload, err := readerWriter.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transaction from %s: %w", filename, err)
+	}
+
+	envelope, resign, err := services.Transactions.Refresh(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RefreshResult{
+		TransactionResult: &TransactionResult{
+			tx:       envelope.Transaction().FlowTransaction(),
+			envelope: envelope,
+			include:  refreshFlags.Include,
+		},
+		resign: resign,
+	}, nil
+}
+
+// RefreshResult represents a transaction rebuilt with a new reference block.
+type RefreshResult struct {
+	*TransactionResult
+	resign []*flowkit.EnvelopeSigner
+}
+
+func (r *RefreshResult) JSON() interface{} {
+	result := r.TransactionResult.JSON().(map[string]interface{})
+
+	resign := make([]string, 0, len(r.resign))
+	for _, s := range r.resign {
+		resign = append(resign, s.Address.String())
+	}
+	result["resign"] = resign
+
+	return result
+}
+
+func (r *RefreshResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Reference Block\t%s\n", r.tx.ReferenceBlockID)
+	if len(r.resign) == 0 {
+		_, _ = fmt.Fprintf(writer, "Signatures To Collect Again\tNone\n\n")
+	} else {
+		_, _ = fmt.Fprintf(writer, "Signatures To Collect Again:\n")
+		for _, s := range r.resign {
+			_, _ = fmt.Fprintf(writer, "    %s\t%s\n", s.Address, strings.Join(s.Roles, ", "))
+		}
+		_, _ = fmt.Fprintf(writer, "\n")
+	}
+
+	_ = writer.Flush()
+	return b.String() + r.TransactionResult.String()
+}
+
+func (r *RefreshResult) Oneliner() string {
+	return fmt.Sprintf("%s, Reference Block: %s", r.TransactionResult.Oneliner(), r.tx.ReferenceBlockID)
+}
@@ -32,6 +32,7 @@ import (
 type flagsSendSigned struct {
 	Include []string `default:"" flag:"include" info:"Fields to include in the output"`
 	Exclude []string `default:"" flag:"exclude" info:"Fields to exclude from the output (events)"`
+	NoWait  bool     `default:"false" flag:"no-wait" info:"Return the transaction ID right after submission without waiting for the result"`
 }
 
 var sendSignedFlags = flagsSendSigned{}
@@ -41,7 +42,7 @@ var SendSignedCommand = &command.Command{
 		Use:     "send-signed <signed transaction filename>",
 		Short:   "Send signed transaction",
 		Args:    cobra.ExactArgs(1),
-		Example: `flow transactions send-signed signed.rlp`,
+		Example: `flow transactions send-signed signed.json`,
 	},
 	Flags: &sendSignedFlags,
 	RunS:  sendSigned,
@@ -61,6 +62,15 @@ func sendSigned(
 		return nil, fmt.Errorf("error loading transaction payload: %w", err)
 	}
 
+	if sendSignedFlags.NoWait {
+		tx, err := services.Transactions.SubmitSigned(code)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewSubmittedResult(tx), nil
+	}
+
 	tx, result, err := services.Transactions.SendSigned(code)
 	if err != nil {
 		return nil, err
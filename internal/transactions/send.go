@@ -20,6 +20,7 @@ package transactions
 
 import (
 	"fmt"
+	"net"
 
 	"github.com/spf13/cobra"
 
@@ -30,13 +31,27 @@ import (
 	"github.com/onflow/flow-cli/pkg/flowkit/services"
 )
 
+// gasSafetyMargin is the percentage added to the estimated computation when setting the gas limit.
+const gasSafetyMargin = 20
+
 type flagsSend struct {
-	ArgsJSON string   `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
-	Arg      []string `default:"" flag:"arg" info:"⚠️  Deprecated: use command arguments"`
-	Signer   string   `default:"emulator-account" flag:"signer" info:"Account name from configuration used to sign the transaction"`
-	GasLimit uint64   `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
-	Include  []string `default:"" flag:"include" info:"Fields to include in the output"`
-	Exclude  []string `default:"" flag:"exclude" info:"Fields to exclude from the output (events)"`
+	ArgsJSON    string   `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
+	ArgsFile    string   `default:"" flag:"args-file" info:"JSON file with the transaction arguments in JSON-Cadence format or plain JSON"`
+	Arg         []string `default:"" flag:"arg" info:"⚠️  Deprecated: use command arguments"`
+	Signer      string   `default:"emulator-account" flag:"signer" info:"Account name from configuration used to sign the transaction"`
+	Proposer    string   `default:"" flag:"proposer" info:"Account name from configuration used as proposer, defaults to signer"`
+	Payer       string   `default:"" flag:"payer" info:"Account name from configuration used as payer, defaults to signer"`
+	Authorizers []string `default:"" flag:"authorizer" info:"Account names from configuration used as authorizers, defaults to signer"`
+	GasLimit    uint64   `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+	Count       int      `default:"0" flag:"count" info:"Send the transaction the given number of times concurrently, each using a distinct proposal key of the signer"`
+	DryRun      bool     `default:"false" flag:"dry-run" info:"Execute the transaction on an in-process emulator without submitting it, only for accounts on the emulator network"`
+	EstimateGas bool     `default:"false" flag:"estimate-gas" info:"Set the gas limit to the computation used on an in-process emulator plus a safety margin, only for accounts on the emulator network"`
+	Include     []string `default:"" flag:"include" info:"Fields to include in the output"`
+	Exclude     []string `default:"" flag:"exclude" info:"Fields to exclude from the output (events)"`
+	Policy      string   `default:"" flag:"policy" info:"Signing policy file evaluated before signing, overrides the policy in configuration"`
+	NoWait      bool     `default:"false" flag:"no-wait" info:"Return the transaction ID right after submission without waiting for the result"`
+	Diff        bool     `default:"false" flag:"diff" info:"Show the changes made to the accounts touched by the transaction, only on a local emulator or with dry-run"`
+	CadenceJSON string   `default:"full" flag:"cadence-json" info:"Encoding of Cadence values in the JSON output (full, plain)"`
 }
 
 var sendFlags = flagsSend{}
@@ -66,6 +81,11 @@ func send(
 		return nil, err
 	}
 
+	roles, err := transactionRoles(state, signer)
+	if err != nil {
+		return nil, err
+	}
+
 	code, err := readerWriter.ReadFile(codeFilename)
 	if err != nil {
 		return nil, fmt.Errorf("error loading transaction file: %w", err)
@@ -75,19 +95,177 @@ func send(
 		fmt.Println("⚠️  DEPRECATION WARNING: use transaction arguments as command arguments: send <code filename> [<argument> <argument> ...]")
 	}
 
+	addresses := state.AddressResolver(globalFlags.Network)
 	var transactionArgs []cadence.Value
-	if sendFlags.ArgsJSON != "" || len(sendFlags.Arg) != 0 {
-		transactionArgs, err = flowkit.ParseArguments(sendFlags.Arg, sendFlags.ArgsJSON)
+	if sendFlags.ArgsFile != "" {
+		if len(args) > 1 || sendFlags.ArgsJSON != "" || len(sendFlags.Arg) != 0 {
+			return nil, fmt.Errorf("args-file flag can not be used together with other transaction arguments")
+		}
+
+		var argsFile []byte
+		argsFile, err = readerWriter.ReadFile(sendFlags.ArgsFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading arguments file: %w", err)
+		}
+
+		transactionArgs, err = flowkit.NewArgumentParserWithReader(codeFilename, code, readerWriter).
+			SetAddressResolver(addresses).
+			ParseJSON(argsFile)
+	} else if sendFlags.ArgsJSON != "" || len(sendFlags.Arg) != 0 {
+		transactionArgs, err = flowkit.ParseArgumentsWithResolver(sendFlags.Arg, sendFlags.ArgsJSON, addresses)
 	} else {
-		transactionArgs, err = flowkit.ParseArgumentsWithoutType(codeFilename, code, args[1:])
+		transactionArgs, err = flowkit.NewArgumentParserWithReader(codeFilename, code, readerWriter).
+			SetAddressResolver(addresses).
+			Parse(args[1:])
 	}
 
 	if err != nil {
 		return nil, fmt.Errorf("error parsing transaction arguments: %w", err)
 	}
 
-	tx, result, err := services.Transactions.Send(
-		signer,
+	err = command.ValidateCadenceJSON(sendFlags.CadenceJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	if sendFlags.NoWait && (sendFlags.DryRun || sendFlags.Count > 0) {
+		return nil, fmt.Errorf("no-wait flag can not be used together with dry-run or count flags")
+	}
+
+	if sendFlags.Policy != "" {
+		state.Config().Policy = sendFlags.Policy
+	}
+
+	if sendFlags.Diff {
+		if sendFlags.NoWait || sendFlags.Count > 0 {
+			return nil, fmt.Errorf("diff flag can not be used together with no-wait or count flags")
+		}
+
+		if !sendFlags.DryRun {
+			local, err := isLocalEmulator(state, globalFlags)
+			if err != nil {
+				return nil, err
+			}
+			if !local {
+				return nil, fmt.Errorf("diff flag is only supported on an emulator running locally, use the dry-run flag to execute the transaction on an in-process emulator")
+			}
+		}
+	}
+
+	transactions := services.Transactions
+	if sendFlags.DryRun || sendFlags.EstimateGas {
+		// the accounts and contracts are copied to an in-process emulator, so the network isn't changed
+		fork, forkRoles, err := services.Transactions.Fork(roles, code, codeFilename, globalFlags.Network)
+		if err != nil {
+			return nil, err
+		}
+
+		// a dry run with the diff flag sends the transaction to the fork, it is only simulated first to estimate the gas limit
+		if sendFlags.EstimateGas || !sendFlags.Diff {
+			dryRun, err := fork.DryRun(
+				forkRoles,
+				code,
+				codeFilename,
+				sendFlags.GasLimit,
+				transactionArgs,
+				globalFlags.Network,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			if sendFlags.DryRun && !sendFlags.Diff {
+				return &DryRunResult{
+					TransactionResult: &TransactionResult{
+						result:      dryRun.Result,
+						tx:          dryRun.Tx,
+						include:     sendFlags.Include,
+						exclude:     sendFlags.Exclude,
+						cadenceJSON: sendFlags.CadenceJSON,
+						logs:        fork.Logs(dryRun.Tx.ID()),
+					},
+					computationUsed: dryRun.ComputationUsed,
+				}, nil
+			}
+
+			if dryRun.ComputationUsed == 0 {
+				return nil, fmt.Errorf("failed to estimate gas, transaction failed on the emulator: %w", dryRun.Result.Error)
+			}
+
+			sendFlags.GasLimit = dryRun.ComputationUsed + dryRun.ComputationUsed*gasSafetyMargin/100 + 1
+		}
+
+		if sendFlags.DryRun {
+			transactions, roles = fork, forkRoles
+		}
+	}
+
+	if sendFlags.Count > 0 {
+		if len(roles.Signers()) > 1 {
+			return nil, fmt.Errorf("count flag can not be used with different proposer, payer or authorizer accounts")
+		}
+
+		results, err := services.Transactions.SendBatch(
+			signer,
+			code,
+			codeFilename,
+			sendFlags.GasLimit,
+			transactionArgs,
+			globalFlags.Network,
+			sendFlags.Count,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return &BatchResult{results: results}, nil
+	}
+
+	if sendFlags.NoWait {
+		tx, err := services.Transactions.SubmitWithRoles(
+			roles,
+			code,
+			codeFilename,
+			sendFlags.GasLimit,
+			transactionArgs,
+			globalFlags.Network,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewSubmittedResult(tx), nil
+	}
+
+	if sendFlags.Diff {
+		tx, result, diffs, err := transactions.SendWithDiff(
+			roles,
+			code,
+			codeFilename,
+			sendFlags.GasLimit,
+			transactionArgs,
+			globalFlags.Network,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return &DiffResult{
+			TransactionResult: &TransactionResult{
+				result:      result,
+				tx:          tx,
+				include:     sendFlags.Include,
+				exclude:     sendFlags.Exclude,
+				cadenceJSON: sendFlags.CadenceJSON,
+				logs:        transactions.Logs(tx.ID()),
+			},
+			diffs:  diffs,
+			dryRun: sendFlags.DryRun,
+		}, nil
+	}
+
+	tx, result, err := services.Transactions.SendWithRoles(
+		roles,
 		code,
 		codeFilename,
 		sendFlags.GasLimit,
@@ -100,9 +278,74 @@ func send(
 	}
 
 	return &TransactionResult{
-		result:  result,
-		tx:      tx,
-		include: sendFlags.Include,
-		exclude: sendFlags.Exclude,
+		result:      result,
+		tx:          tx,
+		include:     sendFlags.Include,
+		exclude:     sendFlags.Exclude,
+		cadenceJSON: sendFlags.CadenceJSON,
+		logs:        services.Transactions.Logs(tx.ID()),
 	}, nil
 }
+
+// isLocalEmulator returns true if the command is sent to a network on the local host, which is assumed to be an emulator.
+func isLocalEmulator(state *flowkit.State, globalFlags command.GlobalFlags) (bool, error) {
+	host := globalFlags.Host
+	if host == "" {
+		network, err := state.Networks().ByName(globalFlags.Network)
+		if err != nil {
+			return false, err
+		}
+		host = network.Host
+	}
+
+	hostname, _, err := net.SplitHostPort(host)
+	if err != nil {
+		hostname = host
+	}
+
+	switch hostname {
+	case "localhost", "127.0.0.1", "::1":
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// transactionRoles creates transaction roles from the flags, roles not provided default to the signer.
+func transactionRoles(state *flowkit.State, signer *flowkit.Account) (*flowkit.TransactionAccountRoles, error) {
+	roles := flowkit.NewTransactionSingleAccountRole(signer)
+
+	if sendFlags.Proposer != "" {
+		proposer, err := state.Accounts().ByName(sendFlags.Proposer)
+		if err != nil {
+			return nil, fmt.Errorf("proposer: %w", err)
+		}
+		roles.Proposer = proposer
+	}
+
+	if sendFlags.Payer != "" {
+		payer, err := state.Accounts().ByName(sendFlags.Payer)
+		if err != nil {
+			return nil, fmt.Errorf("payer: %w", err)
+		}
+		roles.Payer = payer
+	}
+
+	authorizers := make([]*flowkit.Account, 0)
+	for _, name := range sendFlags.Authorizers {
+		if name == "" {
+			continue
+		}
+
+		authorizer, err := state.Accounts().ByName(name)
+		if err != nil {
+			return nil, fmt.Errorf("authorizer: %w", err)
+		}
+		authorizers = append(authorizers, authorizer)
+	}
+	if len(authorizers) > 0 {
+		roles.Authorizers = authorizers
+	}
+
+	return roles, nil
+}
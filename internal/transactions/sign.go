@@ -32,6 +32,8 @@ import (
 type flagsSign struct {
 	Signer  string   `default:"emulator-account" flag:"signer" info:"name of the account used to sign"`
 	Include []string `default:"" flag:"include" info:"Fields to include in the output"`
+	Policy  string   `default:"" flag:"policy" info:"Signing policy file evaluated before signing, overrides the policy in configuration"`
+	Offline bool     `default:"false" flag:"offline" info:"Sign without network access, skipping the comparison of the signer key with the on-chain key"`
 }
 
 var signFlags = flagsSign{}
@@ -40,7 +42,7 @@ var SignCommand = &command.Command{
 	Cmd: &cobra.Command{
 		Use:     "sign <built transaction filename>",
 		Short:   "Sign built transaction",
-		Example: "flow transactions sign ./built.rlp --signer alice",
+		Example: "flow transactions sign ./built.json --signer alice --filter envelope --save signed.json",
 		Args:    cobra.ExactArgs(1),
 	},
 	Flags: &signFlags,
@@ -60,18 +62,35 @@ func sign(
 		return nil, fmt.Errorf("failed to read partial transaction from %s: %v", filename, err)
 	}
 
+	envelope, err := flowkit.ParseTransactionEnvelope(payload)
+	if err != nil {
+		return nil, err
+	}
+
 	signer, err := state.Accounts().ByName(signFlags.Signer)
 	if err != nil {
 		return nil, fmt.Errorf("signer account: [%s] doesn't exists in configuration", signFlags.Signer)
 	}
 
-	signed, err := services.Transactions.Sign(signer, payload, globalFlags.Yes)
+	if signFlags.Policy != "" {
+		state.Config().Policy = signFlags.Policy
+	}
+
+	signTransaction := services.Transactions.SignForNetwork
+	if signFlags.Offline {
+		signTransaction = services.Transactions.SignOffline
+	}
+
+	signed, err := signTransaction(signer, payload, globalFlags.Yes, globalFlags.Network)
 	if err != nil {
 		return nil, err
 	}
 
+	envelope.SetTransaction(signed)
+
 	return &TransactionResult{
-		tx:      signed.FlowTransaction(),
-		include: signFlags.Include,
+		tx:       signed.FlowTransaction(),
+		envelope: envelope,
+		include:  signFlags.Include,
 	}, nil
 }
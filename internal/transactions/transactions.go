@@ -21,17 +21,21 @@ package transactions
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/onflow/flow-cli/pkg/flowkit"
 	"github.com/onflow/flow-cli/pkg/flowkit/output"
 
 	"github.com/onflow/flow-cli/internal/command"
 
-	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence"
 	"github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/internal/events"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
 	"github.com/onflow/flow-cli/pkg/flowkit/util"
 )
 
@@ -47,13 +51,37 @@ func init() {
 	SignCommand.AddToParent(Cmd)
 	BuildCommand.AddToParent(Cmd)
 	SendSignedCommand.AddToParent(Cmd)
+	DecodeCommand.AddToParent(Cmd)
+	ContextCommand.AddToParent(Cmd)
+	RefreshCommand.AddToParent(Cmd)
+	WaitCommand.AddToParent(Cmd)
+	BatchCommand.AddToParent(Cmd)
 }
 
 type TransactionResult struct {
-	result  *flow.TransactionResult
-	tx      *flow.Transaction
-	include []string
-	exclude []string
+	result   *flow.TransactionResult
+	tx       *flow.Transaction
+	envelope *flowkit.TransactionEnvelope
+	include  []string
+	exclude  []string
+	logs     []string // nil if logs are not available on the network
+	// cadenceJSON is the encoding of the event values in the JSON output, full or plain
+	cadenceJSON string
+}
+
+// NewTransactionResult creates a result for the transaction sent by commands outside of this package.
+func NewTransactionResult(
+	tx *flow.Transaction,
+	result *flow.TransactionResult,
+	include []string,
+	exclude []string,
+) *TransactionResult {
+	return &TransactionResult{
+		result:  result,
+		tx:      tx,
+		include: include,
+		exclude: exclude,
+	}
 }
 
 func (r *TransactionResult) JSON() interface{} {
@@ -66,26 +94,45 @@ func (r *TransactionResult) JSON() interface{} {
 	if r.result != nil {
 		result["status"] = r.result.Status.String()
 
-		txEvents := make([]interface{}, 0, len(r.result.Events))
-		for _, event := range r.result.Events {
-			txEvents = append(txEvents, map[string]interface{}{
-				"index": event.EventIndex,
-				"type":  event.Type,
-				"values": json.RawMessage(
-					jsoncdc.MustEncode(event.Value),
-				),
-			})
+		result["events"] = eventsJSON(r.result.Events, r.cadenceJSON)
+
+		if r.logs != nil {
+			result["logs"] = r.logs
 		}
-		result["events"] = txEvents
 
 		if r.result.Error != nil {
 			result["error"] = r.result.Error.Error()
+
+			var executionErr *flowkit.ExecutionError
+			if errors.As(r.result.Error, &executionErr) {
+				errorsJSON, _ := json.MarshalIndent(executionErr.Errors, "", "\t")
+				result["errors"] = json.RawMessage(errorsJSON)
+			}
 		}
 	}
 
+	if r.envelope != nil {
+		envelope, _ := r.envelope.Encode()
+		result["envelope"] = json.RawMessage(envelope)
+	}
+
 	return result
 }
 
+// eventsJSON converts the transaction events to the JSON output format with the values in the cadence-json format.
+func eventsJSON(events []flow.Event, cadenceJSON string) []interface{} {
+	txEvents := make([]interface{}, 0, len(events))
+	for _, event := range events {
+		txEvents = append(txEvents, map[string]interface{}{
+			"index":  event.EventIndex,
+			"type":   event.Type,
+			"values": command.CadenceJSON(event.Value, cadenceJSON),
+		})
+	}
+
+	return txEvents
+}
+
 func (r *TransactionResult) String() string {
 	var b bytes.Buffer
 	writer := util.CreateTabWriter(&b)
@@ -145,6 +192,17 @@ func (r *TransactionResult) String() string {
 		_, _ = fmt.Fprintf(writer, "\nSignatures (minimized, use --include signatures)")
 	}
 
+	if r.envelope != nil {
+		_, _ = fmt.Fprintf(writer, "\n\nRequired Signers:\n")
+		for _, s := range r.envelope.Signers {
+			status := fmt.Sprintf("%s missing", output.ErrorEmoji())
+			if s.Signed {
+				status = fmt.Sprintf("%s signed", output.OkEmoji())
+			}
+			_, _ = fmt.Fprintf(writer, "    %s\t%s\t%s\n", s.Address, strings.Join(s.Roles, ", "), status)
+		}
+	}
+
 	if r.result != nil && !command.ContainsFlag(r.exclude, "events") {
 		e := events.EventResult{
 			Events: r.result.Events,
@@ -158,6 +216,18 @@ func (r *TransactionResult) String() string {
 		_, _ = fmt.Fprintf(writer, "\n\nEvents:\t %s\n", eventsOutput)
 	}
 
+	if r.result != nil && r.logs != nil {
+		logsOutput := "None"
+		if len(r.logs) > 0 {
+			logsOutput = ""
+			for _, log := range r.logs {
+				logsOutput += fmt.Sprintf("\n    %s", log)
+			}
+		}
+
+		_, _ = fmt.Fprintf(writer, "\nLogs:\t %s\n", logsOutput)
+	}
+
 	if r.tx.Script != nil {
 		if command.ContainsFlag(r.include, "code") {
 			if len(r.tx.Arguments) == 0 {
@@ -181,6 +251,15 @@ func (r *TransactionResult) String() string {
 		_, _ = fmt.Fprint(writer, "\n\nPayload (hidden, use --include payload)")
 	}
 
+	if r.envelope != nil {
+		if command.ContainsFlag(r.include, "envelope") {
+			envelope, _ := r.envelope.Encode()
+			_, _ = fmt.Fprintf(writer, "\n\nEnvelope:\n%s", envelope)
+		} else {
+			_, _ = fmt.Fprint(writer, "\n\nEnvelope (hidden, use --include envelope)")
+		}
+	}
+
 	_ = writer.Flush()
 	return b.String()
 }
@@ -196,3 +275,319 @@ func (r *TransactionResult) Oneliner() string {
 
 	return result
 }
+
+// SubmittedResult represents a transaction that was sent without waiting for the result.
+type SubmittedResult struct {
+	tx *flow.Transaction
+}
+
+// NewSubmittedResult creates a result for the transaction sent without waiting by commands outside of this package.
+func NewSubmittedResult(tx *flow.Transaction) *SubmittedResult {
+	return &SubmittedResult{tx: tx}
+}
+
+func (r *SubmittedResult) JSON() interface{} {
+	return map[string]interface{}{
+		"id":     r.tx.ID().String(),
+		"status": flow.TransactionStatusPending.String(),
+	}
+}
+
+func (r *SubmittedResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "ID\t%s\n", r.tx.ID())
+	_, _ = fmt.Fprintf(writer, "Status\t%s\n", flow.TransactionStatusPending)
+	_, _ = fmt.Fprintf(writer, "\nTransaction submitted without waiting, get the result using: flow transactions wait %s\n", r.tx.ID())
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *SubmittedResult) Oneliner() string {
+	return r.tx.ID().String()
+}
+
+// BatchResult represents the results of transactions sent concurrently.
+type BatchResult struct {
+	results []services.BatchResult
+}
+
+func (r *BatchResult) JSON() interface{} {
+	result := make([]interface{}, 0, len(r.results))
+
+	for _, res := range r.results {
+		item := make(map[string]interface{})
+		if res.Tx != nil {
+			item["id"] = res.Tx.ID().String()
+			item["sequence"] = res.Tx.ProposalKey.SequenceNumber
+			item["keyIndex"] = res.Tx.ProposalKey.KeyIndex
+		}
+		if res.Result != nil {
+			item["status"] = res.Result.Status.String()
+			if res.Result.Error != nil {
+				item["error"] = res.Result.Error.Error()
+			}
+		}
+		if res.Err != nil {
+			item["error"] = res.Err.Error()
+		}
+
+		result = append(result, item)
+	}
+
+	return result
+}
+
+func (r *BatchResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	failed := 0
+	_, _ = fmt.Fprintf(writer, "Index\tID\tKey Index\tSequence\tStatus\n")
+	for i, res := range r.results {
+		id, keyIndex, sequence, status := "-", "-", "-", "-"
+		if res.Tx != nil {
+			id = res.Tx.ID().String()
+			keyIndex = fmt.Sprintf("%d", res.Tx.ProposalKey.KeyIndex)
+			sequence = fmt.Sprintf("%d", res.Tx.ProposalKey.SequenceNumber)
+		}
+		if res.Result != nil {
+			status = res.Result.Status.String()
+		}
+
+		errMsg := ""
+		if res.Err != nil {
+			errMsg = res.Err.Error()
+		} else if res.Result != nil && res.Result.Error != nil {
+			errMsg = res.Result.Error.Error()
+		}
+
+		if errMsg != "" {
+			failed++
+			status = fmt.Sprintf("%s %s", output.ErrorEmoji(), status)
+		} else if res.Result != nil && res.Result.Status == flow.TransactionStatusSealed {
+			status = fmt.Sprintf("%s %s", output.OkEmoji(), status)
+		}
+
+		_, _ = fmt.Fprintf(writer, "%d\t%s\t%s\t%s\t%s\n", i, id, keyIndex, sequence, status)
+		if errMsg != "" {
+			_, _ = fmt.Fprintf(writer, "\t%s\n", errMsg)
+		}
+	}
+
+	_, _ = fmt.Fprintf(writer, "\nSent %d transactions, %d failed\n", len(r.results), failed)
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *BatchResult) Oneliner() string {
+	result := ""
+	for _, res := range r.results {
+		if res.Tx != nil {
+			result += fmt.Sprintf("ID: %s", res.Tx.ID())
+		}
+		if res.Result != nil {
+			result += fmt.Sprintf(", Status: %s", res.Result.Status)
+		}
+		if res.Err != nil {
+			result += fmt.Sprintf(", Error: %s", res.Err)
+		}
+		result += "; "
+	}
+
+	return result
+}
+
+// DryRunResult represents the result of a transaction executed without being submitted.
+type DryRunResult struct {
+	*TransactionResult
+	computationUsed uint64
+}
+
+func (r *DryRunResult) JSON() interface{} {
+	result := r.TransactionResult.JSON().(map[string]interface{})
+	result["dryRun"] = true
+	result["computationUsed"] = r.computationUsed
+
+	return result
+}
+
+func (r *DryRunResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Dry Run\tTransaction was not submitted\n")
+	if r.computationUsed > 0 {
+		_, _ = fmt.Fprintf(writer, "Computation Used\t%d\n\n", r.computationUsed)
+	} else {
+		_, _ = fmt.Fprintf(writer, "Computation Used\tunknown, transaction failed\n\n")
+	}
+
+	_ = writer.Flush()
+	return b.String() + r.TransactionResult.String()
+}
+
+func (r *DryRunResult) Oneliner() string {
+	return fmt.Sprintf("%s, Computation Used: %d", r.TransactionResult.Oneliner(), r.computationUsed)
+}
+
+// DiffResult represents the result of a transaction with the changes made to the touched accounts.
+type DiffResult struct {
+	*TransactionResult
+	diffs  []*services.AccountDiff
+	dryRun bool
+}
+
+func (r *DiffResult) JSON() interface{} {
+	result := r.TransactionResult.JSON().(map[string]interface{})
+
+	diffs := make([]interface{}, 0, len(r.diffs))
+	for _, diff := range r.diffs {
+		keys := make([]interface{}, 0, len(diff.KeysAdded))
+		for _, key := range diff.KeysAdded {
+			keys = append(keys, map[string]interface{}{
+				"index":     key.Index,
+				"publicKey": fmt.Sprintf("%x", key.PublicKey.Encode()),
+				"sigAlgo":   key.SigAlgo.String(),
+				"hashAlgo":  key.HashAlgo.String(),
+				"weight":    key.Weight,
+			})
+		}
+
+		var paths interface{}
+		if diff.PathsAvailable {
+			paths = map[string]interface{}{
+				"added":   nonNil(diff.PathsAdded),
+				"removed": nonNil(diff.PathsRemoved),
+			}
+		}
+
+		diffs = append(diffs, map[string]interface{}{
+			"address": diff.Address.String(),
+			"created": diff.Created,
+			"changed": diff.Changed(),
+			"balance": map[string]interface{}{
+				"before": cadence.UFix64(diff.BalanceBefore).String(),
+				"after":  cadence.UFix64(diff.BalanceAfter).String(),
+			},
+			"storageUsed": map[string]interface{}{
+				"before": diff.StorageUsedBefore,
+				"after":  diff.StorageUsedAfter,
+			},
+			"contracts": map[string]interface{}{
+				"added":   nonNil(diff.ContractsAdded),
+				"updated": nonNil(diff.ContractsUpdated),
+				"removed": nonNil(diff.ContractsRemoved),
+			},
+			"keys": map[string]interface{}{
+				"added":   keys,
+				"revoked": append(make([]int, 0), diff.KeysRevoked...),
+			},
+			"paths": paths,
+		})
+	}
+	result["diff"] = diffs
+	if r.dryRun {
+		result["dryRun"] = true
+	}
+
+	return result
+}
+
+func (r *DiffResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Account Changes\n")
+
+	for _, diff := range r.diffs {
+		header := fmt.Sprintf("0x%s", diff.Address)
+		if diff.Created {
+			header += " (created)"
+		}
+		_, _ = fmt.Fprintf(writer, "\n%s\n", header)
+
+		if !diff.Changed() {
+			_, _ = fmt.Fprintf(writer, "  No changes\n")
+			continue
+		}
+
+		if diff.BalanceBefore != diff.BalanceAfter {
+			_, _ = fmt.Fprintf(writer, "  Balance\t%s → %s (%s)\n",
+				cadence.UFix64(diff.BalanceBefore),
+				cadence.UFix64(diff.BalanceAfter),
+				balanceChange(diff.BalanceBefore, diff.BalanceAfter),
+			)
+		}
+		if diff.StorageUsedBefore != diff.StorageUsedAfter {
+			_, _ = fmt.Fprintf(writer, "  Storage Used\t%d → %d (%+d bytes)\n",
+				diff.StorageUsedBefore,
+				diff.StorageUsedAfter,
+				int64(diff.StorageUsedAfter)-int64(diff.StorageUsedBefore),
+			)
+		}
+		for _, name := range diff.ContractsAdded {
+			_, _ = fmt.Fprintf(writer, "  Contract\t+ %s\n", name)
+		}
+		for _, name := range diff.ContractsUpdated {
+			_, _ = fmt.Fprintf(writer, "  Contract\t~ %s\n", name)
+		}
+		for _, name := range diff.ContractsRemoved {
+			_, _ = fmt.Fprintf(writer, "  Contract\t- %s\n", name)
+		}
+		for _, key := range diff.KeysAdded {
+			_, _ = fmt.Fprintf(writer, "  Key\t+ %d %x (%s, %s, weight %d)\n",
+				key.Index, key.PublicKey.Encode(), key.SigAlgo, key.HashAlgo, key.Weight)
+		}
+		for _, index := range diff.KeysRevoked {
+			_, _ = fmt.Fprintf(writer, "  Key\trevoked %d\n", index)
+		}
+		for _, path := range diff.PathsAdded {
+			_, _ = fmt.Fprintf(writer, "  Path\t+ %s\n", path)
+		}
+		for _, path := range diff.PathsRemoved {
+			_, _ = fmt.Fprintf(writer, "  Path\t- %s\n", path)
+		}
+	}
+
+	if len(r.diffs) > 0 && !r.diffs[0].PathsAvailable {
+		_, _ = fmt.Fprintf(writer, "\nStored paths are not available on this network, use the dry-run flag to include them.\n")
+	}
+
+	_ = writer.Flush()
+
+	if r.dryRun {
+		return "Dry Run\tTransaction was not submitted\n\n" + r.TransactionResult.String() + "\n" + b.String()
+	}
+	return r.TransactionResult.String() + "\n" + b.String()
+}
+
+func (r *DiffResult) Oneliner() string {
+	changed := 0
+	for _, diff := range r.diffs {
+		if diff.Changed() {
+			changed++
+		}
+	}
+
+	return fmt.Sprintf("%s, Changed Accounts: %d", r.TransactionResult.Oneliner(), changed)
+}
+
+// balanceChange formats the signed difference between two balances.
+func balanceChange(before uint64, after uint64) string {
+	if after >= before {
+		return "+" + cadence.UFix64(after-before).String()
+	}
+	return "-" + cadence.UFix64(before-after).String()
+}
+
+// nonNil returns an empty slice instead of nil so the JSON output contains an empty array.
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,216 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/events"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+type flagsWait struct {
+	Status      string        `default:"sealed" flag:"status" info:"Status to wait for: pending, finalized, executed or sealed"`
+	Timeout     time.Duration `default:"5m" flag:"timeout" info:"Maximum time to wait for the transactions"`
+	CadenceJSON string        `default:"full" flag:"cadence-json" info:"Encoding of Cadence values in the JSON output (full, plain)"`
+}
+
+var waitFlags = flagsWait{}
+
+var WaitCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "wait <tx_id> [<tx_id> ...]",
+		Short: "Wait for transactions to reach a status",
+		Example: `flow transactions wait 07a8...b433
+
+flow transactions wait 07a8...b433 1f3c...9a02 --status executed --timeout 30s`,
+		Args: cobra.MinimumNArgs(1),
+	},
+	Flags: &waitFlags,
+	Run:   wait,
+}
+
+func wait(
+	args []string,
+	_ flowkit.ReaderWriter,
+	_ command.GlobalFlags,
+	services *services.Services,
+) (command.Result, error) {
+	status, err := parseTransactionStatus(waitFlags.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	if waitFlags.Timeout <= 0 {
+		return nil, fmt.Errorf("timeout must be greater than zero")
+	}
+
+	err = command.ValidateCadenceJSON(waitFlags.CadenceJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]flow.Identifier, 0, len(args))
+	for _, arg := range args {
+		id := flow.HexToID(strings.TrimPrefix(arg, "0x"))
+		if id == flow.EmptyID {
+			return nil, fmt.Errorf("invalid transaction ID: %s", arg)
+		}
+		ids = append(ids, id)
+	}
+
+	results := services.Transactions.Wait(ids, status, waitFlags.Timeout)
+
+	return &WaitResult{results: results, status: status, cadenceJSON: waitFlags.CadenceJSON}, nil
+}
+
+// parseTransactionStatus parses the status a transaction can be waited for.
+func parseTransactionStatus(value string) (flow.TransactionStatus, error) {
+	statuses := []flow.TransactionStatus{
+		flow.TransactionStatusPending,
+		flow.TransactionStatusFinalized,
+		flow.TransactionStatusExecuted,
+		flow.TransactionStatusSealed,
+	}
+
+	for _, s := range statuses {
+		if strings.EqualFold(s.String(), value) {
+			return s, nil
+		}
+	}
+
+	return flow.TransactionStatusUnknown, fmt.Errorf(
+		"invalid status %s, valid statuses are: pending, finalized, executed and sealed", value,
+	)
+}
+
+// WaitResult represents the results of waiting for transactions.
+type WaitResult struct {
+	results     []services.WaitResult
+	status      flow.TransactionStatus
+	cadenceJSON string
+}
+
+func (r *WaitResult) JSON() interface{} {
+	result := make([]interface{}, 0, len(r.results))
+
+	for _, res := range r.results {
+		item := make(map[string]interface{})
+		item["id"] = res.ID.String()
+		if res.Result != nil {
+			item["status"] = res.Result.Status.String()
+			item["events"] = eventsJSON(res.Result.Events, r.cadenceJSON)
+			if res.Result.Error != nil {
+				item["error"] = res.Result.Error.Error()
+			}
+		}
+		if res.Err != nil {
+			item["error"] = res.Err.Error()
+		}
+
+		result = append(result, item)
+	}
+
+	return result
+}
+
+func (r *WaitResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	failed := 0
+	_, _ = fmt.Fprintf(writer, "ID\tStatus\tEvents\n")
+	for _, res := range r.results {
+		status, events := "-", "-"
+		if res.Result != nil {
+			status = res.Result.Status.String()
+			events = fmt.Sprintf("%d", len(res.Result.Events))
+		}
+
+		errMsg := ""
+		if res.Err != nil {
+			errMsg = res.Err.Error()
+		} else if res.Result != nil && res.Result.Error != nil {
+			errMsg = res.Result.Error.Error()
+		} else if res.Result != nil && res.Result.Status == flow.TransactionStatusExpired {
+			errMsg = "transaction expired"
+		}
+
+		if errMsg != "" {
+			failed++
+			status = fmt.Sprintf("%s %s", output.ErrorEmoji(), status)
+		} else {
+			status = fmt.Sprintf("%s %s", output.OkEmoji(), status)
+		}
+
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\n", res.ID, status, events)
+		if errMsg != "" {
+			_, _ = fmt.Fprintf(writer, "\t%s\n", errMsg)
+		}
+	}
+
+	_ = writer.Flush()
+
+	for _, res := range r.results {
+		if res.Result == nil || len(res.Result.Events) == 0 {
+			continue
+		}
+
+		e := events.EventResult{
+			Events: res.Result.Events,
+		}
+		_, _ = fmt.Fprintf(&b, "\nEvents of %s:%s", res.ID, e.String())
+	}
+
+	_, _ = fmt.Fprintf(
+		&b,
+		"\nWaited for %d transactions to be %s, %d failed\n",
+		len(r.results),
+		strings.ToLower(r.status.String()),
+		failed,
+	)
+
+	return b.String()
+}
+
+func (r *WaitResult) Oneliner() string {
+	result := ""
+	for _, res := range r.results {
+		result += fmt.Sprintf("ID: %s", res.ID)
+		if res.Result != nil {
+			result += fmt.Sprintf(", Status: %s, Events: %s", res.Result.Status, res.Result.Events)
+		}
+		if res.Err != nil {
+			result += fmt.Sprintf(", Error: %s", res.Err)
+		}
+		result += "; "
+	}
+
+	return result
+}
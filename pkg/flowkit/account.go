@@ -19,7 +19,9 @@
 package flowkit
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
@@ -65,10 +67,146 @@ func (a *Account) SetName(name string) {
 	a.name = name
 }
 
-func accountsFromConfig(conf *config.Config) (Accounts, error) {
+// Key fields compared with the on-chain account key.
+const (
+	KeyFieldIndex     = "index"
+	KeyFieldPublicKey = "public key"
+	KeyFieldSigAlgo   = "signature algorithm"
+	KeyFieldHashAlgo  = "hash algorithm"
+	KeyFieldWeight    = "weight"
+	KeyFieldRevoked   = "revoked"
+)
+
+// KeyMismatch is a difference between the configured account key and the on-chain account key.
+type KeyMismatch struct {
+	Field      string
+	Configured string
+	OnChain    string
+}
+
+func (k KeyMismatch) String() string {
+	return fmt.Sprintf("%s: configured %s, on-chain %s", k.Field, k.Configured, k.OnChain)
+}
+
+// KeyMismatchError is returned when the configured account key doesn't match the on-chain account key.
+type KeyMismatchError struct {
+	Account    string
+	Address    flow.Address
+	KeyIndex   int
+	Mismatches []KeyMismatch
+}
+
+func (e *KeyMismatchError) Error() string {
+	mismatches := make([]string, 0, len(e.Mismatches))
+	for _, m := range e.Mismatches {
+		mismatches = append(mismatches, m.String())
+	}
+
+	return fmt.Sprintf(
+		"configured key %d of account %s (%s) doesn't match the on-chain key: %s",
+		e.KeyIndex,
+		e.Account,
+		e.Address,
+		strings.Join(mismatches, "; "),
+	)
+}
+
+// VerifyKey compares the account key with the on-chain account key and returns all the mismatches.
+//
+// The public key, index, algorithms and revoked flag must match the configured key.
+// A key weight below the threshold is reported since the key can't sign transactions alone.
+func (a *Account) VerifyKey(onChain *flow.Account) ([]KeyMismatch, error) {
+	mismatches := make([]KeyMismatch, 0)
+
+	publicKey, err := a.key.PublicKey(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive public key for account %s: %w", a.name, err)
+	}
+
+	var key *flow.AccountKey
+	for _, k := range onChain.Keys {
+		if k.Index == a.key.Index() {
+			key = k
+		}
+	}
+
+	if key == nil {
+		found := "missing"
+		for _, k := range onChain.Keys {
+			if k.PublicKey.Equals(publicKey) {
+				found = fmt.Sprintf("public key found at index %d", k.Index)
+			}
+		}
+
+		return append(mismatches, KeyMismatch{
+			Field:      KeyFieldIndex,
+			Configured: fmt.Sprintf("%d", a.key.Index()),
+			OnChain:    found,
+		}), nil
+	}
+
+	if !key.PublicKey.Equals(publicKey) {
+		onChainKey := key.PublicKey.String()
+		for _, k := range onChain.Keys {
+			if k.PublicKey.Equals(publicKey) {
+				onChainKey = fmt.Sprintf("%s (configured public key found at index %d)", onChainKey, k.Index)
+			}
+		}
+
+		mismatches = append(mismatches, KeyMismatch{
+			Field:      KeyFieldPublicKey,
+			Configured: publicKey.String(),
+			OnChain:    onChainKey,
+		})
+	}
+
+	if key.SigAlgo != a.key.SigAlgo() {
+		mismatches = append(mismatches, KeyMismatch{
+			Field:      KeyFieldSigAlgo,
+			Configured: a.key.SigAlgo().String(),
+			OnChain:    key.SigAlgo.String(),
+		})
+	}
+
+	if key.HashAlgo != a.key.HashAlgo() {
+		mismatches = append(mismatches, KeyMismatch{
+			Field:      KeyFieldHashAlgo,
+			Configured: a.key.HashAlgo().String(),
+			OnChain:    key.HashAlgo.String(),
+		})
+	}
+
+	if key.Weight < flow.AccountKeyWeightThreshold {
+		mismatches = append(mismatches, KeyMismatch{
+			Field:      KeyFieldWeight,
+			Configured: fmt.Sprintf("%d", flow.AccountKeyWeightThreshold),
+			OnChain:    fmt.Sprintf("%d", key.Weight),
+		})
+	}
+
+	if key.Revoked {
+		mismatches = append(mismatches, KeyMismatch{
+			Field:      KeyFieldRevoked,
+			Configured: "false",
+			OnChain:    "true",
+		})
+	}
+
+	return mismatches, nil
+}
+
+func accountsFromConfig(conf *config.Config, readerWriter ReaderWriter) (Accounts, error) {
 	var accounts Accounts
 
 	for _, accountConf := range conf.Accounts {
+		if accountConf.Key.Type == config.KeyTypeFile {
+			privateKey, err := readKeyFile(readerWriter, accountConf.Key)
+			if err != nil {
+				return nil, fmt.Errorf("invalid key file of account %s: %w", accountConf.Name, err)
+			}
+			accountConf.Key.PrivateKey = privateKey
+		}
+
 		acc, err := fromConfig(accountConf)
 		if err != nil {
 			return nil, err
@@ -80,6 +218,16 @@ func accountsFromConfig(conf *config.Config) (Accounts, error) {
 	return accounts, nil
 }
 
+// readKeyFile reads the private key in hex representation from the key file location.
+func readKeyFile(readerWriter ReaderWriter, key config.AccountKey) (crypto.PrivateKey, error) {
+	data, err := readerWriter.ReadFile(key.LocationPath())
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.DecodePrivateKeyHex(key.SigAlgo, strings.TrimPrefix(strings.TrimSpace(string(data)), "0x"))
+}
+
 func accountsToConfig(accounts Accounts) config.Accounts {
 	accountConfs := make([]config.Account, 0)
 
@@ -178,7 +326,7 @@ func (a Accounts) ByName(name string) (*Account, error) {
 func (a *Accounts) AddOrUpdate(account *Account) {
 	for i, acc := range *a {
 		if acc.name == account.name {
-			(*a)[i] = acc
+			(*a)[i] = *account
 			return
 		}
 	}
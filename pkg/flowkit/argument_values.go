@@ -0,0 +1,622 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+)
+
+// parseLiteral parses the literal as a value of the type.
+//
+// String values don't need to be quoted and addresses don't need the 0x prefix or are references like @alice.
+func (p *ArgumentParser) parseLiteral(literal string, ty sema.Type) (cadence.Value, error) {
+	_, isAddress := ty.(*sema.AddressType)
+	if _, ok := config.AddressReference(literal); ok && isAddressType(ty) {
+		literal = strconv.Quote(literal)
+	} else if isAddress && !strings.Contains(literal, "0x") {
+		literal = fmt.Sprintf("0x%s", literal)
+	}
+
+	if ty == sema.StringType && !strings.HasPrefix(literal, "\"") {
+		literal = "\"" + literal + "\""
+	}
+
+	expression, errs := parser2.ParseExpression(literal)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("expected %s, got `%s`", typeDescription(ty), literal)
+	}
+
+	return p.literalValue(expression, ty)
+}
+
+// literalValue converts the literal expression to a value of the type.
+//
+// In addition to the literals supported by the Cadence runtime, structs are written as dictionaries
+// with the field names as keys and fixed-point numbers can be written without a fractional part.
+func (p *ArgumentParser) literalValue(expression ast.Expression, ty sema.Type) (cadence.Value, error) {
+	switch ty := ty.(type) {
+	case *sema.OptionalType:
+		if _, ok := expression.(*ast.NilExpression); ok {
+			return cadence.NewOptional(nil), nil
+		}
+
+		value, err := p.literalValue(expression, ty.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		return cadence.NewOptional(value), nil
+
+	case *sema.VariableSizedType, *sema.ConstantSizedType:
+		array, ok := expression.(*ast.ArrayExpression)
+		if !ok {
+			return nil, literalTypeError(expression, ty)
+		}
+
+		elements := make([]interface{}, 0, len(array.Values))
+		for _, element := range array.Values {
+			elements = append(elements, element)
+		}
+
+		return arrayValue(elements, ty.(sema.ArrayType), func(element interface{}, elementType sema.Type) (cadence.Value, error) {
+			return p.literalValue(element.(ast.Expression), elementType)
+		})
+
+	case *sema.DictionaryType:
+		dictionary, ok := expression.(*ast.DictionaryExpression)
+		if !ok {
+			return nil, literalTypeError(expression, ty)
+		}
+
+		pairs := make([]cadence.KeyValuePair, 0, len(dictionary.Entries))
+		for _, entry := range dictionary.Entries {
+			key, err := p.literalValue(entry.Key, ty.KeyType)
+			if err != nil {
+				return nil, fmt.Errorf("key %s: %w", entry.Key, err)
+			}
+
+			value, err := p.literalValue(entry.Value, ty.ValueType)
+			if err != nil {
+				return nil, fmt.Errorf("value of key %s: %w", entry.Key, err)
+			}
+
+			pairs = append(pairs, cadence.KeyValuePair{Key: key, Value: value})
+		}
+
+		return cadence.NewDictionary(pairs), nil
+
+	case *sema.CompositeType:
+		dictionary, ok := expression.(*ast.DictionaryExpression)
+		if !ok {
+			return nil, literalTypeError(expression, ty)
+		}
+
+		fields := make(map[string]interface{}, len(dictionary.Entries))
+		for _, entry := range dictionary.Entries {
+			var name string
+			switch key := entry.Key.(type) {
+			case *ast.IdentifierExpression:
+				name = key.Identifier.Identifier
+			case *ast.StringExpression:
+				name = key.Value
+			default:
+				return nil, fmt.Errorf("expected a field name, got `%s`", entry.Key)
+			}
+
+			fields[name] = entry.Value
+		}
+
+		return structValue(fields, ty, func(field interface{}, fieldType sema.Type) (cadence.Value, error) {
+			return p.literalValue(field.(ast.Expression), fieldType)
+		})
+
+	case *sema.AddressType:
+		// address references are written as strings in arrays, dictionaries and structs
+		if str, ok := expression.(*ast.StringExpression); ok {
+			if _, ok := config.AddressReference(str.Value); ok {
+				address, err := p.resolveAddress(str.Value)
+				if err != nil {
+					return nil, err
+				}
+
+				return cadence.NewAddress(address), nil
+			}
+		}
+	}
+
+	// integers are accepted as fixed-point numbers without a fractional part
+	if integer, ok := expression.(*ast.IntegerExpression); ok && sema.IsSubType(ty, sema.FixedPointType) {
+		expression = &ast.FixedPointExpression{
+			Negative:        integer.Value.Sign() < 0,
+			UnsignedInteger: new(big.Int).Abs(integer.Value),
+			Fractional:      new(big.Int),
+			Scale:           1,
+			Range:           integer.Range,
+		}
+	}
+
+	value, err := runtime.LiteralValue(expression, ty)
+	switch err {
+	case nil:
+		return value, nil
+	case runtime.LiteralExpressionTypeError:
+		return nil, literalTypeError(expression, ty)
+	case runtime.InvalidLiteralError:
+		return nil, fmt.Errorf("`%s` is not a valid `%s` value", expression, ty)
+	case runtime.UnsupportedLiteralError:
+		return nil, unsupportedTypeError(ty)
+	}
+
+	return nil, err
+}
+
+// plainValue converts a value decoded from plain JSON, with numbers decoded as json.Number, to a value of the type.
+//
+// Numbers, addresses and paths can be written as strings, structs are written as objects with the field names as keys.
+func (p *ArgumentParser) plainValue(value interface{}, ty sema.Type) (cadence.Value, error) {
+	switch ty := ty.(type) {
+	case *sema.OptionalType:
+		if value == nil {
+			return cadence.NewOptional(nil), nil
+		}
+
+		inner, err := p.plainValue(value, ty.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		return cadence.NewOptional(inner), nil
+
+	case *sema.VariableSizedType, *sema.ConstantSizedType:
+		elements, ok := value.([]interface{})
+		if !ok {
+			return nil, plainTypeError(value, ty)
+		}
+
+		return arrayValue(elements, ty.(sema.ArrayType), p.plainValue)
+
+	case *sema.DictionaryType:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, plainTypeError(value, ty)
+		}
+
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		pairs := make([]cadence.KeyValuePair, 0, len(object))
+		for _, key := range keys {
+			keyValue, err := p.plainValue(key, ty.KeyType)
+			if err != nil {
+				return nil, fmt.Errorf("key %s: %w", key, err)
+			}
+
+			elementValue, err := p.plainValue(object[key], ty.ValueType)
+			if err != nil {
+				return nil, fmt.Errorf("value of key %s: %w", key, err)
+			}
+
+			pairs = append(pairs, cadence.KeyValuePair{Key: keyValue, Value: elementValue})
+		}
+
+		return cadence.NewDictionary(pairs), nil
+
+	case *sema.CompositeType:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, plainTypeError(value, ty)
+		}
+
+		return structValue(object, ty, p.plainValue)
+	}
+
+	switch value := value.(type) {
+	case string:
+		if ty == sema.StringType {
+			return cadence.NewString(value), nil
+		}
+
+		return p.parseLiteral(value, ty)
+
+	case json.Number:
+		if ty == sema.StringType {
+			return nil, plainTypeError(value, ty)
+		}
+
+		return p.parseLiteral(value.String(), ty)
+
+	case bool:
+		if ty == sema.BoolType {
+			return cadence.NewBool(value), nil
+		}
+	}
+
+	if !literalSupported(ty) {
+		return nil, unsupportedTypeError(ty)
+	}
+
+	return nil, plainTypeError(value, ty)
+}
+
+// jsonArgument converts an argument decoded from JSON to a value of the type, objects with only a type and
+// a value key are decoded as JSON-Cadence and other values are converted as plain JSON.
+func (p *ArgumentParser) jsonArgument(argument interface{}, ty sema.Type) (cadence.Value, error) {
+	if object, ok := argument.(map[string]interface{}); ok && len(object) == 2 {
+		_, hasValue := object["value"]
+		if _, hasType := object["type"].(string); hasType && hasValue {
+			return p.jsonCadenceValue(object)
+		}
+	}
+
+	return p.plainValue(argument, ty)
+}
+
+// jsonCadenceValue decodes a JSON-Cadence value decoded from JSON, with address references resolved.
+func (p *ArgumentParser) jsonCadenceValue(value interface{}) (cadence.Value, error) {
+	resolved, err := p.resolveJSONAddresses(value)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.Marshal(resolved)
+	if err != nil {
+		return nil, err
+	}
+
+	return jsoncdc.Decode(encoded)
+}
+
+// resolveJSONAddresses replaces address references in the JSON-Cadence Address values decoded from JSON.
+func (p *ArgumentParser) resolveJSONAddresses(value interface{}) (interface{}, error) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		if reference, ok := value["value"].(string); ok && value["type"] == "Address" {
+			if _, ok := config.AddressReference(reference); ok {
+				address, err := p.resolveAddress(reference)
+				if err != nil {
+					return nil, err
+				}
+
+				return map[string]interface{}{"type": "Address", "value": "0x" + address.Hex()}, nil
+			}
+		}
+
+		resolved := make(map[string]interface{}, len(value))
+		for key, element := range value {
+			element, err := p.resolveJSONAddresses(element)
+			if err != nil {
+				return nil, err
+			}
+			resolved[key] = element
+		}
+
+		return resolved, nil
+
+	case []interface{}:
+		resolved := make([]interface{}, 0, len(value))
+		for _, element := range value {
+			element, err := p.resolveJSONAddresses(element)
+			if err != nil {
+				return nil, err
+			}
+			resolved = append(resolved, element)
+		}
+
+		return resolved, nil
+	}
+
+	return value, nil
+}
+
+// resolveAddress returns the address of the account or contract referenced like @alice.
+func (p *ArgumentParser) resolveAddress(reference string) (flow.Address, error) {
+	name, _ := config.AddressReference(reference)
+	if p.addresses == nil {
+		return flow.EmptyAddress, fmt.Errorf("address reference %s can not be resolved without configuration", reference)
+	}
+
+	address, err := p.addresses(name)
+	if err != nil {
+		return flow.EmptyAddress, fmt.Errorf("address reference %s can not be resolved: %w", reference, err)
+	}
+
+	return address, nil
+}
+
+// isAddressType returns whether the type is Address or an optional Address.
+func isAddressType(ty sema.Type) bool {
+	if optional, ok := ty.(*sema.OptionalType); ok {
+		return isAddressType(optional.Type)
+	}
+
+	_, ok := ty.(*sema.AddressType)
+	return ok
+}
+
+// arrayValue converts the elements to an array of the type using the element conversion.
+func arrayValue(
+	elements []interface{},
+	ty sema.ArrayType,
+	convert func(interface{}, sema.Type) (cadence.Value, error),
+) (cadence.Value, error) {
+	if constantSized, ok := ty.(*sema.ConstantSizedType); ok && int64(len(elements)) != constantSized.Size {
+		return nil, fmt.Errorf("expected %d elements, got %d", constantSized.Size, len(elements))
+	}
+
+	values := make([]cadence.Value, 0, len(elements))
+	for i, element := range elements {
+		value, err := convert(element, ty.ElementType(false))
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+
+		values = append(values, value)
+	}
+
+	return cadence.NewArray(values), nil
+}
+
+// structValue converts the fields by name to a struct of the type using the field conversion,
+// all the fields of the struct are required.
+func structValue(
+	fields map[string]interface{},
+	ty *sema.CompositeType,
+	convert func(interface{}, sema.Type) (cadence.Value, error),
+) (cadence.Value, error) {
+	if ty.Kind != common.CompositeKindStructure {
+		return nil, unsupportedTypeError(ty)
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !isField(ty, name) {
+			return nil, fmt.Errorf("unknown field `%s` of `%s`", name, ty.QualifiedIdentifier())
+		}
+	}
+
+	structType := &cadence.StructType{
+		Location:            ty.Location,
+		QualifiedIdentifier: ty.QualifiedIdentifier(),
+		Fields:              make([]cadence.Field, 0, len(ty.Fields)),
+	}
+	values := make([]cadence.Value, 0, len(ty.Fields))
+
+	for _, name := range ty.Fields {
+		member, _ := ty.Members.Get(name)
+		fieldType := member.TypeAnnotation.Type
+
+		field, ok := fields[name]
+		if !ok {
+			return nil, fmt.Errorf("missing field `%s` of `%s`", name, ty.QualifiedIdentifier())
+		}
+
+		value, err := convert(field, fieldType)
+		if err != nil {
+			return nil, fmt.Errorf("field `%s`: %w", name, err)
+		}
+
+		structType.Fields = append(structType.Fields, cadence.Field{
+			Identifier: name,
+			Type:       runtime.ExportType(fieldType, map[sema.TypeID]cadence.Type{}),
+		})
+		values = append(values, value)
+	}
+
+	return cadence.NewStruct(values).WithType(structType), nil
+}
+
+func isField(ty *sema.CompositeType, name string) bool {
+	for _, field := range ty.Fields {
+		if field == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// literalSupported returns whether values of the type can be parsed from literals.
+func literalSupported(ty sema.Type) bool {
+	switch ty := ty.(type) {
+	case *sema.OptionalType:
+		return literalSupported(ty.Type)
+	case *sema.VariableSizedType:
+		return literalSupported(ty.Type)
+	case *sema.ConstantSizedType:
+		return literalSupported(ty.Type)
+	case *sema.DictionaryType:
+		return literalSupported(ty.KeyType) && literalSupported(ty.ValueType)
+	case *sema.CompositeType:
+		return ty.Kind == common.CompositeKindStructure
+	case *sema.AddressType:
+		return true
+	}
+
+	return ty == sema.BoolType ||
+		ty == sema.StringType ||
+		sema.IsSubType(ty, sema.IntegerType) ||
+		sema.IsSubType(ty, sema.FixedPointType) ||
+		sema.IsSubType(ty, sema.PathType)
+}
+
+// typeDescription describes the values of the type in errors.
+func typeDescription(ty sema.Type) string {
+	switch ty := ty.(type) {
+	case *sema.OptionalType:
+		return fmt.Sprintf("nil or %s", typeDescription(ty.Type))
+	case *sema.VariableSizedType:
+		return "an array"
+	case *sema.ConstantSizedType:
+		return fmt.Sprintf("an array of %d elements", ty.Size)
+	case *sema.DictionaryType:
+		return "a dictionary"
+	case *sema.CompositeType:
+		return fmt.Sprintf("`%s` struct fields", ty.QualifiedIdentifier())
+	case *sema.AddressType:
+		return "an address"
+	}
+
+	switch {
+	case ty == sema.BoolType:
+		return "true or false"
+	case ty == sema.StringType:
+		return "a string"
+	case sema.IsSubType(ty, sema.IntegerType):
+		return "an integer"
+	case sema.IsSubType(ty, sema.FixedPointType):
+		return "a fixed-point number"
+	case sema.IsSubType(ty, sema.PathType):
+		return "a path"
+	}
+
+	return fmt.Sprintf("a `%s` value", ty)
+}
+
+func literalTypeError(expression ast.Expression, ty sema.Type) error {
+	return fmt.Errorf("expected %s, got `%s`", typeDescription(ty), expression)
+}
+
+func plainTypeError(value interface{}, ty sema.Type) error {
+	encoded, _ := json.Marshal(value)
+	return fmt.Errorf("expected %s, got %s", typeDescription(ty), encoded)
+}
+
+func unsupportedTypeError(ty sema.Type) error {
+	return fmt.Errorf("arguments of type `%s` are not supported, use JSON-Cadence", ty)
+}
+
+// ResolveArgumentTypes returns the arguments with the locations of struct types declared in files, like the types
+// of arguments parsed with an ArgumentParser, replaced by the locations returned by resolve.
+func ResolveArgumentTypes(
+	args []cadence.Value,
+	resolve func(location common.StringLocation, qualifiedIdentifier string) (common.Location, error),
+) ([]cadence.Value, error) {
+	types := make(map[*cadence.StructType]*cadence.StructType)
+
+	var resolveValue func(value cadence.Value) (cadence.Value, error)
+	resolveValue = func(value cadence.Value) (cadence.Value, error) {
+		switch value := value.(type) {
+		case cadence.Optional:
+			if value.Value == nil {
+				return value, nil
+			}
+
+			inner, err := resolveValue(value.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			return cadence.NewOptional(inner), nil
+
+		case cadence.Array:
+			values, err := resolveValues(value.Values, resolveValue)
+			if err != nil {
+				return nil, err
+			}
+
+			return cadence.NewArray(values), nil
+
+		case cadence.Dictionary:
+			pairs := make([]cadence.KeyValuePair, 0, len(value.Pairs))
+			for _, pair := range value.Pairs {
+				key, err := resolveValue(pair.Key)
+				if err != nil {
+					return nil, err
+				}
+
+				element, err := resolveValue(pair.Value)
+				if err != nil {
+					return nil, err
+				}
+
+				pairs = append(pairs, cadence.KeyValuePair{Key: key, Value: element})
+			}
+
+			return cadence.NewDictionary(pairs), nil
+
+		case cadence.Struct:
+			fields, err := resolveValues(value.Fields, resolveValue)
+			if err != nil {
+				return nil, err
+			}
+
+			structType := value.StructType
+			if location, ok := structType.Location.(common.StringLocation); ok {
+				resolved, ok := types[value.StructType]
+				if !ok {
+					resolvedLocation, err := resolve(location, structType.QualifiedIdentifier)
+					if err != nil {
+						return nil, err
+					}
+
+					copied := *structType
+					copied.Location = resolvedLocation
+					resolved = &copied
+					types[value.StructType] = resolved
+				}
+
+				structType = resolved
+			}
+
+			return cadence.NewStruct(fields).WithType(structType), nil
+		}
+
+		return value, nil
+	}
+
+	return resolveValues(args, resolveValue)
+}
+
+func resolveValues(values []cadence.Value, resolve func(cadence.Value) (cadence.Value, error)) ([]cadence.Value, error) {
+	resolved := make([]cadence.Value, 0, len(values))
+	for _, value := range values {
+		value, err := resolve(value)
+		if err != nil {
+			return nil, err
+		}
+
+		resolved = append(resolved, value)
+	}
+
+	return resolved, nil
+}
@@ -19,18 +19,22 @@
 package flowkit
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"path"
 	"strconv"
 	"strings"
 
 	"github.com/onflow/cadence"
 	jsoncdc "github.com/onflow/cadence/encoding/json"
-	"github.com/onflow/cadence/runtime"
 	"github.com/onflow/cadence/runtime/ast"
-	"github.com/onflow/cadence/runtime/cmd"
 	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/parser2"
 	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/spf13/afero"
 )
 
 type CadenceArgument struct {
@@ -65,35 +69,175 @@ func ParseArgumentsJSON(input string) ([]cadence.Value, error) {
 	return cadenceArgs, nil
 }
 
+// ParseArgumentsCommaSplit parses arguments in the Type:Value format.
+//
+// The type is split from the value at the first colon outside of brackets, so dictionary types and values containing
+// colons are supported. Values of arrays, dictionaries, optionals, paths and numbers are parsed as Cadence literals.
 func ParseArgumentsCommaSplit(input []string) ([]cadence.Value, error) {
-	args := make([]map[string]interface{}, 0)
-
-	if len(input) == 0 {
-		return make([]cadence.Value, 0), nil
-	}
+	return (&ArgumentParser{}).parseTyped(input)
+}
 
-	for _, in := range input {
-		argInput := strings.Split(in, ":")
+// parseTyped parses arguments in the Type:Value format, see ParseArgumentsCommaSplit.
+func (p *ArgumentParser) parseTyped(input []string) ([]cadence.Value, error) {
+	cadenceArgs := make([]cadence.Value, 0, len(input))
 
-		if len(argInput) != 2 {
+	for _, in := range joinSplitArguments(input) {
+		argType, argValue, ok := splitTypedArgument(in)
+		if !ok {
 			return nil, fmt.Errorf(
 				"argument not passed in correct format, correct format is: Type:Value, got %s",
 				in,
 			)
 		}
 
-		argType := argInput[0]
-		argValue := argInput[1]
-		args = append(args, map[string]interface{}{
+		if semaType := parseArgumentType(argType); semaType != nil {
+			value, err := p.parseLiteral(argValue, semaType)
+			if err != nil {
+				return nil, fmt.Errorf("argument `%s` is not expected type `%s`: %w", in, argType, err)
+			}
+
+			cadenceArgs = append(cadenceArgs, value)
+			continue
+		}
+
+		jsonArg, _ := json.Marshal(map[string]interface{}{
 			"value": processValue(argType, argValue),
 			"type":  argType,
 		})
+		value, err := jsoncdc.Decode(jsonArg)
+		if err != nil {
+			return nil, err
+		}
+
+		cadenceArgs = append(cadenceArgs, value)
+	}
+
+	return cadenceArgs, nil
+}
+
+// joinSplitArguments joins arguments split at commas inside brackets or strings of array,
+// dictionary and struct values, as argument flags are split at every comma.
+func joinSplitArguments(input []string) []string {
+	joined := make([]string, 0, len(input))
+
+	open := false
+	for _, in := range input {
+		if open {
+			joined[len(joined)-1] += "," + in
+		} else {
+			joined = append(joined, in)
+			if !bracketed(in) {
+				continue
+			}
+		}
+		open = !balanced(joined[len(joined)-1])
+	}
+
+	return joined
+}
+
+// bracketed returns whether the type of the argument in the Type:Value format is a collection
+// or struct type, with values written in brackets which can contain commas.
+func bracketed(argument string) bool {
+	argType, _, ok := splitTypedArgument(argument)
+	if !ok {
+		return false
+	}
+
+	astType, errs := parser2.ParseType(argType)
+	if len(errs) > 0 {
+		return false
+	}
+
+	for {
+		optional, ok := astType.(*ast.OptionalType)
+		if !ok {
+			break
+		}
+		astType = optional.Type
+	}
+
+	switch astType.(type) {
+	case *ast.VariableSizedType, *ast.ConstantSizedType, *ast.DictionaryType:
+		return true
+	case *ast.NominalType:
+		// types which are not built-in are declared by programs, like structs
+		checker, err := sema.NewChecker(&ast.Program{}, common.StringLocation(""))
+		if err != nil {
+			return false
+		}
+
+		return checker.ConvertType(astType).IsInvalidType()
+	}
+
+	return false
+}
+
+// balanced returns whether all brackets and strings in the argument are closed.
+func balanced(argument string) bool {
+	depth := 0
+	inString := false
+	escaped := false
+	for _, r := range argument {
+		switch {
+		case escaped:
+			escaped = false
+		case inString && r == '\\':
+			escaped = true
+		case r == '"':
+			inString = !inString
+		case inString:
+		case r == '[' || r == '{':
+			depth++
+		case r == ']' || r == '}':
+			depth--
+		}
 	}
 
-	jsonArgs, _ := json.Marshal(args)
-	cadenceArgs, err := ParseArgumentsJSON(string(jsonArgs))
+	return depth <= 0 && !inString
+}
+
+// splitTypedArgument splits the argument in the Type:Value format at the first colon outside of brackets.
+func splitTypedArgument(argument string) (string, string, bool) {
+	depth := 0
+	for i, r := range argument {
+		switch r {
+		case '[', '{', '(', '<':
+			depth++
+		case ']', '}', ')', '>':
+			depth--
+		case ':':
+			if depth == 0 {
+				return argument[:i], argument[i+1:], true
+			}
+		}
+	}
 
-	return cadenceArgs, err
+	return "", "", false
+}
+
+// parseArgumentType returns the type of a typed argument if its values are parsed as literals,
+// strings, booleans and types not supported by literals are encoded as JSON-Cadence instead.
+func parseArgumentType(argType string) sema.Type {
+	astType, errs := parser2.ParseType(argType)
+	if len(errs) > 0 {
+		return nil
+	}
+
+	checker, err := sema.NewChecker(&ast.Program{}, common.StringLocation(""))
+	if err != nil {
+		return nil
+	}
+
+	semaType := checker.ConvertType(astType)
+	if semaType.IsInvalidType() ||
+		semaType == sema.StringType ||
+		semaType == sema.BoolType ||
+		!literalSupported(semaType) {
+		return nil
+	}
+
+	return semaType
 }
 
 // sanitizeAddressArg sanitize address and make sure it has 0x prefix
@@ -108,11 +252,27 @@ func processValue(argType string, argValue string) interface{} {
 	return argValue
 }
 
-func ParseArguments(args []string, argsJSON string) (scriptArgs []cadence.Value, err error) {
+// AddressResolver returns the address of the account or contract with the name,
+// used for Address arguments written as references like @alice or account:alice.
+type AddressResolver func(name string) (flow.Address, error)
+
+// ParseArguments parses arguments in JSON-Cadence format if argsJSON is set, otherwise in the Type:Value format.
+func ParseArguments(args []string, argsJSON string) ([]cadence.Value, error) {
+	return ParseArgumentsWithResolver(args, argsJSON, nil)
+}
+
+// ParseArgumentsWithResolver parses arguments like ParseArguments with Address arguments
+// written as references resolved with the address resolver.
+func ParseArgumentsWithResolver(
+	args []string,
+	argsJSON string,
+	addresses AddressResolver,
+) (scriptArgs []cadence.Value, err error) {
+	parser := &ArgumentParser{addresses: addresses}
 	if argsJSON != "" {
-		scriptArgs, err = ParseArgumentsJSON(argsJSON)
+		scriptArgs, err = parser.parseJSONCadence(argsJSON)
 	} else {
-		scriptArgs, err = ParseArgumentsCommaSplit(args)
+		scriptArgs, err = parser.parseTyped(args)
 	}
 	if err != nil {
 		return nil, err
@@ -121,63 +281,347 @@ func ParseArguments(args []string, argsJSON string) (scriptArgs []cadence.Value,
 	return
 }
 
+// parseJSONCadence parses a JSON array of arguments in JSON-Cadence format.
+func (p *ArgumentParser) parseJSONCadence(input string) ([]cadence.Value, error) {
+	decoder := json.NewDecoder(strings.NewReader(input))
+	decoder.UseNumber()
+
+	var args []interface{}
+	err := decoder.Decode(&args)
+	if err != nil {
+		return nil, err
+	}
+
+	cadenceArgs := make([]cadence.Value, 0, len(args))
+	for _, arg := range args {
+		value, err := p.jsonCadenceValue(arg)
+		if err != nil {
+			return nil, err
+		}
+		cadenceArgs = append(cadenceArgs, value)
+	}
+
+	return cadenceArgs, nil
+}
+
 func ParseArgumentsWithoutType(fileName string, code []byte, args []string) (scriptArgs []cadence.Value, err error) {
+	return NewArgumentParser(fileName, code).Parse(args)
+}
 
-	var resultArgs []cadence.Value = make([]cadence.Value, 0)
+// ArgumentParser parses arguments without type using the parameter types declared in the program,
+// so a program used with many sets of arguments is only parsed and checked once.
+//
+// Struct types of the parameters must be declared in imported files, a script declaring types has no entry point.
+// Struct values created by the parser have the location of the file declaring the type, see ResolveArgumentTypes.
+type ArgumentParser struct {
+	parameters []*ast.Parameter
+	types      []sema.Type
+	addresses  AddressResolver
+	err        error
+}
 
-	codes := map[common.LocationID]string{}
-	location := common.StringLocation(fileName)
-	program, must := cmd.PrepareProgram(string(code), location, codes)
-	checker, _ := cmd.PrepareChecker(program, location, codes, nil, must)
+// NewArgumentParser parses and checks the program in the code to get the types of its parameters.
+//
+// Files imported by the program are read from disk relative to the file name of the code. An invalid program
+// is reported when parsing the arguments.
+func NewArgumentParser(fileName string, code []byte) *ArgumentParser {
+	return NewArgumentParserWithReader(fileName, code, afero.Afero{Fs: afero.NewOsFs()})
+}
 
-	var parameterList []*ast.Parameter
+// NewArgumentParserWithReader creates an argument parser like NewArgumentParser which reads
+// the files imported by the program with the reader.
+func NewArgumentParserWithReader(fileName string, code []byte, readerWriter ReaderWriter) *ArgumentParser {
+	program, err := parser2.ParseProgram(string(code))
+	if err != nil {
+		return &ArgumentParser{err: err}
+	}
 
-	transactionDeclaration := program.SoleTransactionDeclaration()
-	if transactionDeclaration != nil {
-		if transactionDeclaration.ParameterList != nil {
-			parameterList = transactionDeclaration.ParameterList.Parameters
-		}
+	err = checkEntryPointDeclarations(program)
+	if err != nil {
+		return &ArgumentParser{err: err}
 	}
 
-	functionDeclaration := sema.FunctionEntryPointDeclaration(program)
-	if functionDeclaration != nil {
-		if functionDeclaration.ParameterList != nil {
-			parameterList = functionDeclaration.ParameterList.Parameters
-		}
+	checker, err := newArgumentChecker(
+		program,
+		common.StringLocation(fileName),
+		readerWriter,
+		map[common.LocationID]*sema.Checker{},
+	)
+	if err != nil {
+		return &ArgumentParser{err: err}
 	}
 
-	if parameterList == nil {
+	// errors are ignored as only the types of the parameters are needed, unresolved types are reported when parsing
+	_ = checker.Check()
+
+	parser := &ArgumentParser{
+		parameters: parameters(program),
+	}
+	for _, parameter := range parser.parameters {
+		parser.types = append(parser.types, checker.ConvertType(parameter.TypeAnnotation.Type))
+	}
+
+	return parser
+}
+
+// newArgumentChecker creates a checker of the program which imports files relative to the program location.
+func newArgumentChecker(
+	program *ast.Program,
+	location common.StringLocation,
+	readerWriter ReaderWriter,
+	checkers map[common.LocationID]*sema.Checker,
+) (*sema.Checker, error) {
+	return sema.NewChecker(
+		program,
+		location,
+		sema.WithPredeclaredValues(argumentValueDeclarations.ToSemaValueDeclarations()),
+		sema.WithPredeclaredTypes(argumentTypeDeclarations),
+		sema.WithImportHandler(
+			func(checker *sema.Checker, importedLocation common.Location, _ ast.Range) (sema.Import, error) {
+				stringLocation, ok := importedLocation.(common.StringLocation)
+				if !ok {
+					return nil, fmt.Errorf("cannot import `%s`, only files are supported", importedLocation)
+				}
+
+				fileLocation := common.StringLocation(
+					path.Join(path.Dir(string(location)), string(stringLocation)),
+				)
+
+				importedChecker, ok := checkers[fileLocation.ID()]
+				if !ok {
+					code, err := readerWriter.ReadFile(string(fileLocation))
+					if err != nil {
+						return nil, err
+					}
+
+					importedProgram, err := parser2.ParseProgram(string(code))
+					if err != nil {
+						return nil, err
+					}
+
+					importedChecker, err = newArgumentChecker(importedProgram, fileLocation, readerWriter, checkers)
+					if err != nil {
+						return nil, err
+					}
+
+					checkers[fileLocation.ID()] = importedChecker
+					_ = importedChecker.Check()
+				}
+
+				return sema.ElaborationImport{
+					Elaboration: importedChecker.Elaboration,
+				}, nil
+			},
+		),
+	)
+}
+
+var argumentValueDeclarations = append(
+	stdlib.FlowBuiltInFunctions(stdlib.DefaultFlowBuiltinImpls()),
+	stdlib.BuiltinFunctions...,
+)
+
+var argumentTypeDeclarations = append(
+	stdlib.FlowBuiltInTypes,
+	stdlib.BuiltinTypes...,
+).ToTypeDeclarations()
+
+// SetAddressResolver sets the resolver of address references like @alice in Address arguments.
+func (p *ArgumentParser) SetAddressResolver(addresses AddressResolver) *ArgumentParser {
+	p.addresses = addresses
+	return p
+}
+
+// Names returns the names of the program parameters.
+func (p *ArgumentParser) Names() []string {
+	names := make([]string, 0, len(p.parameters))
+	for _, parameter := range p.parameters {
+		names = append(names, parameter.Identifier.Identifier)
+	}
+
+	return names
+}
+
+// Parse converts the arguments to values of the parameter types in the same order.
+//
+// Arguments are Cadence literals, structs are written as dictionaries of their fields like {name: "Alice", age: 42}.
+// Strings don't need to be quoted, addresses don't need the 0x prefix and fixed-point numbers don't need
+// a fractional part. Addresses can be references like @alice or account:alice if an address resolver is set.
+func (p *ArgumentParser) Parse(args []string) ([]cadence.Value, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	var resultArgs []cadence.Value = make([]cadence.Value, 0)
+
+	if p.parameters == nil {
 		return resultArgs, nil
 	}
 
-	if len(parameterList) != len(args) {
-		return nil, fmt.Errorf("argument count is %d, expected %d", len(args), len(parameterList))
+	if len(p.parameters) != len(args) {
+		return nil, fmt.Errorf("argument count is %d, expected %d", len(args), len(p.parameters))
 	}
 
 	for index, argumentString := range args {
-		astType := parameterList[index].TypeAnnotation.Type
-		semaType := checker.ConvertType(astType)
+		value, err := p.convert(index, func(semaType sema.Type) (cadence.Value, error) {
+			return p.parseLiteral(argumentString, semaType)
+		})
+		if err != nil {
+			return nil, err
+		}
+		resultArgs = append(resultArgs, value)
+	}
+	return resultArgs, nil
+}
+
+// ParseJSON converts the arguments in a JSON array, or a JSON object with the parameter names as keys,
+// to values of the parameter types.
+//
+// Each argument is either a value in JSON-Cadence format or a plain JSON value converted using the parameter type.
+// Numbers can be written as JSON numbers or strings, structs are written as objects with the field names as keys.
+func (p *ArgumentParser) ParseJSON(data []byte) ([]cadence.Value, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var input interface{}
+	err := decoder.Decode(&input)
+	if err != nil {
+		return nil, err
+	}
 
-		switch semaType {
-		case sema.StringType:
-			if len(argumentString) > 0 && !strings.HasPrefix(argumentString, "\"") {
-				argumentString = "\"" + argumentString + "\""
+	var args []interface{}
+	switch input := input.(type) {
+	case []interface{}:
+		args = input
+	case map[string]interface{}:
+		names := p.Names()
+		for name := range input {
+			if !containsName(names, name) {
+				return nil, fmt.Errorf("unknown argument `%s`, expected arguments are: %s", name, strings.Join(names, ", "))
 			}
 		}
 
-		switch semaType.(type) {
-		case *sema.AddressType:
-			if !strings.Contains(argumentString, "0x") {
-				argumentString = fmt.Sprintf("0x%s", argumentString)
+		for _, name := range names {
+			arg, ok := input[name]
+			if !ok {
+				return nil, fmt.Errorf("missing argument `%s`", name)
 			}
-
+			args = append(args, arg)
 		}
+	default:
+		return nil, fmt.Errorf("expected a JSON array of arguments or a JSON object with the parameter names as keys")
+	}
+
+	if len(p.parameters) != len(args) {
+		return nil, fmt.Errorf("argument count is %d, expected %d", len(args), len(p.parameters))
+	}
 
-		var value, err = runtime.ParseLiteral(argumentString, semaType)
+	resultArgs := make([]cadence.Value, 0, len(args))
+	for index, arg := range args {
+		value, err := p.convert(index, func(semaType sema.Type) (cadence.Value, error) {
+			return p.jsonArgument(arg, semaType)
+		})
 		if err != nil {
-			return nil, fmt.Errorf("argument `%s` is not expected type `%s`", parameterList[index].Identifier, semaType)
+			return nil, err
 		}
 		resultArgs = append(resultArgs, value)
 	}
+
 	return resultArgs, nil
 }
+
+// convert converts the argument at the index to a value of the parameter type with an error naming the parameter.
+func (p *ArgumentParser) convert(index int, convert func(sema.Type) (cadence.Value, error)) (cadence.Value, error) {
+	parameter := p.parameters[index]
+	semaType := p.types[index]
+
+	if semaType.IsInvalidType() {
+		return nil, fmt.Errorf(
+			"type `%s` of argument `%s` can not be resolved",
+			parameter.TypeAnnotation.Type,
+			parameter.Identifier,
+		)
+	}
+
+	value, err := convert(semaType)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"argument `%s` is not expected type `%s`: %w",
+			parameter.Identifier,
+			parameter.TypeAnnotation.Type,
+			err,
+		)
+	}
+
+	return value, nil
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParameterDeclarations returns the parameters of the transaction or the script entry point formatted as "name: Type".
+func ParameterDeclarations(code []byte) ([]string, error) {
+	program, err := parser2.ParseProgram(string(code))
+	if err != nil {
+		return nil, err
+	}
+
+	declarations := make([]string, 0)
+	for _, parameter := range parameters(program) {
+		declarations = append(
+			declarations,
+			fmt.Sprintf("%s: %s", parameter.Identifier, parameter.TypeAnnotation.Type),
+		)
+	}
+
+	return declarations, nil
+}
+
+// parameters returns the parameter list of the transaction or the script entry point.
+// checkEntryPointDeclarations returns an error if the main function of a script is not an entry point
+// because the script declares types, whose parameters would otherwise be ignored.
+func checkEntryPointDeclarations(program *ast.Program) error {
+	if len(program.TransactionDeclarations()) > 0 ||
+		len(program.CompositeDeclarations())+len(program.InterfaceDeclarations()) == 0 {
+		return nil
+	}
+
+	for _, declaration := range program.FunctionDeclarations() {
+		if declaration.Identifier.Identifier == sema.FunctionEntryPointName {
+			return fmt.Errorf("script can not declare types, declare the types of the arguments in an imported file")
+		}
+	}
+
+	return nil
+}
+
+func parameters(program *ast.Program) []*ast.Parameter {
+	var parameterList []*ast.Parameter
+
+	transactionDeclaration := program.SoleTransactionDeclaration()
+	if transactionDeclaration != nil {
+		if transactionDeclaration.ParameterList != nil {
+			parameterList = transactionDeclaration.ParameterList.Parameters
+		}
+	}
+
+	functionDeclaration := sema.FunctionEntryPointDeclaration(program)
+	if functionDeclaration != nil {
+		if functionDeclaration.ParameterList != nil {
+			parameterList = functionDeclaration.ParameterList.Parameters
+		}
+	}
+
+	return parameterList
+}
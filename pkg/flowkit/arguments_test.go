@@ -20,9 +20,16 @@ package flowkit_test
 
 import (
 	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/onflow/flow-cli/pkg/flowkit"
@@ -62,3 +69,384 @@ func TestArguments(t *testing.T) {
 		assert.Equal(t, []cadence.Value{sample}, args)
 	}
 }
+
+func TestParameterDeclarations(t *testing.T) {
+	params, err := flowkit.ParameterDeclarations([]byte(`
+		transaction(amount: UFix64, to: Address, names: [String]) {}
+	`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"amount: UFix64", "to: Address", "names: [String]"}, params)
+
+	params, err = flowkit.ParameterDeclarations([]byte(`pub fun main(): Int { return 1 }`))
+	assert.NoError(t, err)
+	assert.Len(t, params, 0)
+
+	_, err = flowkit.ParameterDeclarations([]byte(`pub fun main(`))
+	assert.Error(t, err)
+}
+
+func TestArgumentParser(t *testing.T) {
+	parser := flowkit.NewArgumentParser("", []byte(`
+		pub fun main(address: Address, amount: UFix64): Void {}`))
+
+	assert.Equal(t, []string{"address", "amount"}, parser.Names())
+
+	args, err := parser.Parse([]string{"f8d6e0586b0a20c7", "1.5"})
+	assert.NoError(t, err)
+	assert.Equal(t, "0xf8d6e0586b0a20c7", args[0].String())
+	assert.Equal(t, "1.50000000", args[1].String())
+
+	args, err = parser.Parse([]string{"0x01cf0e2f2f715450", "2.0"})
+	assert.NoError(t, err)
+	assert.Equal(t, cadence.BytesToAddress([]byte{0x01, 0xcf, 0x0e, 0x2f, 0x2f, 0x71, 0x54, 0x50}), args[0])
+
+	_, err = parser.Parse([]string{"0x01"})
+	assert.EqualError(t, err, "argument count is 1, expected 2")
+
+	_, err = parser.Parse([]string{"0x01", "foo"})
+	assert.EqualError(t, err, "argument `amount` is not expected type `UFix64`: expected a fixed-point number, got `foo`")
+
+	_, err = flowkit.NewArgumentParser("", []byte(`pub fun main(`)).Parse(nil)
+	assert.Error(t, err)
+}
+
+func TestArgumentParserValues(t *testing.T) {
+	dir, err := ioutil.TempDir("", "arguments")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "People.cdc"), []byte(`
+		pub contract People {
+			pub struct Person {
+				pub let name: String
+				pub let age: UInt8
+				pub let friends: [Address]
+
+				init(name: String, age: UInt8, friends: [Address]) {
+					self.name = name
+					self.age = age
+					self.friends = friends
+				}
+			}
+		}`), 0644)
+	assert.NoError(t, err)
+
+	parser := flowkit.NewArgumentParser(filepath.Join(dir, "script.cdc"), []byte(`
+		import People from "./People.cdc"
+
+		pub fun main(
+			numbers: [Int],
+			balances: {String: UFix64},
+			nickname: String?,
+			path: PublicPath,
+			person: People.Person,
+			big: UInt256,
+			pair: [Int8; 2]
+		): Void {}`))
+
+	personType := &cadence.StructType{
+		Location:            common.StringLocation(filepath.Join(dir, "People.cdc")),
+		QualifiedIdentifier: "People.Person",
+		Fields: []cadence.Field{
+			{Identifier: "name", Type: cadence.StringType{}},
+			{Identifier: "age", Type: cadence.UInt8Type{}},
+			{Identifier: "friends", Type: cadence.VariableSizedArrayType{ElementType: cadence.AddressType{}}},
+		},
+	}
+	bigValue, _ := new(big.Int).SetString("115792089237316195423570985008687907853269984665640564039457584007913129639935", 10)
+
+	expected := []cadence.Value{
+		cadence.NewArray([]cadence.Value{cadence.NewInt(1), cadence.NewInt(-2), cadence.NewInt(1000)}),
+		cadence.NewDictionary([]cadence.KeyValuePair{
+			{Key: cadence.NewString("alice"), Value: cadence.UFix64(1000000000)},
+		}),
+		cadence.NewOptional(nil),
+		cadence.Path{Domain: "public", Identifier: "flowTokenReceiver"},
+		cadence.NewStruct([]cadence.Value{
+			cadence.NewString("Alice"),
+			cadence.NewUInt8(42),
+			cadence.NewArray([]cadence.Value{cadence.BytesToAddress([]byte{0x01})}),
+		}).WithType(personType),
+		cadence.NewUInt256FromBig(bigValue),
+		cadence.NewArray([]cadence.Value{cadence.NewInt8(1), cadence.NewInt8(2)}),
+	}
+
+	t.Run("Literals", func(t *testing.T) {
+		args, err := parser.Parse([]string{
+			"[1, -2, 1_000]",
+			`{"alice": 10}`,
+			"nil",
+			"/public/flowTokenReceiver",
+			`{name: "Alice", age: 42, friends: [0x01]}`,
+			bigValue.String(),
+			"[1, 2]",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, args)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		args, err := parser.ParseJSON([]byte(`[
+			[1, "-2", 1000],
+			{"alice": "10.0"},
+			null,
+			"/public/flowTokenReceiver",
+			{"name": "Alice", "age": 42, "friends": ["0x01"]},
+			` + bigValue.String() + `,
+			{"type": "Array", "value": [{"type": "Int8", "value": "1"}, {"type": "Int8", "value": "2"}]}
+		]`))
+		assert.NoError(t, err)
+		assert.Equal(t, expected[:6], args[:6])
+		assert.Equal(t, expected[6].String(), args[6].String())
+	})
+
+	t.Run("JSON By Name", func(t *testing.T) {
+		args, err := parser.ParseJSON([]byte(`{
+			"pair": [1, 2],
+			"big": "` + bigValue.String() + `",
+			"person": {"name": "Alice", "age": 42, "friends": ["01"]},
+			"path": "/public/flowTokenReceiver",
+			"nickname": null,
+			"balances": {"alice": 10},
+			"numbers": [1, -2, 1000]
+		}`))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, args)
+
+		_, err = parser.ParseJSON([]byte(`{"numbers": []}`))
+		assert.EqualError(t, err, "missing argument `balances`")
+
+		_, err = parser.ParseJSON([]byte(`{"amount": 1}`))
+		assert.EqualError(t, err, "unknown argument `amount`, expected arguments are: numbers, balances, nickname, path, person, big, pair")
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		valid := []string{"[]", "{}", "nil", "/public/foo", `{name: "Alice", age: 42, friends: []}`, "1", "[1, 2]"}
+		tests := []struct {
+			index int
+			arg   string
+			err   string
+		}{
+			{0, "[1, true]", "argument `numbers` is not expected type `[Int]`: element 1: expected an integer, got `true`"},
+			{1, `{"alice": -1.0}`, "argument `balances` is not expected type `{String: UFix64}`: value of key \"alice\": `-1.0` is not a valid `UFix64` value"},
+			{3, "/storage/foo", "argument `path` is not expected type `PublicPath`: path literal type StoragePath is not subtype of requested path type PublicPath"},
+			{4, `{name: "Alice", age: 300, friends: []}`, "argument `person` is not expected type `People.Person`: field `age`: `300` is not a valid `UInt8` value"},
+			{4, `{name: "Alice"}`, "argument `person` is not expected type `People.Person`: missing field `age` of `People.Person`"},
+			{4, `{name: "Alice", age: 1, friends: [], email: ""}`, "argument `person` is not expected type `People.Person`: unknown field `email` of `People.Person`"},
+			{5, "-1", "argument `big` is not expected type `UInt256`: `-1` is not a valid `UInt256` value"},
+			{6, "[1]", "argument `pair` is not expected type `[Int8; 2]`: expected 2 elements, got 1"},
+		}
+
+		for _, test := range tests {
+			args := append([]string{}, valid...)
+			args[test.index] = test.arg
+
+			_, err := parser.Parse(args)
+			assert.EqualError(t, err, test.err)
+		}
+
+		_, err := parser.ParseJSON([]byte(`[[], {}, null, "/public/foo", {"name": 1, "age": 1, "friends": []}, 1, [1, 2]]`))
+		assert.EqualError(t, err, "argument `person` is not expected type `People.Person`: field `name`: expected a string, got 1")
+	})
+}
+
+func TestArgumentParserImports(t *testing.T) {
+	dir, err := ioutil.TempDir("", "arguments")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "Profiles.cdc"), []byte(`
+		pub contract Profiles {
+			pub struct Profile {
+				pub let name: String
+				init(name: String) {
+					self.name = name
+				}
+			}
+		}`), 0644)
+	assert.NoError(t, err)
+
+	parser := flowkit.NewArgumentParser(filepath.Join(dir, "script.cdc"), []byte(`
+		import Profiles from "./Profiles.cdc"
+		import FungibleToken from 0xee82856bf20e2aa6
+
+		pub fun main(profile: Profiles.Profile, vault: FungibleToken.Vault?): Void {}`))
+
+	profileType := &cadence.StructType{
+		Location:            common.StringLocation(filepath.Join(dir, "Profiles.cdc")),
+		QualifiedIdentifier: "Profiles.Profile",
+		Fields:              []cadence.Field{{Identifier: "name", Type: cadence.StringType{}}},
+	}
+
+	values, err := parser.ParseJSON([]byte(`[{"name": "Alice"}, null]`))
+	assert.EqualError(t, err, "type `FungibleToken.Vault?` of argument `vault` can not be resolved")
+	assert.Nil(t, values)
+
+	parser = flowkit.NewArgumentParser(filepath.Join(dir, "script.cdc"), []byte(`
+		import Profiles from "./Profiles.cdc"
+
+		pub fun main(profile: Profiles.Profile): Void {}`))
+
+	values, err = parser.Parse([]string{`{name: "Alice"}`})
+	assert.NoError(t, err)
+	assert.Equal(t, []cadence.Value{
+		cadence.NewStruct([]cadence.Value{cadence.NewString("Alice")}).WithType(profileType),
+	}, values)
+
+	resolved, err := flowkit.ResolveArgumentTypes(
+		[]cadence.Value{cadence.NewOptional(values[0]), cadence.NewInt(1)},
+		func(location common.StringLocation, qualifiedIdentifier string) (common.Location, error) {
+			assert.Equal(t, profileType.Location, location)
+			assert.Equal(t, "Profiles.Profile", qualifiedIdentifier)
+			return common.AddressLocation{Address: common.BytesToAddress([]byte{0x01}), Name: "Profiles"}, nil
+		},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "A.0000000000000001.Profiles.Profile", resolved[0].(cadence.Optional).Value.Type().ID())
+	assert.Equal(t, cadence.NewInt(1), resolved[1])
+	// the parsed value is not changed
+	assert.Equal(t, profileType, values[0].(cadence.Struct).StructType)
+}
+
+func TestArgumentParserReader(t *testing.T) {
+	readerWriter := afero.Afero{Fs: afero.NewMemMapFs()}
+	err := readerWriter.WriteFile("scripts/Profiles.cdc", []byte(`
+		pub contract Profiles {
+			pub struct Profile {
+				pub let name: String
+				init(name: String) {
+					self.name = name
+				}
+			}
+		}`), 0644)
+	assert.NoError(t, err)
+
+	parser := flowkit.NewArgumentParserWithReader("scripts/script.cdc", []byte(`
+		import Profiles from "./Profiles.cdc"
+
+		pub fun main(profile: Profiles.Profile): Void {}`), readerWriter)
+
+	values, err := parser.ParseJSON([]byte(`[{"name": "Alice"}]`))
+	assert.NoError(t, err)
+	assert.Equal(t, "Profiles.Profile", values[0].(cadence.Struct).StructType.QualifiedIdentifier)
+
+	// the file is not read from disk
+	_, err = flowkit.NewArgumentParser("scripts/script.cdc", []byte(`
+		import Profiles from "./Profiles.cdc"
+
+		pub fun main(profile: Profiles.Profile): Void {}`)).ParseJSON([]byte(`[{"name": "Alice"}]`))
+	assert.Error(t, err)
+
+	// types declared in the script make it have no entry point
+	_, err = flowkit.NewArgumentParserWithReader("scripts/script.cdc", []byte(`
+		pub struct Profile {
+			pub let name: String
+			init(name: String) {
+				self.name = name
+			}
+		}
+
+		pub fun main(profile: Profile): Void {}`), readerWriter).ParseJSON([]byte(`[{"name": "Alice"}]`))
+	assert.EqualError(t, err, "script can not declare types, declare the types of the arguments in an imported file")
+}
+
+func TestParseArgumentsCommaSplit(t *testing.T) {
+	args, err := flowkit.ParseArgumentsCommaSplit([]string{
+		"String:Hello: World",
+		"Address:01",
+		"Bool:true",
+		// split at the comma like argument flags
+		"[UInt8]:[1",
+		" 2]",
+		"{String: Int}:{\"a\": 1}",
+		"UFix64?:nil",
+		"StoragePath:/storage/foo",
+		"UFix64:10",
+		// only values of collection and struct types are joined
+		"String:[unclosed",
+		"Int:1",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []cadence.Value{
+		cadence.NewString("Hello: World"),
+		cadence.BytesToAddress([]byte{0x01}),
+		cadence.NewBool(true),
+		cadence.NewArray([]cadence.Value{cadence.NewUInt8(1), cadence.NewUInt8(2)}),
+		cadence.NewDictionary([]cadence.KeyValuePair{{Key: cadence.NewString("a"), Value: cadence.NewInt(1)}}),
+		cadence.NewOptional(nil),
+		cadence.Path{Domain: "storage", Identifier: "foo"},
+		cadence.UFix64(1000000000),
+		cadence.NewString("[unclosed"),
+		cadence.NewInt(1),
+	}, args)
+
+	_, err = flowkit.ParseArgumentsCommaSplit([]string{"Hello"})
+	assert.EqualError(t, err, "argument not passed in correct format, correct format is: Type:Value, got Hello")
+
+	_, err = flowkit.ParseArgumentsCommaSplit([]string{"[UInt8]:[1, 256]"})
+	assert.EqualError(t, err, "argument `[UInt8]:[1, 256]` is not expected type `[UInt8]`: element 1: `256` is not a valid `UInt8` value")
+}
+
+func TestArgumentAddressReferences(t *testing.T) {
+	alice := flow.HexToAddress("01cf0e2f2f715450")
+	addresses := func(name string) (flow.Address, error) {
+		if name == "alice" {
+			return alice, nil
+		}
+		return flow.EmptyAddress, fmt.Errorf("account %s not found", name)
+	}
+	expected := cadence.NewAddress(alice)
+
+	parser := flowkit.NewArgumentParser("", []byte(`
+		pub fun main(owner: Address, friends: [Address], backup: Address?): Void {}
+	`))
+	parser.SetAddressResolver(addresses)
+
+	t.Run("Literals", func(t *testing.T) {
+		args, err := parser.Parse([]string{"@alice", `["account:alice", 0x01]`, "account:alice"})
+		assert.NoError(t, err)
+		assert.Equal(t, []cadence.Value{
+			expected,
+			cadence.NewArray([]cadence.Value{expected, cadence.BytesToAddress([]byte{0x01})}),
+			cadence.NewOptional(expected),
+		}, args)
+
+		_, err = parser.Parse([]string{"@bob", "[]", "nil"})
+		assert.EqualError(t, err, "argument `owner` is not expected type `Address`: address reference @bob can not be resolved: account bob not found")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		args, err := parser.ParseJSON([]byte(`{
+			"owner": "@alice",
+			"friends": {"type": "Array", "value": [{"type": "Address", "value": "account:alice"}]},
+			"backup": {"type": "Optional", "value": {"type": "Address", "value": "@alice"}}
+		}`))
+		assert.NoError(t, err)
+		assert.Equal(t, []cadence.Value{
+			expected,
+			cadence.NewArray([]cadence.Value{expected}),
+			cadence.NewOptional(expected),
+		}, args)
+	})
+
+	t.Run("Arguments Flags", func(t *testing.T) {
+		args, err := flowkit.ParseArgumentsWithResolver([]string{"Address:@alice", "[Address]:[\"@alice\"]"}, "", addresses)
+		assert.NoError(t, err)
+		assert.Equal(t, []cadence.Value{expected, cadence.NewArray([]cadence.Value{expected})}, args)
+
+		args, err = flowkit.ParseArgumentsWithResolver(nil, `[{"type": "Address", "value": "@alice"}]`, addresses)
+		assert.NoError(t, err)
+		assert.Equal(t, []cadence.Value{expected}, args)
+
+		_, err = flowkit.ParseArguments([]string{"Address:@alice"}, "")
+		assert.EqualError(t, err, "argument `Address:@alice` is not expected type `Address`: address reference @alice can not be resolved without configuration")
+	})
+
+	t.Run("Without Type", func(t *testing.T) {
+		args, err := flowkit.NewArgumentParser("", []byte(`
+			transaction(to: Address) {}
+		`)).SetAddressResolver(addresses).Parse([]string{"@alice"})
+		assert.NoError(t, err)
+		assert.Equal(t, []cadence.Value{expected}, args)
+	})
+}
@@ -0,0 +1,248 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/afero"
+)
+
+// AuditEntry is a record of a signature produced when signing a transaction.
+//
+// Each entry contains the hash of the previous entry line, so changing or removing
+// an entry breaks the chain of hashes.
+//
+// The transaction ID is the ID when the signature was produced. Payload signatures added
+// later change the ID, so the payload hash is recorded to identify the transaction.
+type AuditEntry struct {
+	Timestamp     time.Time         `json:"timestamp"`
+	Network       string            `json:"network"`
+	Signer        string            `json:"signer"`
+	Address       flow.Address      `json:"address"`
+	KeyIndex      int               `json:"keyIndex"`
+	SignatureType string            `json:"signatureType"`
+	TransactionID string            `json:"transactionId"`
+	PayloadHash   string            `json:"payloadHash"`
+	ScriptHash    string            `json:"scriptHash"`
+	Arguments     []json.RawMessage `json:"arguments"`
+	Payer         flow.Address      `json:"payer"`
+	PreviousHash  string            `json:"previousHash"`
+}
+
+// AuditLog is an append-only log of transaction signatures stored as JSON lines.
+type AuditLog struct {
+	path         string
+	network      string
+	readerWriter ReaderWriter
+	mu           sync.Mutex
+}
+
+// NewAuditLog creates an audit log stored in the file at path.
+func NewAuditLog(readerWriter ReaderWriter, path string) *AuditLog {
+	return &AuditLog{
+		path:         path,
+		readerWriter: readerWriter,
+	}
+}
+
+// Path returns the path of the audit log file.
+func (a *AuditLog) Path() string {
+	return a.path
+}
+
+// SetNetwork sets the network recorded in new entries.
+func (a *AuditLog) SetNetwork(network string) {
+	a.network = network
+}
+
+// fileOpener is implemented by readers and writers that can open files, like afero.Afero.
+type fileOpener interface {
+	OpenFile(name string, flag int, perm os.FileMode) (afero.File, error)
+}
+
+// Record appends an entry for the signature of the signer on the transaction.
+//
+// The file is locked while the entry is appended, so processes signing at the same time
+// don't break the chain, and only the last line is read to get the previous hash.
+func (a *AuditLog) Record(tx *flow.Transaction, signer *Account, signatureType string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	opener, ok := a.readerWriter.(fileOpener)
+	if !ok {
+		return fmt.Errorf("failed to open audit log: appending to files is not supported")
+	}
+
+	file, err := opener.OpenFile(a.path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	// files not on disk can only be used by this process and are locked by the mutex
+	if f, ok := file.(*os.File); ok {
+		err = lockFile(f)
+		if err != nil {
+			return fmt.Errorf("failed to lock audit log: %w", err)
+		}
+		defer func() { _ = unlockFile(f) }()
+	}
+
+	last, newline, err := lastAuditLine(file)
+	if err != nil {
+		return fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	previousHash := ""
+	if last != nil {
+		previousHash = auditHash(last)
+	}
+
+	arguments := make([]json.RawMessage, 0, len(tx.Arguments))
+	for _, arg := range tx.Arguments {
+		arguments = append(arguments, bytes.TrimSpace(arg))
+	}
+
+	entry, err := json.Marshal(AuditEntry{
+		Timestamp:     time.Now().UTC(),
+		Network:       a.network,
+		Signer:        signer.Name(),
+		Address:       signer.Address(),
+		KeyIndex:      signer.Key().Index(),
+		SignatureType: signatureType,
+		TransactionID: tx.ID().String(),
+		PayloadHash:   hex.EncodeToString(crypto.NewSHA3_256().ComputeHash(tx.PayloadMessage())),
+		ScriptHash:    ScriptHash(tx.Script),
+		Arguments:     arguments,
+		Payer:         tx.Payer,
+		PreviousHash:  previousHash,
+	})
+	if err != nil {
+		return err
+	}
+
+	data := append(entry, '\n')
+	if !newline {
+		data = append([]byte("\n"), data...)
+	}
+
+	_, err = file.Write(data)
+	if err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+
+	return nil
+}
+
+// lastAuditLine reads the audit log backwards until the last non-empty line is found,
+// and returns it with whether the file is empty or ends with a new line.
+func lastAuditLine(file afero.File) ([]byte, bool, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return nil, false, err
+	}
+
+	var data []byte
+	chunk := make([]byte, 4096)
+	for offset := info.Size(); offset > 0; {
+		size := int64(len(chunk))
+		if offset < size {
+			size = offset
+		}
+		offset -= size
+
+		_, err = file.ReadAt(chunk[:size], offset)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, false, err
+		}
+		data = append(append([]byte{}, chunk[:size]...), data...)
+
+		// the last line is complete once a line before it was read or the start of the file is reached
+		lines := auditLines(data)
+		if len(lines) > 1 || (len(lines) == 1 && offset == 0) {
+			return lines[len(lines)-1], bytes.HasSuffix(data, []byte("\n")), nil
+		}
+	}
+
+	return nil, len(data) == 0 || bytes.HasSuffix(data, []byte("\n")), nil
+}
+
+// AuditLogError is returned when the audit log chain is broken.
+type AuditLogError struct {
+	Line    int
+	Message string
+}
+
+func (e *AuditLogError) Error() string {
+	return fmt.Sprintf("audit log is not valid at line %d: %s", e.Line, e.Message)
+}
+
+// VerifyAuditLog checks the chain of hashes in the audit log and returns the entries and the hash of the last entry.
+func VerifyAuditLog(data []byte) ([]*AuditEntry, string, error) {
+	entries := make([]*AuditEntry, 0)
+	previousHash := ""
+
+	for i, line := range auditLines(data) {
+		var entry AuditEntry
+		err := json.Unmarshal(line, &entry)
+		if err != nil {
+			return nil, "", &AuditLogError{Line: i + 1, Message: fmt.Sprintf("invalid entry: %s", err)}
+		}
+
+		if entry.PreviousHash != previousHash {
+			return nil, "", &AuditLogError{
+				Line:    i + 1,
+				Message: "previous hash doesn't match, the previous entry was changed or removed",
+			}
+		}
+
+		entries = append(entries, &entry)
+		previousHash = auditHash(line)
+	}
+
+	return entries, previousHash, nil
+}
+
+// auditLines splits the audit log to non-empty lines.
+func auditLines(data []byte) [][]byte {
+	lines := make([][]byte, 0)
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) > 0 {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
+
+// auditHash returns the hex encoded SHA3-256 hash of the audit log line.
+func auditHash(line []byte) string {
+	return hex.EncodeToString(crypto.NewSHA3_256().ComputeHash(line))
+}
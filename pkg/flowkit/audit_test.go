@@ -0,0 +1,160 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+)
+
+func TestAuditLog(t *testing.T) {
+	readerWriter := afero.Afero{Fs: afero.NewMemMapFs()}
+	state, err := flowkit.Init(readerWriter, crypto.ECDSA_P256, crypto.SHA3_256)
+	assert.NoError(t, err)
+
+	assert.Nil(t, state.AuditLog())
+	state.Config().AuditLog = "audit.log"
+	auditLog := state.AuditLog()
+	auditLog.SetNetwork("testnet")
+
+	signer, _ := state.EmulatorServiceAccount()
+	payer := flow.HexToAddress("01cf0e2f2f715450")
+
+	tx := flow.NewTransaction().
+		SetScript([]byte(`transaction(greeting: String) {}`)).
+		SetPayer(payer).
+		AddAuthorizer(signer.Address())
+	_ = tx.AddArgument(cadence.NewString("Hello"))
+
+	assert.NoError(t, auditLog.Record(tx, signer, flowkit.SignaturePayload))
+	assert.NoError(t, auditLog.Record(tx, signer, flowkit.SignatureEnvelope))
+
+	t.Run("Verify", func(t *testing.T) {
+		data, _ := readerWriter.ReadFile("audit.log")
+		entries, lastHash, err := flowkit.VerifyAuditLog(data)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 2)
+		assert.Len(t, lastHash, 64)
+
+		entry := entries[0]
+		assert.Equal(t, "", entry.PreviousHash)
+		assert.Equal(t, "testnet", entry.Network)
+		assert.Equal(t, signer.Name(), entry.Signer)
+		assert.Equal(t, signer.Address(), entry.Address)
+		assert.Equal(t, 0, entry.KeyIndex)
+		assert.Equal(t, flowkit.SignaturePayload, entry.SignatureType)
+		assert.Equal(t, tx.ID().String(), entry.TransactionID)
+		assert.Equal(t, flowkit.ScriptHash(tx.Script), entry.ScriptHash)
+		assert.Equal(t, payer, entry.Payer)
+		assert.Len(t, entry.Arguments, 1)
+		assert.JSONEq(t, `{"type":"String","value":"Hello"}`, string(entry.Arguments[0]))
+		assert.NotEmpty(t, entries[1].PreviousHash)
+	})
+
+	t.Run("Verify Empty", func(t *testing.T) {
+		entries, lastHash, err := flowkit.VerifyAuditLog(nil)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 0)
+		assert.Equal(t, "", lastHash)
+	})
+
+	t.Run("Fail Changed Entry", func(t *testing.T) {
+		data, _ := readerWriter.ReadFile("audit.log")
+		data = bytes.Replace(data, []byte("testnet"), []byte("mainnet"), 1)
+
+		_, _, err := flowkit.VerifyAuditLog(data)
+		var auditErr *flowkit.AuditLogError
+		assert.ErrorAs(t, err, &auditErr)
+		assert.Equal(t, 2, auditErr.Line)
+	})
+
+	t.Run("Fail Removed Entry", func(t *testing.T) {
+		data, _ := readerWriter.ReadFile("audit.log")
+		lines := bytes.SplitN(data, []byte("\n"), 2)
+
+		_, _, err := flowkit.VerifyAuditLog(lines[1])
+		assert.EqualError(t, err, "audit log is not valid at line 1: previous hash doesn't match, the previous entry was changed or removed")
+	})
+}
+
+func TestAuditLogConcurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	readerWriter := afero.Afero{Fs: afero.NewOsFs()}
+	state, err := flowkit.Init(readerWriter, crypto.ECDSA_P256, crypto.SHA3_256)
+	assert.NoError(t, err)
+
+	signer, _ := state.EmulatorServiceAccount()
+	tx := flow.NewTransaction().
+		SetScript([]byte(`transaction {}`)).
+		SetPayer(signer.Address())
+
+	// file written without a trailing new line
+	assert.NoError(t, flowkit.NewAuditLog(readerWriter, path).Record(tx, signer, flowkit.SignatureEnvelope))
+	data, _ := readerWriter.ReadFile(path)
+	assert.NoError(t, readerWriter.WriteFile(path, bytes.TrimSpace(data), 0600))
+
+	// separate audit logs for the same file lock the file like separate processes
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, flowkit.NewAuditLog(readerWriter, path).Record(tx, signer, flowkit.SignatureEnvelope))
+		}()
+	}
+	wg.Wait()
+
+	data, _ = readerWriter.ReadFile(path)
+	entries, _, err := flowkit.VerifyAuditLog(data)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 11)
+}
+
+func TestAuditLogPayloadHash(t *testing.T) {
+	readerWriter := afero.Afero{Fs: afero.NewMemMapFs()}
+	state, err := flowkit.Init(readerWriter, crypto.ECDSA_P256, crypto.SHA3_256)
+	assert.NoError(t, err)
+	state.Config().AuditLog = "audit.log"
+
+	signer, _ := state.EmulatorServiceAccount()
+	tx := flow.NewTransaction().
+		SetScript([]byte(`transaction {}`)).
+		SetPayer(flow.HexToAddress("01cf0e2f2f715450"))
+
+	assert.NoError(t, state.AuditLog().Record(tx, signer, flowkit.SignaturePayload))
+	tx.AddPayloadSignature(signer.Address(), 0, []byte{1, 2, 3})
+	assert.NoError(t, state.AuditLog().Record(tx, signer, flowkit.SignaturePayload))
+
+	data, _ := readerWriter.ReadFile("audit.log")
+	entries, _, err := flowkit.VerifyAuditLog(data)
+	assert.NoError(t, err)
+	assert.NotEqual(t, entries[0].TransactionID, entries[1].TransactionID)
+	assert.Equal(t, entries[0].PayloadHash, entries[1].PayloadHash)
+	assert.Len(t, entries[0].PayloadHash, 64)
+}
@@ -20,6 +20,7 @@ package config
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
@@ -41,7 +42,19 @@ type AccountKey struct {
 	SigAlgo    crypto.SignatureAlgorithm
 	HashAlgo   crypto.HashAlgorithm
 	ResourceID string
+	Location   string
 	PrivateKey crypto.PrivateKey
+	dir        string // directory of the configuration file declaring the key
+}
+
+// LocationPath returns the path of the key file, a relative location is resolved against
+// the directory of the configuration file declaring the key.
+func (a AccountKey) LocationPath() string {
+	if a.dir == "" || filepath.IsAbs(a.Location) {
+		return a.Location
+	}
+
+	return filepath.Join(a.dir, a.Location)
 }
 
 // ByName get account by name.
@@ -32,12 +32,20 @@ import (
 // Networks defines all the Flow networks addresses
 // Accounts defines Flow accounts and their addresses, private key and more properties
 // Deployments describes which contracts should be deployed to which accounts
+// Transactions defines named transactions with their default signer, gas limit and arguments
+// Scripts defines named scripts with their default arguments
+// Policy is a path to the signing policy file evaluated before signing transactions
+// AuditLog is a path to the file recording all transaction signatures
 type Config struct {
-	Emulators   Emulators
-	Contracts   Contracts
-	Networks    Networks
-	Accounts    Accounts
-	Deployments Deployments
+	Emulators    Emulators
+	Contracts    Contracts
+	Networks     Networks
+	Accounts     Accounts
+	Deployments  Deployments
+	Transactions Transactions
+	Scripts      Scripts
+	Policy       string
+	AuditLog     string
 }
 
 type KeyType string
@@ -45,6 +53,7 @@ type KeyType string
 const (
 	KeyTypeHex                        KeyType = "hex"
 	KeyTypeGoogleKMS                  KeyType = "google-kms"
+	KeyTypeFile                       KeyType = "file"
 	DefaultEmulatorConfigName                 = "default"
 	DefaultEmulatorServiceAccountName         = "emulator-account"
 )
@@ -84,6 +93,36 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	for _, tx := range c.Transactions {
+		if tx.Signer != "" {
+			_, err := c.Accounts.ByName(tx.Signer)
+			if err != nil {
+				return fmt.Errorf("transaction %s contains nonexisting signer account %s", tx.Name, tx.Signer)
+			}
+		}
+
+		for network := range tx.Args {
+			_, err := c.Networks.ByName(network)
+			if err != nil {
+				return fmt.Errorf("transaction %s contains arguments for nonexisting network %s", tx.Name, network)
+			}
+		}
+
+		_, err := c.Scripts.ByName(tx.Name)
+		if err == nil {
+			return fmt.Errorf("name %s is used for both a transaction and a script", tx.Name)
+		}
+	}
+
+	for _, script := range c.Scripts {
+		for network := range script.Args {
+			_, err := c.Networks.ByName(network)
+			if err != nil {
+				return fmt.Errorf("script %s contains arguments for nonexisting network %s", script.Name, network)
+			}
+		}
+	}
+
 	return nil
 }
 
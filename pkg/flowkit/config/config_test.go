@@ -192,3 +192,22 @@ func Test_GetNetworkByNameComplex(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, network.Host, "127.0.0.1.3569")
 }
+
+func Test_ValidateTransactionsAndScripts(t *testing.T) {
+	conf := config.DefaultConfig()
+	conf.Accounts = generateComplexConfig().Accounts
+	conf.Transactions = config.Transactions{{Name: "transfer", Source: "transfer.cdc", Signer: "emulator-account"}}
+	conf.Scripts = config.Scripts{{Name: "balance", Source: "balance.cdc"}}
+	assert.NoError(t, conf.Validate())
+
+	conf.Transactions[0].Signer = "missing"
+	assert.EqualError(t, conf.Validate(), "transaction transfer contains nonexisting signer account missing")
+
+	conf.Transactions[0].Signer = ""
+	conf.Scripts[0].Args = map[string][]cadence.Value{"foo": nil}
+	assert.EqualError(t, conf.Validate(), "script balance contains arguments for nonexisting network foo")
+
+	conf.Scripts[0].Args = nil
+	conf.Scripts = append(conf.Scripts, config.Script{Name: "transfer", Source: "transfer.cdc"})
+	assert.EqualError(t, conf.Validate(), "name transfer is used for both a transaction and a script")
+}
@@ -20,6 +20,7 @@ package config
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/onflow/cadence"
 )
@@ -28,6 +29,29 @@ import (
 type ContractDeployment struct {
 	Name string
 	Args []cadence.Value
+	// AddressReferences are the references like @alice used as Address args by arg index,
+	// they are not included in Args and are resolved on the network of the deployment.
+	AddressReferences map[int]string
+}
+
+// ArgsCount returns the number of args including the address references.
+func (c ContractDeployment) ArgsCount() int {
+	return len(c.Args) + len(c.AddressReferences)
+}
+
+// AddressReference returns the account or contract name of an address reference in the @name or account:name format.
+func AddressReference(value string) (string, bool) {
+	var name string
+	switch {
+	case strings.HasPrefix(value, "@"):
+		name = strings.TrimPrefix(value, "@")
+	case strings.HasPrefix(value, "account:"):
+		name = strings.TrimPrefix(value, "account:")
+	default:
+		return "", false
+	}
+
+	return name, name != ""
 }
 
 type Deployments []Deployment
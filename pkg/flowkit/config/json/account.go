@@ -80,7 +80,7 @@ func transformAdvancedToConfig(accountName string, a advancedAccount) (*config.A
 	sigAlgo := crypto.StringToSignatureAlgorithm(a.Key.SigAlgo)
 	hashAlgo := crypto.StringToHashAlgorithm(a.Key.HashAlgo)
 
-	if a.Key.Type != config.KeyTypeHex && a.Key.Type != config.KeyTypeGoogleKMS {
+	if a.Key.Type != config.KeyTypeHex && a.Key.Type != config.KeyTypeGoogleKMS && a.Key.Type != config.KeyTypeFile {
 		return nil, fmt.Errorf("invalid key type for account %s", accountName)
 	}
 
@@ -102,6 +102,10 @@ func transformAdvancedToConfig(accountName string, a advancedAccount) (*config.A
 		}
 	}
 
+	if a.Key.Type == config.KeyTypeFile && a.Key.Location == "" {
+		return nil, fmt.Errorf("missing location for file key type on account %s", accountName)
+	}
+
 	if sigAlgo == crypto.UnknownSignatureAlgorithm {
 		return nil, fmt.Errorf("invalid signature algorithm for account %s", accountName)
 	}
@@ -124,6 +128,7 @@ func transformAdvancedToConfig(accountName string, a advancedAccount) (*config.A
 			SigAlgo:    sigAlgo,
 			HashAlgo:   hashAlgo,
 			ResourceID: a.Key.ResourceID,
+			Location:   a.Key.Location,
 			PrivateKey: pKey,
 		},
 	}, nil
@@ -179,17 +184,24 @@ func transformSimpleAccountToJSON(a config.Account) account {
 }
 
 func transformAdvancedAccountToJSON(a config.Account) account {
+	key := advanceKey{
+		Type:       a.Key.Type,
+		Index:      a.Key.Index,
+		SigAlgo:    a.Key.SigAlgo.String(),
+		HashAlgo:   a.Key.HashAlgo.String(),
+		ResourceID: a.Key.ResourceID,
+		Location:   a.Key.Location,
+	}
+
+	// the private key of the file key type is only saved in the key file
+	if a.Key.Type == config.KeyTypeHex {
+		key.PrivateKey = strings.TrimPrefix(a.Key.PrivateKey.String(), "0x")
+	}
+
 	return account{
 		Advanced: advancedAccount{
 			Address: a.Address.String(),
-			Key: advanceKey{
-				Type:       a.Key.Type,
-				Index:      a.Key.Index,
-				SigAlgo:    a.Key.SigAlgo.String(),
-				HashAlgo:   a.Key.HashAlgo.String(),
-				ResourceID: a.Key.ResourceID,
-				PrivateKey: strings.TrimPrefix(a.Key.PrivateKey.String(), "0x"),
-			},
+			Key:     key,
 		},
 	}
 }
@@ -225,6 +237,8 @@ type advanceKey struct {
 	PrivateKey string `json:"privateKey,omitempty"`
 	// kms key type
 	ResourceID string `json:"resourceID,omitempty"`
+	// file key type
+	Location string `json:"location,omitempty"`
 	// old key format
 	Context map[string]string `json:"context,omitempty"`
 }
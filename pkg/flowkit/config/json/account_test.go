@@ -21,7 +21,10 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
 )
 
 func Test_ConfigAccountKeysSimple(t *testing.T) {
@@ -116,6 +119,49 @@ func Test_ConfigAccountKeysAdvancedKMS(t *testing.T) {
 	assert.Nil(t, key.PrivateKey)
 }
 
+func Test_ConfigAccountKeysAdvancedFile(t *testing.T) {
+	b := []byte(`{
+		"test": {
+			"address": "f8d6e0586b0a20c7",
+			"key": {
+				"type": "file",
+				"index": 0,
+				"signatureAlgorithm": "ECDSA_P256",
+				"hashAlgorithm": "SHA3_256",
+				"location": "test.pkey"
+			}
+		}
+	}`)
+
+	var jsonAccounts jsonAccounts
+	err := json.Unmarshal(b, &jsonAccounts)
+	assert.NoError(t, err)
+
+	accounts, err := jsonAccounts.transformToConfig()
+	assert.NoError(t, err)
+
+	account, err := accounts.ByName("test")
+	assert.NoError(t, err)
+	assert.Equal(t, config.KeyTypeFile, account.Key.Type)
+	assert.Equal(t, "test.pkey", account.Key.Location)
+	assert.Nil(t, account.Key.PrivateKey)
+
+	// the private key is not saved to the configuration
+	account.Key.PrivateKey, _ = crypto.DecodePrivateKeyHex(
+		crypto.ECDSA_P256,
+		"271cec6bb5221d12713759188166bdfa00079db5789c36b54dcf1d794d8d8cdf",
+	)
+	saved, err := json.Marshal(transformAccountsToJSON(accounts))
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(b), string(saved))
+
+	b = []byte(`{"test": {"address": "service", "key": {"type": "file", "signatureAlgorithm": "ECDSA_P256", "hashAlgorithm": "SHA3_256"}}}`)
+	err = json.Unmarshal(b, &jsonAccounts)
+	assert.NoError(t, err)
+	_, err = jsonAccounts.transformToConfig()
+	assert.EqualError(t, err, "missing location for file key type on account test")
+}
+
 func Test_ConfigAccountOldFormats(t *testing.T) {
 	b := []byte(`{
 		"old-format-1": {
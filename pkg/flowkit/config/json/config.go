@@ -27,11 +27,15 @@ import (
 
 // jsonConfig implements JSON format for persisting and parsing configuration.
 type jsonConfig struct {
-	Emulators   jsonEmulators   `json:"emulators"`
-	Contracts   jsonContracts   `json:"contracts"`
-	Networks    jsonNetworks    `json:"networks"`
-	Accounts    jsonAccounts    `json:"accounts"`
-	Deployments jsonDeployments `json:"deployments"`
+	Emulators    jsonEmulators    `json:"emulators"`
+	Contracts    jsonContracts    `json:"contracts"`
+	Networks     jsonNetworks     `json:"networks"`
+	Accounts     jsonAccounts     `json:"accounts"`
+	Deployments  jsonDeployments  `json:"deployments"`
+	Transactions jsonTransactions `json:"transactions,omitempty"`
+	Scripts      jsonScripts      `json:"scripts,omitempty"`
+	Policy       string           `json:"policy,omitempty"`
+	AuditLog     string           `json:"auditLog,omitempty"`
 }
 
 func (j *jsonConfig) transformToConfig() (*config.Config, error) {
@@ -60,12 +64,26 @@ func (j *jsonConfig) transformToConfig() (*config.Config, error) {
 		return nil, err
 	}
 
+	transactions, err := j.Transactions.transformToConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	scripts, err := j.Scripts.transformToConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	conf := &config.Config{
-		Emulators:   emulators,
-		Contracts:   contracts,
-		Networks:    networks,
-		Accounts:    accounts,
-		Deployments: deployments,
+		Emulators:    emulators,
+		Contracts:    contracts,
+		Networks:     networks,
+		Accounts:     accounts,
+		Deployments:  deployments,
+		Transactions: transactions,
+		Scripts:      scripts,
+		Policy:       j.Policy,
+		AuditLog:     j.AuditLog,
 	}
 
 	return conf, nil
@@ -73,11 +91,15 @@ func (j *jsonConfig) transformToConfig() (*config.Config, error) {
 
 func transformConfigToJSON(config *config.Config) jsonConfig {
 	return jsonConfig{
-		Emulators:   transformEmulatorsToJSON(config.Emulators),
-		Contracts:   transformContractsToJSON(config.Contracts),
-		Networks:    transformNetworksToJSON(config.Networks),
-		Accounts:    transformAccountsToJSON(config.Accounts),
-		Deployments: transformDeploymentsToJSON(config.Deployments),
+		Emulators:    transformEmulatorsToJSON(config.Emulators),
+		Contracts:    transformContractsToJSON(config.Contracts),
+		Networks:     transformNetworksToJSON(config.Networks),
+		Accounts:     transformAccountsToJSON(config.Accounts),
+		Deployments:  transformDeploymentsToJSON(config.Deployments),
+		Transactions: transformTransactionsToJSON(config.Transactions),
+		Scripts:      transformScriptsToJSON(config.Scripts),
+		Policy:       config.Policy,
+		AuditLog:     config.AuditLog,
 	}
 }
 
@@ -156,3 +156,21 @@ func Test_NonExistingAccountForEmulator(t *testing.T) {
 	err = conf.Validate()
 	assert.Equal(t, err.Error(), "emulator default contains nonexisting service account emulator-account")
 }
+
+func Test_PolicyJSONConfig(t *testing.T) {
+	b := []byte(`{
+		"networks": {
+			"emulator": "127.0.0.1:3569"
+		},
+		"policy": "./signing-policy.json"
+	}`)
+
+	parser := NewParser()
+	conf, err := parser.Deserialize(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "./signing-policy.json", conf.Policy)
+
+	serialized, err := parser.Serialize(conf)
+	assert.NoError(t, err)
+	assert.Contains(t, string(serialized), `"policy": "./signing-policy.json"`)
+}
@@ -56,7 +56,17 @@ func (j jsonDeployments) transformToConfig() (config.Deployments, error) {
 					)
 				} else {
 					args := make([]cadence.Value, 0)
-					for _, arg := range contract.advanced.Args {
+					var references map[int]string
+					for i, arg := range contract.advanced.Args {
+						// address references are resolved when the contracts of a network are deployed
+						if reference, ok := addressReference(arg); ok {
+							if references == nil {
+								references = make(map[int]string)
+							}
+							references[i] = reference
+							continue
+						}
+
 						b, err := json.Marshal(arg)
 						if err != nil {
 							return nil, err
@@ -73,8 +83,9 @@ func (j jsonDeployments) transformToConfig() (config.Deployments, error) {
 					contractDeploys = append(
 						contractDeploys,
 						config.ContractDeployment{
-							Name: contract.advanced.Name,
-							Args: args,
+							Name:              contract.advanced.Name,
+							Args:              args,
+							AddressReferences: references,
 						},
 					)
 				}
@@ -96,13 +107,28 @@ func transformDeploymentsToJSON(configDeployments config.Deployments) jsonDeploy
 
 		deployments := make([]deployment, 0)
 		for _, c := range d.Contracts {
-			if len(c.Args) == 0 {
+			if c.ArgsCount() == 0 {
 				deployments = append(deployments, deployment{
 					simple: c.Name,
 				})
 			} else {
 				args := make([]map[string]interface{}, 0)
-				for _, arg := range c.Args {
+				values := c.Args
+				for i := 0; i < c.ArgsCount(); i++ {
+					if reference, ok := c.AddressReferences[i]; ok {
+						args = append(args, map[string]interface{}{
+							"type":  "Address",
+							"value": reference,
+						})
+						continue
+					}
+
+					if len(values) == 0 {
+						break
+					}
+					arg := values[0]
+					values = values[1:]
+
 					switch arg.Type().ID() {
 					case "Bool":
 						args = append(args, map[string]interface{}{
@@ -139,6 +165,24 @@ func transformDeploymentsToJSON(configDeployments config.Deployments) jsonDeploy
 	return jsonDeploys
 }
 
+// addressReference returns the reference of an Address arg with a reference like @alice as value.
+func addressReference(arg map[string]interface{}) (string, bool) {
+	if arg["type"] != "Address" {
+		return "", false
+	}
+
+	value, ok := arg["value"].(string)
+	if !ok {
+		return "", false
+	}
+
+	if _, ok := config.AddressReference(value); !ok {
+		return "", false
+	}
+
+	return value, true
+}
+
 type contractDeployment struct {
 	Name string                   `json:"name"`
 	Args []map[string]interface{} `json:"args"`
@@ -23,6 +23,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/onflow/cadence"
 	"github.com/stretchr/testify/require"
 
 	"github.com/stretchr/testify/assert"
@@ -93,7 +94,8 @@ func Test_TransformDeployToJSON(t *testing.T) {
 					"name": "Kibble",
 					"args": [
 						{ "type": "String", "value": "Hello World" },
-						{ "type": "Int8", "value": "10" }
+						{ "type": "Int8", "value": "10" },
+						{ "type": "Address", "value": "@account-4" }
 					]
 			}],
 			"account-4":["FungibleToken","NonFungibleToken","Kibble","KittyItems","KittyItemsMarket"]
@@ -152,3 +154,42 @@ func Test_DeploymentAdvanced(t *testing.T) {
 	assert.Equal(t, alice[0].Contracts[1].Name, "KittyItemsMarket")
 	assert.Len(t, alice[0].Contracts[1].Args, 0)
 }
+
+func Test_DeploymentAddressReferences(t *testing.T) {
+	b := []byte(`{
+		"emulator": {
+			"alice": [
+				{
+					"name": "Kibble",
+					"args": [
+						{ "type": "Address", "value": "0x01cf0e2f2f715450" },
+						{ "type": "Address", "value": "@bob" },
+						{ "type": "Address", "value": "account:FungibleToken" },
+						{ "type": "String", "value": "@alice" }
+					]
+				}
+			]
+		}
+	}`)
+
+	var jsonDeployments jsonDeployments
+	err := json.Unmarshal(b, &jsonDeployments)
+	assert.NoError(t, err)
+
+	deployments, err := jsonDeployments.transformToConfig()
+	assert.NoError(t, err)
+
+	contract := deployments.ByAccountAndNetwork("alice", "emulator")[0].Contracts[0]
+	assert.Len(t, contract.Args, 2)
+	assert.Equal(t, 4, contract.ArgsCount())
+	assert.Equal(t, cadence.BytesToAddress([]byte{0x01, 0xcf, 0x0e, 0x2f, 0x2f, 0x71, 0x54, 0x50}), contract.Args[0])
+	assert.Equal(t, `"@alice"`, contract.Args[1].String())
+	assert.Equal(t, map[int]string{1: "@bob", 2: "account:FungibleToken"}, contract.AddressReferences)
+
+	// references are written back in place
+	args := transformDeploymentsToJSON(deployments)["emulator"]["alice"][0].advanced.Args
+	assert.Len(t, args, 4)
+	assert.Equal(t, "@bob", args[1]["value"])
+	assert.Equal(t, "account:FungibleToken", args[2]["value"])
+	assert.Equal(t, "@alice", args[3]["value"])
+}
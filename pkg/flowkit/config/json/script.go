@@ -0,0 +1,119 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+)
+
+type jsonScripts map[string]jsonScript
+
+// transformToConfig transforms json structures to config structure.
+func (j jsonScripts) transformToConfig() (config.Scripts, error) {
+	scripts := make(config.Scripts, 0)
+
+	for name, s := range j {
+		if s.Simple != "" {
+			scripts = append(scripts, config.Script{
+				Name:   name,
+				Source: s.Simple,
+			})
+			continue
+		}
+
+		args, err := decodeNetworkArgs(s.Advanced.Args)
+		if err != nil {
+			return nil, fmt.Errorf("script %s: %w", name, err)
+		}
+
+		scripts = append(scripts, config.Script{
+			Name:   name,
+			Source: s.Advanced.Source,
+			Args:   args,
+		})
+	}
+
+	return scripts, nil
+}
+
+// transformToJSON transforms config structure to json structures for saving.
+func transformScriptsToJSON(scripts config.Scripts) jsonScripts {
+	jsonScripts := jsonScripts{}
+
+	for _, s := range scripts {
+		if len(s.Args) == 0 {
+			jsonScripts[s.Name] = jsonScript{
+				Simple: s.Source,
+			}
+			continue
+		}
+
+		jsonScripts[s.Name] = jsonScript{
+			Advanced: jsonScriptAdvanced{
+				Source: s.Source,
+				Args:   encodeNetworkArgs(s.Args),
+			},
+		}
+	}
+
+	return jsonScripts
+}
+
+// jsonScriptAdvanced for json parsing advanced config.
+type jsonScriptAdvanced struct {
+	Source string                       `json:"source"`
+	Args   map[string][]json.RawMessage `json:"args,omitempty"`
+}
+
+// jsonScript structure for json parsing.
+type jsonScript struct {
+	Simple   string
+	Advanced jsonScriptAdvanced
+}
+
+func (j *jsonScript) UnmarshalJSON(b []byte) error {
+	// simple
+	var source string
+	err := json.Unmarshal(b, &source)
+	if err == nil {
+		j.Simple = source
+		return nil
+	}
+
+	// advanced
+	var advanced jsonScriptAdvanced
+	err = json.Unmarshal(b, &advanced)
+	if err != nil {
+		return err
+	}
+
+	j.Advanced = advanced
+	return nil
+}
+
+func (j jsonScript) MarshalJSON() ([]byte, error) {
+	if j.Simple != "" {
+		return json.Marshal(j.Simple)
+	}
+
+	return json.Marshal(j.Advanced)
+}
@@ -0,0 +1,62 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ConfigScripts(t *testing.T) {
+	b := []byte(`{
+		"total-supply": "./cadence/scripts/total_supply.cdc",
+		"balance": {
+			"source": "./cadence/scripts/balance.cdc",
+			"args": {
+				"emulator": [{"type": "Address", "value": "0xf8d6e0586b0a20c7"}],
+				"testnet": [{"type": "Address", "value": "0x9a0766d93b6608b7"}]
+			}
+		}
+	}`)
+
+	var jsonScripts jsonScripts
+	err := json.Unmarshal(b, &jsonScripts)
+	assert.NoError(t, err)
+
+	scripts, err := jsonScripts.transformToConfig()
+	assert.NoError(t, err)
+	assert.Len(t, scripts, 2)
+
+	supply, err := scripts.ByName("total-supply")
+	assert.NoError(t, err)
+	assert.Equal(t, "./cadence/scripts/total_supply.cdc", supply.Source)
+
+	balance, err := scripts.ByName("balance")
+	assert.NoError(t, err)
+	assert.Equal(t, "./cadence/scripts/balance.cdc", balance.Source)
+	assert.Equal(t, []cadence.Value{cadence.BytesToAddress([]byte{0xf8, 0xd6, 0xe0, 0x58, 0x6b, 0x0a, 0x20, 0xc7})}, balance.ArgsByNetwork("emulator"))
+	assert.Len(t, balance.ArgsByNetwork("testnet"), 1)
+
+	raw, err := json.Marshal(transformScriptsToJSON(scripts))
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(b), string(raw))
+}
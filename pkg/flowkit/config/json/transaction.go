@@ -0,0 +1,171 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+)
+
+type jsonTransactions map[string]jsonTransaction
+
+// transformToConfig transforms json structures to config structure.
+func (j jsonTransactions) transformToConfig() (config.Transactions, error) {
+	transactions := make(config.Transactions, 0)
+
+	for name, t := range j {
+		if t.Simple != "" {
+			transactions = append(transactions, config.Transaction{
+				Name:   name,
+				Source: t.Simple,
+			})
+			continue
+		}
+
+		args, err := decodeNetworkArgs(t.Advanced.Args)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %s: %w", name, err)
+		}
+
+		transactions = append(transactions, config.Transaction{
+			Name:     name,
+			Source:   t.Advanced.Source,
+			Signer:   t.Advanced.Signer,
+			GasLimit: t.Advanced.GasLimit,
+			Args:     args,
+		})
+	}
+
+	return transactions, nil
+}
+
+// transformToJSON transforms config structure to json structures for saving.
+func transformTransactionsToJSON(transactions config.Transactions) jsonTransactions {
+	jsonTransactions := jsonTransactions{}
+
+	for _, t := range transactions {
+		if t.Signer == "" && t.GasLimit == 0 && len(t.Args) == 0 {
+			jsonTransactions[t.Name] = jsonTransaction{
+				Simple: t.Source,
+			}
+			continue
+		}
+
+		jsonTransactions[t.Name] = jsonTransaction{
+			Advanced: jsonTransactionAdvanced{
+				Source:   t.Source,
+				Signer:   t.Signer,
+				GasLimit: t.GasLimit,
+				Args:     encodeNetworkArgs(t.Args),
+			},
+		}
+	}
+
+	return jsonTransactions
+}
+
+// jsonTransactionAdvanced for json parsing advanced config.
+type jsonTransactionAdvanced struct {
+	Source   string                       `json:"source"`
+	Signer   string                       `json:"signer,omitempty"`
+	GasLimit uint64                       `json:"gasLimit,omitempty"`
+	Args     map[string][]json.RawMessage `json:"args,omitempty"`
+}
+
+// jsonTransaction structure for json parsing.
+type jsonTransaction struct {
+	Simple   string
+	Advanced jsonTransactionAdvanced
+}
+
+func (j *jsonTransaction) UnmarshalJSON(b []byte) error {
+	// simple
+	var source string
+	err := json.Unmarshal(b, &source)
+	if err == nil {
+		j.Simple = source
+		return nil
+	}
+
+	// advanced
+	var advanced jsonTransactionAdvanced
+	err = json.Unmarshal(b, &advanced)
+	if err != nil {
+		return err
+	}
+
+	j.Advanced = advanced
+	return nil
+}
+
+func (j jsonTransaction) MarshalJSON() ([]byte, error) {
+	if j.Simple != "" {
+		return json.Marshal(j.Simple)
+	}
+
+	return json.Marshal(j.Advanced)
+}
+
+// decodeNetworkArgs decodes JSON-Cadence arguments for each network.
+func decodeNetworkArgs(networkArgs map[string][]json.RawMessage) (map[string][]cadence.Value, error) {
+	if len(networkArgs) == 0 {
+		return nil, nil
+	}
+
+	args := make(map[string][]cadence.Value, len(networkArgs))
+	for network, rawArgs := range networkArgs {
+		values := make([]cadence.Value, 0, len(rawArgs))
+		for i, raw := range rawArgs {
+			value, err := jsoncdc.Decode(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid argument %d for network %s: %w", i, network, err)
+			}
+
+			values = append(values, value)
+		}
+
+		args[network] = values
+	}
+
+	return args, nil
+}
+
+// encodeNetworkArgs encodes arguments for each network to JSON-Cadence.
+func encodeNetworkArgs(args map[string][]cadence.Value) map[string][]json.RawMessage {
+	if len(args) == 0 {
+		return nil
+	}
+
+	networkArgs := make(map[string][]json.RawMessage, len(args))
+	for network, values := range args {
+		rawArgs := make([]json.RawMessage, 0, len(values))
+		for _, value := range values {
+			rawArgs = append(rawArgs, jsoncdc.MustEncode(value))
+		}
+
+		networkArgs[network] = rawArgs
+	}
+
+	return networkArgs
+}
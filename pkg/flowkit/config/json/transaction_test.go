@@ -0,0 +1,94 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ConfigTransactions(t *testing.T) {
+	b := []byte(`{
+		"setup": "./cadence/transactions/setup.cdc",
+		"transfer": {
+			"source": "./cadence/transactions/transfer.cdc",
+			"signer": "alice",
+			"gasLimit": 500,
+			"args": {
+				"emulator": [
+					{"type": "UFix64", "value": "10.00000000"},
+					{"type": "Address", "value": "0xf8d6e0586b0a20c7"}
+				]
+			}
+		}
+	}`)
+
+	var jsonTransactions jsonTransactions
+	err := json.Unmarshal(b, &jsonTransactions)
+	assert.NoError(t, err)
+
+	transactions, err := jsonTransactions.transformToConfig()
+	assert.NoError(t, err)
+	assert.Len(t, transactions, 2)
+
+	setup, err := transactions.ByName("setup")
+	assert.NoError(t, err)
+	assert.Equal(t, "./cadence/transactions/setup.cdc", setup.Source)
+	assert.Equal(t, "", setup.Signer)
+	assert.Nil(t, setup.ArgsByNetwork("emulator"))
+
+	transfer, err := transactions.ByName("transfer")
+	assert.NoError(t, err)
+	assert.Equal(t, "./cadence/transactions/transfer.cdc", transfer.Source)
+	assert.Equal(t, "alice", transfer.Signer)
+	assert.Equal(t, uint64(500), transfer.GasLimit)
+
+	args := transfer.ArgsByNetwork("emulator")
+	assert.Len(t, args, 2)
+	amount, _ := cadence.NewUFix64("10.0")
+	assert.Equal(t, amount, args[0])
+	assert.Nil(t, transfer.ArgsByNetwork("testnet"))
+
+	_, err = transactions.ByName("missing")
+	assert.EqualError(t, err, "transaction named missing does not exist in configuration")
+
+	raw, err := json.Marshal(transformTransactionsToJSON(transactions))
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(b), string(raw))
+}
+
+func Test_ConfigTransactionsInvalidArgs(t *testing.T) {
+	b := []byte(`{
+		"transfer": {
+			"source": "./cadence/transactions/transfer.cdc",
+			"args": { "emulator": [{"type": "UFix64", "value": "foo"}] }
+		}
+	}`)
+
+	var jsonTransactions jsonTransactions
+	err := json.Unmarshal(b, &jsonTransactions)
+	assert.NoError(t, err)
+
+	_, err = jsonTransactions.transformToConfig()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "transaction transfer: invalid argument 0 for network emulator")
+}
@@ -119,7 +119,22 @@ func (l *Loader) loadConfig(confPath string) (*Config, error) {
 		return nil, fmt.Errorf("parser not found for config: %s", confPath)
 	}
 
-	return configParser.Deserialize(preProcessed)
+	conf, err := configParser.Deserialize(preProcessed)
+	if err != nil {
+		return nil, err
+	}
+
+	setKeyDirs(conf, confPath)
+	return conf, nil
+}
+
+// setKeyDirs sets the directory of the configuration file to the file keys declared in it.
+func setKeyDirs(conf *Config, confPath string) {
+	for i, account := range conf.Accounts {
+		if account.Key.Type == KeyTypeFile {
+			conf.Accounts[i].Key.dir = filepath.Dir(confPath)
+		}
+	}
 }
 
 // Load loads configuration from one or more file paths.
@@ -196,6 +211,7 @@ func (l *Loader) postprocess(baseConf *Config) (*Config, error) {
 		if err != nil {
 			return nil, err
 		}
+		setKeyDirs(conf, path)
 
 		account, err := conf.Accounts.ByName(name)
 		if err != nil {
@@ -234,6 +250,18 @@ func (l *Loader) composeConfig(baseConf *Config, conf *Config) {
 	for _, deployment := range conf.Deployments {
 		baseConf.Deployments.AddOrUpdate(deployment)
 	}
+	for _, transaction := range conf.Transactions {
+		baseConf.Transactions.AddOrUpdate(transaction.Name, transaction)
+	}
+	for _, script := range conf.Scripts {
+		baseConf.Scripts.AddOrUpdate(script.Name, script)
+	}
+	if conf.Policy != "" {
+		baseConf.Policy = conf.Policy
+	}
+	if conf.AuditLog != "" {
+		baseConf.AuditLog = conf.AuditLog
+	}
 }
 
 // loadFile simple file loader.
@@ -456,3 +456,43 @@ func Test_JSONEnv(t *testing.T) {
 	assert.Equal(t, 1, len(conf.Accounts))
 	assert.Equal(t, "0x21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7", conf.Accounts[0].Key.PrivateKey.String())
 }
+
+func Test_FileKeyLocation(t *testing.T) {
+	b := []byte(`{
+		"accounts": {
+			"admin-account": {
+				"address": "f8d6e0586b0a20c7",
+				"key": {
+					"type": "file",
+					"signatureAlgorithm": "ECDSA_P256",
+					"hashAlgorithm": "SHA3_256",
+					"location": "admin.pkey"
+				}
+			},
+			"absolute-account": {
+				"address": "f8d6e0586b0a20c7",
+				"key": {
+					"type": "file",
+					"signatureAlgorithm": "ECDSA_P256",
+					"hashAlgorithm": "SHA3_256",
+					"location": "/keys/absolute.pkey"
+				}
+			}
+		}
+	}`)
+
+	err := afero.WriteFile(mockFS, "project/file-key-flow.json", b, 0644)
+	assert.NoError(t, err)
+
+	composer := config.NewLoader(af)
+	composer.AddConfigParser(json.NewParser())
+	conf, err := composer.Load([]string{"project/file-key-flow.json"})
+	assert.NoError(t, err)
+
+	admin, _ := conf.Accounts.ByName("admin-account")
+	assert.Equal(t, "admin.pkey", admin.Key.Location)
+	assert.Equal(t, "project/admin.pkey", admin.Key.LocationPath())
+
+	absolute, _ := conf.Accounts.ByName("absolute-account")
+	assert.Equal(t, "/keys/absolute.pkey", absolute.Key.LocationPath())
+}
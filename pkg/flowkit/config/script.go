@@ -0,0 +1,78 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence"
+)
+
+// Script defines the configuration for a named script.
+type Script struct {
+	Name   string
+	Source string
+	Args   map[string][]cadence.Value // default arguments by network name
+}
+
+type Scripts []Script
+
+// ArgsByNetwork get default arguments for the network, nil is returned if no arguments are set for the network.
+func (s *Script) ArgsByNetwork(network string) []cadence.Value {
+	return s.Args[network]
+}
+
+// ByName get script by name.
+func (s *Scripts) ByName(name string) (*Script, error) {
+	for _, script := range *s {
+		if script.Name == name {
+			return &script, nil
+		}
+	}
+
+	return nil, fmt.Errorf("script named %s does not exist in configuration", name)
+}
+
+// AddOrUpdate add new script or update if already present.
+func (s *Scripts) AddOrUpdate(name string, script Script) {
+	for i, existingScript := range *s {
+		if existingScript.Name == name {
+			(*s)[i] = script
+			return
+		}
+	}
+
+	*s = append(*s, script)
+}
+
+// Remove script by the name.
+func (s *Scripts) Remove(name string) error {
+	_, err := s.ByName(name)
+	if err != nil {
+		return err
+	}
+
+	for i, script := range *s {
+		if script.Name == name {
+			*s = append((*s)[0:i], (*s)[i+1:]...) // remove item
+		}
+	}
+
+	return nil
+}
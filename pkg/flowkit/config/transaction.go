@@ -0,0 +1,80 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence"
+)
+
+// Transaction defines the configuration for a named transaction.
+type Transaction struct {
+	Name     string
+	Source   string
+	Signer   string                     // default account name signing the transaction
+	GasLimit uint64                     // default gas limit, zero if not set
+	Args     map[string][]cadence.Value // default arguments by network name
+}
+
+type Transactions []Transaction
+
+// ArgsByNetwork get default arguments for the network, nil is returned if no arguments are set for the network.
+func (t *Transaction) ArgsByNetwork(network string) []cadence.Value {
+	return t.Args[network]
+}
+
+// ByName get transaction by name.
+func (t *Transactions) ByName(name string) (*Transaction, error) {
+	for _, tx := range *t {
+		if tx.Name == name {
+			return &tx, nil
+		}
+	}
+
+	return nil, fmt.Errorf("transaction named %s does not exist in configuration", name)
+}
+
+// AddOrUpdate add new transaction or update if already present.
+func (t *Transactions) AddOrUpdate(name string, transaction Transaction) {
+	for i, existingTransaction := range *t {
+		if existingTransaction.Name == name {
+			(*t)[i] = transaction
+			return
+		}
+	}
+
+	*t = append(*t, transaction)
+}
+
+// Remove transaction by the name.
+func (t *Transactions) Remove(name string) error {
+	_, err := t.ByName(name)
+	if err != nil {
+		return err
+	}
+
+	for i, tx := range *t {
+		if tx.Name == name {
+			*t = append((*t)[0:i], (*t)[i+1:]...) // remove item
+		}
+	}
+
+	return nil
+}
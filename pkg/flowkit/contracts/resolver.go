@@ -33,8 +33,9 @@ import (
 
 // Resolver handles resolving imports in Cadence code.
 type Resolver struct {
-	code    []byte
-	program *ast.Program
+	code      []byte
+	program   *ast.Program
+	sourceMap *flowkit.SourceMap
 }
 
 // NewResolver creates a new resolver.
@@ -62,6 +63,7 @@ func (r *Resolver) ResolveImports(
 ) ([]byte, error) {
 	imports := r.getFileImports()
 	sourceTarget := r.getSourceTarget(contracts, aliases)
+	r.sourceMap = flowkit.NewSourceMap(codePath, r.code)
 
 	for _, imp := range imports {
 		target := sourceTarget[absolutePath(codePath, imp)]
@@ -75,14 +77,24 @@ func (r *Resolver) ResolveImports(
 	return r.code, nil
 }
 
-// replaceImport replaces import from path to address.
+// SourceMap returns the source map of the code with resolved imports, nil is returned if imports weren't resolved.
+func (r *Resolver) SourceMap() *flowkit.SourceMap {
+	return r.sourceMap
+}
+
+// replaceImport replaces import from path to address and records the replacement in the source map.
 func (r *Resolver) replaceImport(from string, to string) []byte {
-	return []byte(strings.Replace(
-		string(r.code),
-		fmt.Sprintf(`"%s"`, from),
-		fmt.Sprintf("0x%s", to),
-		1,
-	))
+	code := string(r.code)
+	original := fmt.Sprintf(`"%s"`, from)
+	replacement := fmt.Sprintf("0x%s", to)
+
+	if index := strings.Index(code, original); index >= 0 {
+		line := strings.Count(code[:index], "\n") + 1
+		column := index - strings.LastIndex(code[:index], "\n") - 1
+		r.sourceMap.AddReplacement(line, column, len(original), len(replacement))
+	}
+
+	return []byte(strings.Replace(code, original, replacement, 1))
 }
 
 // getSourceTarget return a map with contract paths as keys and addresses as values.
@@ -91,15 +103,29 @@ func (r *Resolver) getSourceTarget(
 	aliases flowkit.Aliases,
 ) map[string]string {
 	sourceTarget := make(map[string]string)
+	for source, target := range ContractAddresses(contracts, aliases) {
+		sourceTarget[source] = target.String()
+	}
+
+	return sourceTarget
+}
+
+// ContractAddresses returns a map with cleaned contract paths as keys and the addresses of the contracts
+// or their aliases as values.
+func ContractAddresses(
+	contracts []flowkit.Contract,
+	aliases flowkit.Aliases,
+) map[string]flow.Address {
+	addresses := make(map[string]flow.Address)
 	for _, contract := range contracts {
-		sourceTarget[path.Clean(contract.Source)] = contract.Target.String()
+		addresses[path.Clean(contract.Source)] = contract.Target
 	}
 
 	for source, target := range aliases {
-		sourceTarget[path.Clean(source)] = flow.HexToAddress(target).String()
+		addresses[path.Clean(source)] = flow.HexToAddress(target)
 	}
 
-	return sourceTarget
+	return addresses
 }
 
 // HasFileImports checks if there is a file import statement present in Cadence code.
@@ -107,6 +133,31 @@ func (r *Resolver) HasFileImports() bool {
 	return len(r.getFileImports()) > 0
 }
 
+// ImportedFiles returns the paths of the files imported in Cadence code, relative to the directory of the code path.
+func (r *Resolver) ImportedFiles(codePath string) []string {
+	files := make([]string, 0)
+	for _, imp := range r.getFileImports() {
+		files = append(files, absolutePath(codePath, imp))
+	}
+
+	return files
+}
+
+// AddressImports returns the addresses of all address imports in the Cadence code.
+func (r *Resolver) AddressImports() []flow.Address {
+	addresses := make([]flow.Address, 0)
+
+	for _, importDeclaration := range r.program.ImportDeclarations() {
+		location, isAddressImport := importDeclaration.Location.(common.AddressLocation)
+
+		if isAddressImport {
+			addresses = append(addresses, flow.BytesToAddress(location.Address.Bytes()))
+		}
+	}
+
+	return addresses
+}
+
 // getFileImports returns all cadence file imports from Cadence code as an array.
 func (r *Resolver) getFileImports() []string {
 	imports := make([]string, 0)
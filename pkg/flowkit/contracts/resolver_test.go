@@ -20,6 +20,7 @@ package contracts
 
 import (
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/onflow/flow-cli/pkg/flowkit"
@@ -130,6 +131,16 @@ func TestResolver(t *testing.T) {
 		})
 	})
 
+	t.Run("Imported files", func(t *testing.T) {
+		resolver, err := NewResolver(scripts[1])
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"tests/Kibble.cdc", "tests/FT.cdc"}, resolver.ImportedFiles(paths[1]))
+
+		resolver, err = NewResolver(scripts[3])
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"tests/Kibble.cdc"}, resolver.ImportedFiles(paths[3]))
+	})
+
 	t.Run("Resolve imports", func(t *testing.T) {
 		for i, script := range scripts {
 			resolver, err := NewResolver(script)
@@ -142,4 +153,32 @@ func TestResolver(t *testing.T) {
 		}
 	})
 
+	t.Run("Source map", func(t *testing.T) {
+		script := []byte("import Kibble from \"./Kibble.cdc\"; import FT from \"./FT.cdc\"\npub fun main() { Kibble.foo() }")
+		resolver, err := NewResolver(script)
+		assert.NoError(t, err)
+
+		code, err := resolver.ResolveImports("./tests/foo.cdc", contracts, aliases)
+		assert.NoError(t, err)
+
+		sourceMap := resolver.SourceMap()
+		assert.Equal(t, "./tests/foo.cdc", sourceMap.Filename)
+		assert.Equal(t, script, sourceMap.Source)
+
+		// "FT" in the second import is moved left by resolving the first import
+		resolvedLine := strings.Split(string(code), "\n")[0]
+		line, column := sourceMap.OriginalPosition(1, strings.Index(resolvedLine, "FT from"))
+		assert.Equal(t, 1, line)
+		assert.Equal(t, strings.Index(string(script), "FT from"), column)
+
+		// position inside the resolved address maps to the start of the original import path
+		line, column = sourceMap.OriginalPosition(1, strings.Index(resolvedLine, "0x")+5)
+		assert.Equal(t, 1, line)
+		assert.Equal(t, strings.Index(string(script), `"./Kibble.cdc"`), column)
+
+		// lines without imports are not changed
+		line, column = sourceMap.OriginalPosition(2, 17)
+		assert.Equal(t, 2, line)
+		assert.Equal(t, 17, column)
+	})
 }
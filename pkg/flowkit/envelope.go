@@ -0,0 +1,210 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// Transaction signer roles.
+const (
+	RoleProposer   = "proposer"
+	RoleAuthorizer = "authorizer"
+	RolePayer      = "payer"
+)
+
+// Transaction signature types.
+const (
+	SignaturePayload  = "payload"
+	SignatureEnvelope = "envelope"
+)
+
+// TransactionEnvelope is a built transaction passed between co-signers.
+//
+// Besides the RLP encoded transaction it describes which accounts need to sign the
+// transaction and which signatures were already collected. Signers and signatures are
+// always derived from the payload, which is the only source of truth.
+type TransactionEnvelope struct {
+	Payload    string               `json:"payload"`
+	Source     string               `json:"source,omitempty"`
+	Network    string               `json:"network,omitempty"`
+	Signers    []*EnvelopeSigner    `json:"signers"`
+	Signatures []*EnvelopeSignature `json:"signatures"`
+	tx         *Transaction
+}
+
+// EnvelopeSigner is an account required to sign the transaction.
+type EnvelopeSigner struct {
+	Address flow.Address `json:"address"`
+	Roles   []string     `json:"roles"`
+	// KeyIndex is the key which must be used for signing, only set for the proposer.
+	KeyIndex *int `json:"keyIndex,omitempty"`
+	Signed   bool `json:"signed"`
+}
+
+// EnvelopeSignature is a signature already added to the transaction.
+type EnvelopeSignature struct {
+	Address   flow.Address `json:"address"`
+	KeyIndex  int          `json:"keyIndex"`
+	Type      string       `json:"type"`
+	Signature string       `json:"signature"`
+}
+
+// NewTransactionEnvelope creates an envelope for the transaction built from the source file for the network.
+func NewTransactionEnvelope(tx *Transaction, source string, network string) *TransactionEnvelope {
+	e := &TransactionEnvelope{
+		Source:  source,
+		Network: network,
+	}
+	e.SetTransaction(tx)
+
+	return e
+}
+
+// ParseTransactionEnvelope parses the envelope in JSON format or a raw RLP hex encoded transaction.
+func ParseTransactionEnvelope(data []byte) (*TransactionEnvelope, error) {
+	data = bytes.TrimSpace(data)
+	if !bytes.HasPrefix(data, []byte("{")) {
+		tx, err := NewTransactionFromPayload(data)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewTransactionEnvelope(tx, "", ""), nil
+	}
+
+	var e TransactionEnvelope
+	err := json.Unmarshal(data, &e)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse transaction envelope: %w", err)
+	}
+
+	tx, err := NewTransactionFromPayload([]byte(e.Payload))
+	if err != nil {
+		return nil, err
+	}
+	e.SetTransaction(tx)
+
+	return &e, nil
+}
+
+// Transaction returns the transaction in the envelope.
+func (e *TransactionEnvelope) Transaction() *Transaction {
+	return e.tx
+}
+
+// SetTransaction sets the transaction and updates the payload, signers and signatures.
+func (e *TransactionEnvelope) SetTransaction(tx *Transaction) {
+	flowTx := tx.FlowTransaction()
+
+	e.tx = tx
+	e.Payload = hex.EncodeToString(flowTx.Encode())
+	e.Signatures = make([]*EnvelopeSignature, 0)
+	for _, s := range flowTx.PayloadSignatures {
+		e.Signatures = append(e.Signatures, newEnvelopeSignature(s, SignaturePayload))
+	}
+	for _, s := range flowTx.EnvelopeSignatures {
+		e.Signatures = append(e.Signatures, newEnvelopeSignature(s, SignatureEnvelope))
+	}
+
+	e.Signers = make([]*EnvelopeSigner, 0)
+	addRole := func(address flow.Address, role string) *EnvelopeSigner {
+		for _, s := range e.Signers {
+			if s.Address == address {
+				s.Roles = append(s.Roles, role)
+				return s
+			}
+		}
+
+		s := &EnvelopeSigner{Address: address, Roles: []string{role}}
+		e.Signers = append(e.Signers, s)
+		return s
+	}
+
+	proposer := addRole(flowTx.ProposalKey.Address, RoleProposer)
+	proposer.KeyIndex = &flowTx.ProposalKey.KeyIndex
+	for _, a := range flowTx.Authorizers {
+		addRole(a, RoleAuthorizer)
+	}
+	addRole(flowTx.Payer, RolePayer)
+
+	for _, s := range e.Signers {
+		s.Signed = e.hasSignature(s)
+	}
+}
+
+// hasSignature checks if the signer signed the transaction.
+//
+// The payer must sign the envelope and other signers the payload, the proposer must
+// sign using the proposal key.
+func (e *TransactionEnvelope) hasSignature(signer *EnvelopeSigner) bool {
+	signatureType := SignaturePayload
+	if signer.Address == e.tx.FlowTransaction().Payer {
+		signatureType = SignatureEnvelope
+	}
+
+	for _, s := range e.Signatures {
+		if s.Address != signer.Address || s.Type != signatureType {
+			continue
+		}
+
+		if signer.KeyIndex == nil || s.KeyIndex == *signer.KeyIndex {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MissingSigners returns the signers that didn't sign the transaction yet.
+func (e *TransactionEnvelope) MissingSigners() []*EnvelopeSigner {
+	missing := make([]*EnvelopeSigner, 0)
+	for _, s := range e.Signers {
+		if !s.Signed {
+			missing = append(missing, s)
+		}
+	}
+
+	return missing
+}
+
+// Encode encodes the envelope to JSON.
+func (e *TransactionEnvelope) Encode() ([]byte, error) {
+	return json.MarshalIndent(e, "", "\t")
+}
+
+// String returns the signer address and roles.
+func (s *EnvelopeSigner) String() string {
+	return fmt.Sprintf("%s (%s)", s.Address, strings.Join(s.Roles, ", "))
+}
+
+func newEnvelopeSignature(s flow.TransactionSignature, signatureType string) *EnvelopeSignature {
+	return &EnvelopeSignature{
+		Address:   s.Address,
+		KeyIndex:  s.KeyIndex,
+		Type:      signatureType,
+		Signature: hex.EncodeToString(s.Signature),
+	}
+}
@@ -0,0 +1,102 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+)
+
+func TestTransactionEnvelope(t *testing.T) {
+	proposer := flow.HexToAddress("01")
+	payer := flow.HexToAddress("02")
+
+	tx := flow.NewTransaction().
+		SetScript([]byte(`transaction {}`)).
+		SetProposalKey(proposer, 1, 0).
+		SetPayer(payer).
+		AddAuthorizer(proposer).
+		AddAuthorizer(payer)
+	payload := hex.EncodeToString(tx.Encode())
+
+	t.Run("Parse Raw Payload", func(t *testing.T) {
+		e, err := flowkit.ParseTransactionEnvelope([]byte(payload + "\n"))
+		assert.NoError(t, err)
+		assert.Equal(t, payload, e.Payload)
+		assert.Len(t, e.Signers, 2)
+
+		assert.Equal(t, proposer, e.Signers[0].Address)
+		assert.Equal(t, []string{flowkit.RoleProposer, flowkit.RoleAuthorizer}, e.Signers[0].Roles)
+		assert.Equal(t, 1, *e.Signers[0].KeyIndex)
+
+		assert.Equal(t, payer, e.Signers[1].Address)
+		assert.Equal(t, []string{flowkit.RoleAuthorizer, flowkit.RolePayer}, e.Signers[1].Roles)
+		assert.Nil(t, e.Signers[1].KeyIndex)
+
+		assert.Len(t, e.MissingSigners(), 2)
+	})
+
+	t.Run("Parse Envelope", func(t *testing.T) {
+		e, _ := flowkit.ParseTransactionEnvelope([]byte(payload))
+		e.Source = "tx.cdc"
+		e.Network = "testnet"
+		data, err := e.Encode()
+		assert.NoError(t, err)
+
+		parsed, err := flowkit.ParseTransactionEnvelope(data)
+		assert.NoError(t, err)
+		assert.Equal(t, e, parsed)
+	})
+
+	t.Run("Signers From Payload", func(t *testing.T) {
+		signed := flow.NewTransaction().
+			SetScript([]byte(`transaction {}`)).
+			SetProposalKey(proposer, 1, 0).
+			SetPayer(payer).
+			AddAuthorizer(proposer).
+			AddAuthorizer(payer)
+		signed.AddPayloadSignature(proposer, 0, []byte{1})
+		signed.AddEnvelopeSignature(payer, 0, []byte{2})
+
+		// signers in the file are ignored, only the payload is used
+		e, err := flowkit.ParseTransactionEnvelope([]byte(`{
+			"payload": "` + hex.EncodeToString(signed.Encode()) + `",
+			"signers": [{"address": "01", "roles": ["proposer"], "signed": true}]
+		}`))
+		assert.NoError(t, err)
+		assert.Len(t, e.Signatures, 2)
+
+		missing := e.MissingSigners()
+		assert.Len(t, missing, 1)
+		assert.Equal(t, proposer, missing[0].Address) // signed with a key other than the proposal key
+	})
+
+	t.Run("Fail Parse", func(t *testing.T) {
+		_, err := flowkit.ParseTransactionEnvelope([]byte(`{"payload": 1}`))
+		assert.Error(t, err)
+
+		_, err = flowkit.ParseTransactionEnvelope([]byte(`zz`))
+		assert.Error(t, err)
+	})
+}
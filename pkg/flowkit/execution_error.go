@@ -0,0 +1,193 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	CadenceErrorParsing   = "parsing"
+	CadenceErrorChecking  = "checking"
+	CadenceErrorExecution = "execution"
+)
+
+var (
+	cadenceErrorRegex     = regexp.MustCompile(`(?m)^error: (.*)\n\s*--> (\S+):(\d+):(\d+)`)
+	cadenceErrorKindRegex = regexp.MustCompile(`(Parsing|Checking|Execution) failed:`)
+	cadenceErrorCodeRegex = regexp.MustCompile(`\[Error Code: (\d+)\]`)
+	programLocationRegex  = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+)
+
+// CadenceError is an error reported by Cadence when parsing, checking or executing the code.
+//
+// If the error is in the transaction or script code the location is the original source file
+// and the position is mapped back to it, otherwise the location is reported by Cadence,
+// for example an imported contract. The line starts at 1 and the column at 0.
+type CadenceError struct {
+	Kind     string `json:"kind"`
+	Message  string `json:"message"`
+	Location string `json:"location"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	source   string
+}
+
+// ExecutionError is an error returned when executing a transaction or script with parsed Cadence errors.
+type ExecutionError struct {
+	// Code is the error code reported by the network, zero if it wasn't reported.
+	Code   int
+	Errors []*CadenceError
+	prefix string
+	err    error
+}
+
+// NewExecutionError parses Cadence errors from the error and maps them to the original source code.
+//
+// The error is returned unchanged if it doesn't contain Cadence errors, a nil source map
+// leaves the positions as reported.
+func NewExecutionError(err error, sourceMap *SourceMap) error {
+	if err == nil {
+		return nil
+	}
+
+	message := err.Error()
+	matches := cadenceErrorRegex.FindAllStringSubmatchIndex(message, -1)
+	if len(matches) == 0 {
+		return err
+	}
+
+	kind := CadenceErrorExecution
+	if k := cadenceErrorKindRegex.FindStringSubmatch(message); k != nil {
+		kind = strings.ToLower(k[1])
+	}
+
+	code := 0
+	if c := cadenceErrorCodeRegex.FindStringSubmatch(message); c != nil {
+		code, _ = strconv.Atoi(c[1])
+	}
+
+	errors := make([]*CadenceError, 0, len(matches))
+	for _, m := range matches {
+		line, _ := strconv.Atoi(message[m[6]:m[7]])
+		column, _ := strconv.Atoi(message[m[8]:m[9]])
+
+		cadenceErr := &CadenceError{
+			Kind:     kind,
+			Message:  message[m[2]:m[3]],
+			Location: message[m[4]:m[5]],
+			Line:     line,
+			Column:   column,
+		}
+
+		if sourceMap != nil && programLocationRegex.MatchString(cadenceErr.Location) {
+			cadenceErr.Line, cadenceErr.Column = sourceMap.OriginalPosition(line, column)
+			cadenceErr.Location = sourceMap.Filename
+			cadenceErr.source, _ = sourceMap.Line(cadenceErr.Line)
+		}
+
+		errors = append(errors, cadenceErr)
+	}
+
+	return &ExecutionError{
+		Code:   code,
+		Errors: errors,
+		prefix: strings.TrimSpace(message[:matches[0][0]]),
+		err:    err,
+	}
+}
+
+// Error renders the errors with the offending source lines.
+func (e *ExecutionError) Error() string {
+	var b bytes.Buffer
+
+	if e.prefix != "" {
+		b.WriteString(e.prefix)
+		b.WriteString("\n")
+	}
+
+	for i, cadenceErr := range e.Errors {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(cadenceErr.String())
+	}
+
+	return b.String()
+}
+
+func (e *ExecutionError) Unwrap() error {
+	return e.err
+}
+
+// ExecutionErrorCode returns the Cadence error code of the error or zero if the error doesn't have a code.
+func ExecutionErrorCode(err error) int {
+	var executionErr *ExecutionError
+	if errors.As(err, &executionErr) {
+		return executionErr.Code
+	}
+
+	if c := cadenceErrorCodeRegex.FindStringSubmatch(err.Error()); c != nil {
+		code, _ := strconv.Atoi(c[1])
+		return code
+	}
+
+	return 0
+}
+
+// String renders the error and the source line with a caret at the error position, if the source is available.
+func (e *CadenceError) String() string {
+	var b bytes.Buffer
+
+	_, _ = fmt.Fprintf(&b, "error: %s\n", e.Message)
+
+	gutter := strings.Repeat(" ", len(strconv.Itoa(e.Line)))
+	_, _ = fmt.Fprintf(&b, "%s--> %s:%d:%d\n", gutter, e.Location, e.Line, e.Column)
+
+	if e.source != "" {
+		_, _ = fmt.Fprintf(&b, "%s |\n", gutter)
+		_, _ = fmt.Fprintf(&b, "%d | %s\n", e.Line, e.source)
+		_, _ = fmt.Fprintf(&b, "%s | %s^\n", gutter, caretPadding(e.source, e.Column))
+	}
+
+	return b.String()
+}
+
+// caretPadding returns whitespace up to the column, keeping tabs so the caret is aligned with the source line.
+func caretPadding(line string, column int) string {
+	var b strings.Builder
+	for i, r := range []rune(line) {
+		if i >= column {
+			break
+		}
+
+		if r == '\t' {
+			b.WriteRune('\t')
+		} else {
+			b.WriteRune(' ')
+		}
+	}
+
+	return b.String()
+}
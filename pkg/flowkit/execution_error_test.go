@@ -0,0 +1,119 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+)
+
+func TestExecutionError(t *testing.T) {
+	source := []byte("import Foo from \"./Foo.cdc\"\npub fun main(a: Int): Int {\n\tlet x = [1, 2]\n\treturn x[a]\n}\n")
+	location := "9f2d020bf8426285404aedf6ef03d608166cd8cc9897db08a4fc8a5f95a6ca4d"
+
+	networkErr := fmt.Errorf(`failed to submit executable script: [Error Code: 1101] cadence runtime error Execution failed:
+error: array index out of bounds: 5, but size is 2
+ --> %s:4:9
+  |
+4 | 	return x[a]
+  | 	        ^^^
+
+error: cannot find variable in this scope: bar
+ --> A.f8d6e0586b0a20c7.Foo:2:4
+`, location)
+
+	t.Run("Parse", func(t *testing.T) {
+		sourceMap := flowkit.NewSourceMap("./script.cdc", source)
+		err := flowkit.NewExecutionError(networkErr, sourceMap)
+
+		var executionErr *flowkit.ExecutionError
+		assert.ErrorAs(t, err, &executionErr)
+		assert.True(t, errors.Is(err, errors.Unwrap(err)))
+		assert.Equal(t, 1101, executionErr.Code)
+		assert.Len(t, executionErr.Errors, 2)
+
+		assert.Equal(t, &flowkit.CadenceError{
+			Kind:     flowkit.CadenceErrorExecution,
+			Message:  "array index out of bounds: 5, but size is 2",
+			Location: "./script.cdc",
+			Line:     4,
+			Column:   9,
+		}, withoutSource(executionErr.Errors[0]))
+
+		assert.Equal(t, "A.f8d6e0586b0a20c7.Foo", executionErr.Errors[1].Location)
+		assert.Equal(t, 2, executionErr.Errors[1].Line)
+
+		assert.Equal(t, `failed to submit executable script: [Error Code: 1101] cadence runtime error Execution failed:
+error: array index out of bounds: 5, but size is 2
+ --> ./script.cdc:4:9
+  |
+4 | 	return x[a]
+  | 	        ^
+
+error: cannot find variable in this scope: bar
+ --> A.f8d6e0586b0a20c7.Foo:2:4
+`, err.Error())
+	})
+
+	t.Run("Mapped Position", func(t *testing.T) {
+		sourceMap := flowkit.NewSourceMap("./script.cdc", source)
+		// "./Foo.cdc" with quotes replaced by 0x0000000000000001 at the start of the path
+		sourceMap.AddReplacement(1, 16, 11, 18)
+
+		err := flowkit.NewExecutionError(fmt.Errorf("Checking failed:\nerror: invalid import\n --> %s:1:40\n", location), sourceMap)
+
+		var executionErr *flowkit.ExecutionError
+		assert.ErrorAs(t, err, &executionErr)
+		assert.Equal(t, flowkit.CadenceErrorChecking, executionErr.Errors[0].Kind)
+		assert.Equal(t, 0, executionErr.Code)
+		assert.Equal(t, 1, executionErr.Errors[0].Line)
+		assert.Equal(t, 33, executionErr.Errors[0].Column)
+	})
+
+	t.Run("Not Cadence Error", func(t *testing.T) {
+		err := fmt.Errorf("connection refused")
+		assert.Equal(t, err, flowkit.NewExecutionError(err, nil))
+		assert.Nil(t, flowkit.NewExecutionError(nil, nil))
+	})
+
+	t.Run("Error Code", func(t *testing.T) {
+		withLocation := flowkit.NewExecutionError(networkErr, nil)
+		withoutLocation := fmt.Errorf("execution error code 1101: [Error Code: 1101] cadence runtime error Execution failed:\nerror: authorizer count mismatch")
+
+		assert.Equal(t, 1101, flowkit.ExecutionErrorCode(withLocation))
+		assert.Equal(t, 1101, flowkit.ExecutionErrorCode(withoutLocation))
+		assert.Equal(t, 0, flowkit.ExecutionErrorCode(fmt.Errorf("connection refused")))
+	})
+}
+
+// withoutSource copies the error without the unexported source line for comparison.
+func withoutSource(e *flowkit.CadenceError) *flowkit.CadenceError {
+	return &flowkit.CadenceError{
+		Kind:     e.Kind,
+		Message:  e.Message,
+		Location: e.Location,
+		Line:     e.Line,
+		Column:   e.Column,
+	}
+}
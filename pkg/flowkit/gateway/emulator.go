@@ -20,6 +20,7 @@ package gateway
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/onflow/flow-cli/pkg/flowkit"
@@ -27,25 +28,42 @@ import (
 
 	"github.com/onflow/cadence"
 	emulator "github.com/onflow/flow-emulator"
+	"github.com/onflow/flow-emulator/storage"
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/client"
 	"github.com/onflow/flow-go-sdk/client/convert"
 	flowGo "github.com/onflow/flow-go/model/flow"
 )
 
+// maxTransactionLogs is the number of most recently executed transactions the logs are kept for.
+const maxTransactionLogs = 100
+
 type EmulatorGateway struct {
 	emulator *emulator.Blockchain
+	store    *pathStore
+	logs     map[flow.Identifier][]string
+	logIDs   []flow.Identifier // transactions with logs in the order of execution
+	mu       sync.Mutex        // serializes executing and committing blocks
 }
 
 func NewEmulatorGateway(serviceAccount *flowkit.Account) *EmulatorGateway {
+	store := newPathStore()
+
 	return &EmulatorGateway{
-		emulator: newEmulator(serviceAccount),
+		emulator: newEmulator(serviceAccount, store),
+		store:    store,
+		logs:     make(map[flow.Identifier][]string),
 	}
 }
 
-func newEmulator(serviceAccount *flowkit.Account) *emulator.Blockchain {
-	var opts []emulator.Option
-	if serviceAccount != nil && serviceAccount.Key().Type() == config.KeyTypeHex {
+func newEmulator(serviceAccount *flowkit.Account, store storage.Store) *emulator.Blockchain {
+	opts := []emulator.Option{
+		// without an expiry the emulator only accepts transactions referencing the latest block,
+		// which transactions sent concurrently or signed ahead of sending can't do
+		emulator.WithTransactionExpiry(flowGo.DefaultTransactionExpiry),
+		emulator.WithStore(store),
+	}
+	if serviceAccount != nil && (serviceAccount.Key().Type() == config.KeyTypeHex || serviceAccount.Key().Type() == config.KeyTypeFile) {
 		privKey, _ := serviceAccount.Key().PrivateKey()
 
 		opts = append(opts, emulator.WithServicePublicKey(
@@ -68,16 +86,20 @@ func (g *EmulatorGateway) GetAccount(address flow.Address) (*flow.Account, error
 }
 
 func (g *EmulatorGateway) SendSignedTransaction(tx *flowkit.Transaction) (*flow.Transaction, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	t := tx.FlowTransaction()
 	err := g.emulator.AddTransaction(*t)
 	if err != nil {
 		return nil, fmt.Errorf("failed to submit transaction: %w", err)
 	}
 
-	_, err = g.emulator.ExecuteNextTransaction()
+	result, err := g.emulator.ExecuteNextTransaction()
 	if err != nil {
 		return nil, fmt.Errorf("failed to submit transaction: %w", err)
 	}
+	g.addLogs(t.ID(), result.Logs)
 
 	_, err = g.emulator.CommitBlock()
 	if err != nil {
@@ -87,6 +109,54 @@ func (g *EmulatorGateway) SendSignedTransaction(tx *flowkit.Transaction) (*flow.
 	return t, nil
 }
 
+// SimulateTransaction executes the transaction in a pending block which is reset afterwards,
+// so the transaction doesn't change the state of the emulator.
+func (g *EmulatorGateway) SimulateTransaction(tx *flowkit.Transaction) (*flow.TransactionResult, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	err := g.emulator.AddTransaction(*tx.FlowTransaction())
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate transaction: %w", err)
+	}
+	defer func() { _ = g.emulator.ResetPendingBlock() }()
+
+	result, err := g.emulator.ExecuteNextTransaction()
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate transaction: %w", err)
+	}
+	g.addLogs(result.TransactionID, result.Logs)
+
+	return &flow.TransactionResult{
+		Status: flow.TransactionStatusUnknown,
+		Error:  result.Error,
+		Events: result.Events,
+	}, nil
+}
+
+// addLogs stores the logs of the transaction, the logs of the oldest transaction are removed
+// when logs of more than maxTransactionLogs transactions are stored.
+func (g *EmulatorGateway) addLogs(id flow.Identifier, logs []string) {
+	if _, ok := g.logs[id]; !ok {
+		g.logIDs = append(g.logIDs, id)
+	}
+	g.logs[id] = logs
+
+	if len(g.logIDs) > maxTransactionLogs {
+		delete(g.logs, g.logIDs[0])
+		g.logIDs = g.logIDs[1:]
+	}
+}
+
+// TransactionLogs returns the logs of a transaction executed by the gateway,
+// only the logs of the most recently executed transactions are kept.
+func (g *EmulatorGateway) TransactionLogs(id flow.Identifier) []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.logs[id]
+}
+
 func (g *EmulatorGateway) GetTransactionResult(tx *flow.Transaction, waitSeal bool) (*flow.TransactionResult, error) {
 	result, err := g.emulator.GetTransactionResult(tx.ID())
 	if err != nil {
@@ -110,21 +180,27 @@ func (g *EmulatorGateway) Ping() error {
 }
 
 func (g *EmulatorGateway) ExecuteScript(script []byte, arguments []cadence.Value) (cadence.Value, error) {
+	value, _, err := g.ExecuteScriptWithLogs(script, arguments)
+	return value, err
+}
+
+// ExecuteScriptWithLogs executes the script and returns the value together with the logs.
+func (g *EmulatorGateway) ExecuteScriptWithLogs(script []byte, arguments []cadence.Value) (cadence.Value, []string, error) {
 	args, err := convert.CadenceValuesToMessages(arguments)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	result, err := g.emulator.ExecuteScript(script, args)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, result.Logs, result.Error
 	}
 
-	return result.Value, nil
+	return result.Value, result.Logs, nil
 }
 
 func (g *EmulatorGateway) GetLatestBlock() (*flow.Block, error) {
@@ -195,10 +271,23 @@ func (g *EmulatorGateway) GetCollection(id flow.Identifier) (*flow.Collection, e
 
 func (g *EmulatorGateway) GetBlockByID(id flow.Identifier) (*flow.Block, error) {
 	block, err := g.emulator.GetBlockByID(id)
-	return convertBlock(block), err
+	if err != nil {
+		return nil, err
+	}
+
+	return convertBlock(block), nil
+}
+
+// StoredPaths returns the storage, public and private paths with a value stored in the account.
+func (g *EmulatorGateway) StoredPaths(address flow.Address) ([]string, error) {
+	return g.store.paths(address), nil
 }
 
 func (g *EmulatorGateway) GetBlockByHeight(height uint64) (*flow.Block, error) {
 	block, err := g.emulator.GetBlockByHeight(height)
-	return convertBlock(block), err
+	if err != nil {
+		return nil, err
+	}
+
+	return convertBlock(block), nil
 }
@@ -40,3 +40,19 @@ type Gateway interface {
 	GetCollection(flow.Identifier) (*flow.Collection, error)
 	Ping() error
 }
+
+// Simulator describes gateways able to execute transactions without submitting them.
+type Simulator interface {
+	SimulateTransaction(*flowkit.Transaction) (*flow.TransactionResult, error)
+}
+
+// ProgramLogger describes gateways capturing the output of the Cadence log function.
+type ProgramLogger interface {
+	TransactionLogs(flow.Identifier) []string
+	ExecuteScriptWithLogs([]byte, []cadence.Value) (cadence.Value, []string, error)
+}
+
+// StorageInspector describes gateways able to list the storage paths used by an account.
+type StorageInspector interface {
+	StoredPaths(flow.Address) ([]string, error)
+}
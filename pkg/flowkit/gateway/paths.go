@@ -0,0 +1,113 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/onflow/flow-emulator/storage/memstore"
+	"github.com/onflow/flow-emulator/types"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	flowGo "github.com/onflow/flow-go/model/flow"
+)
+
+// pathDomains are the storage domains of values stored in an account at a path.
+var pathDomains = map[string]bool{
+	"storage": true,
+	"public":  true,
+	"private": true,
+}
+
+// pathStore is an emulator store keeping track of the paths used in account storage.
+//
+// Cadence stores the value at a path in the register keyed by the domain and identifier
+// separated by the unit separator, the paths are collected from the committed register updates.
+type pathStore struct {
+	*memstore.Store
+	mu     sync.RWMutex
+	owners map[string]map[string]bool
+}
+
+func newPathStore() *pathStore {
+	return &pathStore{
+		Store:  memstore.New(),
+		owners: make(map[string]map[string]bool),
+	}
+}
+
+func (s *pathStore) CommitBlock(
+	block flowGo.Block,
+	collections []*flowGo.LightCollection,
+	transactions map[flowGo.Identifier]*flowGo.TransactionBody,
+	transactionResults map[flowGo.Identifier]*types.StorableTransactionResult,
+	ledgerDelta delta.Delta,
+	events []flowGo.Event,
+) error {
+	err := s.Store.CommitBlock(block, collections, transactions, transactionResults, ledgerDelta, events)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, entry := range ledgerDelta.Data {
+		if entry.Key.Controller != "" {
+			continue
+		}
+
+		parts := strings.Split(entry.Key.Key, "\x1f")
+		if len(parts) != 2 || !pathDomains[parts[0]] {
+			continue
+		}
+
+		path := fmt.Sprintf("/%s/%s", parts[0], parts[1])
+		paths, ok := s.owners[entry.Key.Owner]
+		if !ok {
+			paths = make(map[string]bool)
+			s.owners[entry.Key.Owner] = paths
+		}
+
+		if len(entry.Value) > 0 {
+			paths[path] = true
+		} else {
+			delete(paths, path)
+		}
+	}
+
+	return nil
+}
+
+// paths returns the sorted paths used in the storage of the account.
+func (s *pathStore) paths(address flow.Address) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	paths := make([]string, 0)
+	for path := range s.owners[string(address.Bytes())] {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	return paths
+}
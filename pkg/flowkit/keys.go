@@ -25,6 +25,7 @@ import (
 	"os"
 	"os/exec"
 	"regexp"
+	"sync"
 
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
@@ -44,6 +45,7 @@ type AccountKey interface {
 	ToConfig() config.AccountKey
 	Validate() error
 	PrivateKey() (*crypto.PrivateKey, error)
+	PublicKey(ctx context.Context) (crypto.PublicKey, error)
 }
 
 func NewAccountKey(accountKeyConf config.AccountKey) (AccountKey, error) {
@@ -52,6 +54,8 @@ func NewAccountKey(accountKeyConf config.AccountKey) (AccountKey, error) {
 		return newHexAccountKey(accountKeyConf)
 	case config.KeyTypeGoogleKMS:
 		return newKmsAccountKey(accountKeyConf)
+	case config.KeyTypeFile:
+		return newFileAccountKey(accountKeyConf)
 	}
 
 	return nil, fmt.Errorf(`invalid key type: "%s"`, accountKeyConf.Type)
@@ -96,7 +100,9 @@ func (a *baseAccountKey) Validate() error {
 // KmsAccountKey implements Gcloud KMS system for signing.
 type KmsAccountKey struct {
 	*baseAccountKey
-	kmsKey cloudkms.Key
+	kmsKey    cloudkms.Key
+	mu        sync.Mutex
+	publicKey crypto.PublicKey // cached public key, fetched from KMS on first use
 }
 
 // ToConfig convert account key to configuration.
@@ -136,6 +142,28 @@ func (a *KmsAccountKey) PrivateKey() (*crypto.PrivateKey, error) {
 	return nil, fmt.Errorf("private key not accessible")
 }
 
+func (a *KmsAccountKey) PublicKey(ctx context.Context) (crypto.PublicKey, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.publicKey != nil {
+		return a.publicKey, nil
+	}
+
+	kmsClient, err := cloudkms.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, _, err := kmsClient.GetPublicKey(ctx, a.kmsKey)
+	if err != nil {
+		return nil, err
+	}
+
+	a.publicKey = publicKey
+	return publicKey, nil
+}
+
 // gcloudApplicationSignin signs in as an application user using gcloud command line tool
 // currently assumes gcloud is already installed on the machine
 // will by default pop a browser window to sign in
@@ -228,6 +256,10 @@ func (a *HexAccountKey) PrivateKey() (*crypto.PrivateKey, error) {
 	return &a.privateKey, nil
 }
 
+func (a *HexAccountKey) PublicKey(_ context.Context) (crypto.PublicKey, error) {
+	return a.privateKey.PublicKey(), nil
+}
+
 func (a *HexAccountKey) ToConfig() config.AccountKey {
 	return config.AccountKey{
 		Type:       a.keyType,
@@ -250,3 +282,53 @@ func (a *HexAccountKey) Validate() error {
 func (a *HexAccountKey) PrivateKeyHex() string {
 	return hex.EncodeToString(a.privateKey.Encode())
 }
+
+// FileAccountKey implements account key with the private key in hex representation stored in a file.
+//
+// The private key is read from the file when the configuration is loaded.
+type FileAccountKey struct {
+	*HexAccountKey
+	location string
+}
+
+func NewFileAccountKey(
+	location string,
+	index int,
+	hashAlgo crypto.HashAlgorithm,
+	privateKey crypto.PrivateKey,
+) *FileAccountKey {
+	key := NewHexAccountKeyFromPrivateKey(index, hashAlgo, privateKey)
+	key.keyType = config.KeyTypeFile
+
+	return &FileAccountKey{
+		HexAccountKey: key,
+		location:      location,
+	}
+}
+
+func newFileAccountKey(accountKey config.AccountKey) (*FileAccountKey, error) {
+	if accountKey.PrivateKey == nil {
+		return nil, fmt.Errorf("private key of the key file %s is not loaded", accountKey.Location)
+	}
+
+	hexKey, err := newHexAccountKey(accountKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileAccountKey{
+		HexAccountKey: hexKey,
+		location:      accountKey.Location,
+	}, nil
+}
+
+// Location returns the path of the file containing the private key.
+func (a *FileAccountKey) Location() string {
+	return a.location
+}
+
+func (a *FileAccountKey) ToConfig() config.AccountKey {
+	conf := a.HexAccountKey.ToConfig()
+	conf.Location = a.location
+	return conf
+}
@@ -0,0 +1,155 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// TransactionManifest lists transactions sent together as a batch.
+//
+// Transactions are sent in any order unless they depend on other transactions,
+// in which case they are only sent after all their dependencies were sealed without an error.
+type TransactionManifest struct {
+	Transactions []*ManifestTransaction `json:"transactions" yaml:"transactions"`
+}
+
+// ManifestTransaction is a transaction in the manifest.
+//
+// The name defaults to the filename and must be unique, arguments are either provided as strings
+// converted to the parameter types of the transaction or as JSON-Cadence.
+type ManifestTransaction struct {
+	Name      string   `json:"name,omitempty" yaml:"name,omitempty"`
+	File      string   `json:"file" yaml:"file"`
+	Signer    string   `json:"signer,omitempty" yaml:"signer,omitempty"`
+	Args      []string `json:"args,omitempty" yaml:"args,omitempty"`
+	ArgsJSON  string   `json:"argsJson,omitempty" yaml:"argsJson,omitempty"`
+	GasLimit  uint64   `json:"gasLimit,omitempty" yaml:"gasLimit,omitempty"`
+	DependsOn []string `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty"`
+}
+
+// ParseTransactionManifest parses the manifest in JSON or YAML format, YAML is used for files with yml or yaml extension.
+func ParseTransactionManifest(data []byte, filename string) (*TransactionManifest, error) {
+	var m TransactionManifest
+
+	var err error
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yml", ".yaml":
+		err = yaml.UnmarshalStrict(data, &m)
+	default:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&m)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	err = m.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// ByName returns the transaction with the name or nil if it doesn't exist.
+func (m *TransactionManifest) ByName(name string) *ManifestTransaction {
+	for _, tx := range m.Transactions {
+		if tx.Name == name {
+			return tx
+		}
+	}
+
+	return nil
+}
+
+// validate sets the default names and checks the transactions and their dependencies.
+func (m *TransactionManifest) validate() error {
+	if len(m.Transactions) == 0 {
+		return fmt.Errorf("manifest doesn't contain any transactions")
+	}
+
+	names := make(map[string]bool)
+	for i, tx := range m.Transactions {
+		if tx.File == "" {
+			return fmt.Errorf("missing file for transaction at index %d", i)
+		}
+		if tx.Name == "" {
+			tx.Name = tx.File
+		}
+		if names[tx.Name] {
+			return fmt.Errorf("duplicate transaction name %s, specify a unique name for each transaction", tx.Name)
+		}
+		if len(tx.Args) > 0 && tx.ArgsJSON != "" {
+			return fmt.Errorf("transaction %s can not specify both args and argsJson", tx.Name)
+		}
+		names[tx.Name] = true
+	}
+
+	for _, tx := range m.Transactions {
+		for _, dep := range tx.DependsOn {
+			if !names[dep] {
+				return fmt.Errorf("transaction %s depends on transaction %s that doesn't exist", tx.Name, dep)
+			}
+		}
+	}
+
+	// detect cycles with a depth first search, visiting marks transactions on the current path
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int)
+	var visit func(tx *ManifestTransaction, path []string) error
+	visit = func(tx *ManifestTransaction, path []string) error {
+		switch state[tx.Name] {
+		case visiting:
+			return fmt.Errorf("dependency cycle between transactions: %s", strings.Join(append(path, tx.Name), " -> "))
+		case visited:
+			return nil
+		}
+
+		state[tx.Name] = visiting
+		for _, dep := range tx.DependsOn {
+			err := visit(m.ByName(dep), append(path, tx.Name))
+			if err != nil {
+				return err
+			}
+		}
+		state[tx.Name] = visited
+
+		return nil
+	}
+
+	for _, tx := range m.Transactions {
+		err := visit(tx, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
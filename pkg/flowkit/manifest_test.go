@@ -0,0 +1,86 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+)
+
+func TestParseTransactionManifest(t *testing.T) {
+	t.Run("JSON", func(t *testing.T) {
+		manifest, err := flowkit.ParseTransactionManifest([]byte(`{
+			"transactions": [
+				{"file": "setup.cdc", "signer": "alice"},
+				{"name": "mint", "file": "mint.cdc", "args": ["10.0", "0x01"], "gasLimit": 500, "dependsOn": ["setup.cdc"]}
+			]
+		}`), "manifest.json")
+		assert.NoError(t, err)
+		assert.Len(t, manifest.Transactions, 2)
+
+		assert.Equal(t, "setup.cdc", manifest.Transactions[0].Name)
+		assert.Equal(t, "alice", manifest.Transactions[0].Signer)
+
+		mint := manifest.ByName("mint")
+		assert.Equal(t, []string{"10.0", "0x01"}, mint.Args)
+		assert.Equal(t, uint64(500), mint.GasLimit)
+		assert.Equal(t, []string{"setup.cdc"}, mint.DependsOn)
+	})
+
+	t.Run("YAML", func(t *testing.T) {
+		manifest, err := flowkit.ParseTransactionManifest([]byte(`
+transactions:
+  - name: setup
+    file: setup.cdc
+  - name: mint
+    file: mint.cdc
+    argsJson: '[{"type": "UFix64", "value": "10.0"}]'
+    dependsOn: [setup]
+`), "manifest.yml")
+		assert.NoError(t, err)
+		assert.Len(t, manifest.Transactions, 2)
+		assert.Equal(t, `[{"type": "UFix64", "value": "10.0"}]`, manifest.ByName("mint").ArgsJSON)
+		assert.Equal(t, []string{"setup"}, manifest.ByName("mint").DependsOn)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		invalid := map[string]string{
+			`{"transactions": []}`:                                                   "doesn't contain any transactions",
+			`{"transactions": [{"name": "a"}]}`:                                      "missing file",
+			`{"transactions": [{"file": "a.cdc"}, {"file": "a.cdc"}]}`:               "duplicate transaction name a.cdc",
+			`{"transactions": [{"file": "a.cdc", "dependsOn": ["b"]}]}`:              "depends on transaction b that doesn't exist",
+			`{"transactions": [{"file": "a.cdc", "args": ["1"], "argsJson": "[]"}]}`: "both args and argsJson",
+			`{"transactions": [{"file": "a.cdc", "unknown": true}]}`:                 "unknown field",
+			`{"transactions": [
+				{"name": "a", "file": "a.cdc", "dependsOn": ["c"]},
+				{"name": "b", "file": "b.cdc", "dependsOn": ["a"]},
+				{"name": "c", "file": "c.cdc", "dependsOn": ["b"]}
+			]}`: "dependency cycle between transactions: a -> c -> b -> a",
+		}
+
+		for data, msg := range invalid {
+			_, err := flowkit.ParseTransactionManifest([]byte(data), "manifest.json")
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), msg)
+		}
+	})
+}
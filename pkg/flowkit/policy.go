@@ -0,0 +1,292 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+// Policy restricts which transactions can be signed.
+//
+// All the rules are optional, an empty list or zero value doesn't restrict the transaction.
+// Authorizers and payers are account names from the configuration or addresses, templates
+// are paths to transaction files whose imports are resolved the same way as when sending.
+type Policy struct {
+	ScriptHashes []string              `json:"scriptHashes,omitempty"`
+	Templates    []string              `json:"templates,omitempty"`
+	Authorizers  []string              `json:"authorizers,omitempty"`
+	Payers       []string              `json:"payers,omitempty"`
+	MaxGasLimit  uint64                `json:"maxGasLimit,omitempty"`
+	Arguments    []*ArgumentConstraint `json:"arguments,omitempty"`
+
+	templates   [][]byte
+	authorizers []flow.Address
+	payers      []flow.Address
+}
+
+// ArgumentConstraint restricts the value of the transaction argument at the index.
+//
+// Allowed values are compared with the argument value formatted as Cadence literal, strings without quotes
+// and addresses compared as addresses.
+// Minimum and maximum are only valid for number arguments.
+type ArgumentConstraint struct {
+	Index   int      `json:"index"`
+	Type    string   `json:"type,omitempty"`
+	Allowed []string `json:"allowed,omitempty"`
+	Min     string   `json:"min,omitempty"`
+	Max     string   `json:"max,omitempty"`
+}
+
+// PolicyViolation describes why a transaction is not allowed by the policy.
+type PolicyViolation struct {
+	Rule    string
+	Message string
+}
+
+func (v PolicyViolation) String() string {
+	return fmt.Sprintf("%s: %s", v.Rule, v.Message)
+}
+
+// PolicyViolationError is returned when the transaction violates the signing policy.
+type PolicyViolationError struct {
+	Violations []PolicyViolation
+}
+
+func (e *PolicyViolationError) Error() string {
+	violations := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		violations = append(violations, fmt.Sprintf("  - %s", v))
+	}
+
+	return fmt.Sprintf("transaction violates the signing policy:\n%s", strings.Join(violations, "\n"))
+}
+
+// ParsePolicy parses the policy in JSON format.
+func ParsePolicy(data []byte) (*Policy, error) {
+	var p Policy
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(&p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse policy: %w", err)
+	}
+
+	for _, a := range p.Arguments {
+		for _, bound := range []string{a.Min, a.Max} {
+			if _, ok := new(big.Rat).SetString(bound); bound != "" && !ok {
+				return nil, fmt.Errorf("invalid bound %s for argument %d", bound, a.Index)
+			}
+		}
+	}
+
+	return &p, nil
+}
+
+// Resolve resolves authorizer and payer account names to addresses and loads templates code.
+//
+// Policy must be resolved before it is used to evaluate transactions.
+func (p *Policy) Resolve(accounts *Accounts, template func(path string) ([]byte, error)) error {
+	resolveAddresses := func(values []string) ([]flow.Address, error) {
+		addresses := make([]flow.Address, 0, len(values))
+		for _, v := range values {
+			if address, valid := util.ParseAddress(v); valid {
+				addresses = append(addresses, address)
+				continue
+			}
+
+			account, err := accounts.ByName(v)
+			if err != nil {
+				return nil, fmt.Errorf("policy: %w", err)
+			}
+			addresses = append(addresses, account.Address())
+		}
+		return addresses, nil
+	}
+
+	var err error
+	p.authorizers, err = resolveAddresses(p.Authorizers)
+	if err != nil {
+		return err
+	}
+
+	p.payers, err = resolveAddresses(p.Payers)
+	if err != nil {
+		return err
+	}
+
+	p.templates = make([][]byte, 0, len(p.Templates))
+	for _, path := range p.Templates {
+		code, err := template(path)
+		if err != nil {
+			return fmt.Errorf("policy template %s: %w", path, err)
+		}
+		p.templates = append(p.templates, bytes.TrimSpace(code))
+	}
+
+	return nil
+}
+
+// ScriptHash returns the hex encoded SHA3-256 hash of the script.
+func ScriptHash(script []byte) string {
+	return hex.EncodeToString(crypto.NewSHA3_256().ComputeHash(script))
+}
+
+// Evaluate checks the transaction against the policy and returns a policy violation error listing all violations.
+func (p *Policy) Evaluate(tx *flow.Transaction) error {
+	violations := make([]PolicyViolation, 0)
+	violate := func(rule string, format string, a ...interface{}) {
+		violations = append(violations, PolicyViolation{Rule: rule, Message: fmt.Sprintf(format, a...)})
+	}
+
+	if len(p.ScriptHashes) > 0 || len(p.Templates) > 0 {
+		if !p.scriptAllowed(tx.Script) {
+			violate("script", "script with hash %s doesn't match any allowed script hash or template", ScriptHash(tx.Script))
+		}
+	}
+
+	if len(p.Authorizers) > 0 {
+		for _, a := range tx.Authorizers {
+			if !containsAddress(p.authorizers, a) {
+				violate("authorizers", "account %s is not allowed to authorize transactions", a)
+			}
+		}
+	}
+
+	if len(p.Payers) > 0 && !containsAddress(p.payers, tx.Payer) {
+		violate("payers", "account %s is not allowed to pay for transactions", tx.Payer)
+	}
+
+	if p.MaxGasLimit > 0 && tx.GasLimit > p.MaxGasLimit {
+		violate("maxGasLimit", "gas limit %d exceeds the maximum %d", tx.GasLimit, p.MaxGasLimit)
+	}
+
+	for _, c := range p.Arguments {
+		rule := fmt.Sprintf("arguments[%d]", c.Index)
+		if c.Index < 0 || c.Index >= len(tx.Arguments) {
+			violate(rule, "argument is missing")
+			continue
+		}
+
+		value, err := jsoncdc.Decode(tx.Arguments[c.Index])
+		if err != nil {
+			violate(rule, "argument can not be decoded: %s", err)
+			continue
+		}
+
+		for _, message := range c.check(value) {
+			violate(rule, message)
+		}
+	}
+
+	if len(violations) > 0 {
+		return &PolicyViolationError{Violations: violations}
+	}
+
+	return nil
+}
+
+func (p *Policy) scriptAllowed(script []byte) bool {
+	hash := ScriptHash(script)
+	for _, h := range p.ScriptHashes {
+		if strings.EqualFold(strings.TrimPrefix(h, "0x"), hash) {
+			return true
+		}
+	}
+
+	for _, t := range p.templates {
+		if bytes.Equal(t, bytes.TrimSpace(script)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// check returns messages describing why the value doesn't satisfy the constraint.
+func (c *ArgumentConstraint) check(value cadence.Value) []string {
+	messages := make([]string, 0)
+
+	if c.Type != "" && value.Type().ID() != c.Type {
+		messages = append(messages, fmt.Sprintf("type %s is not the required type %s", value.Type().ID(), c.Type))
+	}
+
+	formatted := value.String()
+	switch v := value.(type) {
+	case cadence.String:
+		formatted = string(v)
+	case cadence.Address:
+		formatted = fmt.Sprintf("0x%s", flow.Address(v).Hex())
+	}
+
+	if len(c.Allowed) > 0 {
+		allowed := false
+		for _, a := range c.Allowed {
+			if address, ok := value.(cadence.Address); ok {
+				allowed = allowed || flow.HexToAddress(a) == flow.Address(address)
+			} else {
+				allowed = allowed || a == formatted
+			}
+		}
+
+		if !allowed {
+			messages = append(messages, fmt.Sprintf("value %s is not one of the allowed values %s", formatted, c.Allowed))
+		}
+	}
+
+	if c.Min == "" && c.Max == "" {
+		return messages
+	}
+
+	number, ok := new(big.Rat).SetString(formatted)
+	if !ok {
+		return append(messages, fmt.Sprintf("value %s is not a number, can not check bounds", formatted))
+	}
+
+	if min, ok := new(big.Rat).SetString(c.Min); ok && c.Min != "" && number.Cmp(min) < 0 {
+		messages = append(messages, fmt.Sprintf("value %s is less than the minimum %s", formatted, c.Min))
+	}
+	if max, ok := new(big.Rat).SetString(c.Max); ok && c.Max != "" && number.Cmp(max) > 0 {
+		messages = append(messages, fmt.Sprintf("value %s is greater than the maximum %s", formatted, c.Max))
+	}
+
+	return messages
+}
+
+func containsAddress(addresses []flow.Address, address flow.Address) bool {
+	for _, a := range addresses {
+		if a == address {
+			return true
+		}
+	}
+
+	return false
+}
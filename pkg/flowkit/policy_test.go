@@ -0,0 +1,127 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+)
+
+func TestPolicy(t *testing.T) {
+	alice := flow.HexToAddress("f8d6e0586b0a20c7")
+	bob := flow.HexToAddress("01cf0e2f2f715450")
+	script := []byte(`transaction(amount: UFix64, to: Address) {}`)
+
+	newTx := func(amount string, gasLimit uint64) *flow.Transaction {
+		value, _ := cadence.NewUFix64(amount)
+		tx := flow.NewTransaction().
+			SetScript(script).
+			SetPayer(bob).
+			SetGasLimit(gasLimit).
+			AddAuthorizer(alice)
+		_ = tx.AddArgument(value)
+		_ = tx.AddArgument(cadence.NewAddress(bob))
+		return tx
+	}
+
+	newPolicy := func(data string) *flowkit.Policy {
+		p, err := flowkit.ParsePolicy([]byte(data))
+		assert.NoError(t, err)
+
+		err = p.Resolve(&flowkit.Accounts{}, func(path string) ([]byte, error) {
+			return []byte(fmt.Sprintf("\n%s\n", script)), nil
+		})
+		assert.NoError(t, err)
+
+		return p
+	}
+
+	t.Run("Allowed", func(t *testing.T) {
+		p := newPolicy(fmt.Sprintf(`{
+			"scriptHashes": ["%s"],
+			"authorizers": ["0xf8d6e0586b0a20c7"],
+			"payers": ["01cf0e2f2f715450"],
+			"maxGasLimit": 100,
+			"arguments": [
+				{"index": 0, "type": "UFix64", "min": "1", "max": "10.5"},
+				{"index": 1, "allowed": ["0x01cf0e2f2f715450"]}
+			]
+		}`, flowkit.ScriptHash(script)))
+
+		assert.NoError(t, p.Evaluate(newTx("10.5", 100)))
+	})
+
+	t.Run("Allowed Template", func(t *testing.T) {
+		p := newPolicy(`{"templates": ["./transfer.cdc"]}`)
+		assert.NoError(t, p.Evaluate(newTx("1.0", 100)))
+
+		tx := newTx("1.0", 100)
+		tx.SetScript([]byte(`transaction {}`))
+		assert.Error(t, p.Evaluate(tx))
+	})
+
+	t.Run("Violations", func(t *testing.T) {
+		p := newPolicy(`{
+			"scriptHashes": ["aa"],
+			"authorizers": ["0x01cf0e2f2f715450"],
+			"payers": ["0xf8d6e0586b0a20c7"],
+			"maxGasLimit": 10,
+			"arguments": [
+				{"index": 0, "type": "UFix64", "max": "5"},
+				{"index": 1, "type": "String", "allowed": ["0xf8d6e0586b0a20c7"]},
+				{"index": 2, "min": "0"}
+			]
+		}`)
+
+		err := p.Evaluate(newTx("10.0", 100))
+
+		var policyErr *flowkit.PolicyViolationError
+		assert.ErrorAs(t, err, &policyErr)
+		assert.Equal(t, []flowkit.PolicyViolation{
+			{Rule: "script", Message: fmt.Sprintf("script with hash %s doesn't match any allowed script hash or template", flowkit.ScriptHash(script))},
+			{Rule: "authorizers", Message: "account f8d6e0586b0a20c7 is not allowed to authorize transactions"},
+			{Rule: "payers", Message: "account 01cf0e2f2f715450 is not allowed to pay for transactions"},
+			{Rule: "maxGasLimit", Message: "gas limit 100 exceeds the maximum 10"},
+			{Rule: "arguments[0]", Message: "value 10.00000000 is greater than the maximum 5"},
+			{Rule: "arguments[1]", Message: "type Address is not the required type String"},
+			{Rule: "arguments[1]", Message: "value 0x01cf0e2f2f715450 is not one of the allowed values [0xf8d6e0586b0a20c7]"},
+			{Rule: "arguments[2]", Message: "argument is missing"},
+		}, policyErr.Violations)
+	})
+
+	t.Run("Fail Parse", func(t *testing.T) {
+		_, err := flowkit.ParsePolicy([]byte(`{"maxGas": 10}`))
+		assert.Error(t, err)
+
+		_, err = flowkit.ParsePolicy([]byte(`{"arguments": [{"index": 0, "min": "one"}]}`))
+		assert.EqualError(t, err, "invalid bound one for argument 0")
+	})
+
+	t.Run("Fail Resolve", func(t *testing.T) {
+		p, _ := flowkit.ParsePolicy([]byte(`{"payers": ["alice"]}`))
+		err := p.Resolve(&flowkit.Accounts{}, nil)
+		assert.EqualError(t, err, "policy: could not find account with name alice in the configuration")
+	})
+}
@@ -0,0 +1,148 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// ProposalKey is an account key used as a transaction proposal key.
+type ProposalKey struct {
+	Address        flow.Address
+	Index          int
+	SequenceNumber uint64
+	stale          bool
+}
+
+// ProposalKeyPool hands out distinct proposal keys of one account.
+//
+// Sequence numbers are tracked locally so transactions can be sent concurrently
+// without fetching the account for each of them. A key that was used in a failed
+// transaction is resynced from the network before it is handed out again.
+type ProposalKeyPool struct {
+	address flow.Address
+	fetch   func(flow.Address) (*flow.Account, error)
+	keys    chan *ProposalKey
+	size    int
+}
+
+// NewProposalKeyPool creates a pool from all the account keys matching the key at provided index.
+//
+// Only keys that are not revoked and share the public key and algorithms with the key at
+// the provided index are added, so they can all be signed using the same account key.
+func NewProposalKeyPool(
+	account *flow.Account,
+	keyIndex int,
+	fetch func(flow.Address) (*flow.Account, error),
+) (*ProposalKeyPool, error) {
+	if keyIndex < 0 || keyIndex >= len(account.Keys) {
+		return nil, fmt.Errorf("key index %d does not exist on account %s", keyIndex, account.Address)
+	}
+
+	base := account.Keys[keyIndex]
+	var keys []*ProposalKey
+	for _, key := range account.Keys {
+		if key.Revoked ||
+			key.SigAlgo != base.SigAlgo ||
+			key.HashAlgo != base.HashAlgo ||
+			!key.PublicKey.Equals(base.PublicKey) {
+			continue
+		}
+
+		keys = append(keys, &ProposalKey{
+			Address:        account.Address,
+			Index:          key.Index,
+			SequenceNumber: key.SequenceNumber,
+		})
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no valid proposal keys found on account %s", account.Address)
+	}
+
+	pool := &ProposalKeyPool{
+		address: account.Address,
+		fetch:   fetch,
+		keys:    make(chan *ProposalKey, len(keys)),
+		size:    len(keys),
+	}
+	for _, key := range keys {
+		pool.keys <- key
+	}
+
+	return pool, nil
+}
+
+// Size returns the number of keys in the pool.
+func (p *ProposalKeyPool) Size() int {
+	return p.size
+}
+
+// Acquire waits for a free proposal key and returns it.
+//
+// If the key was used in a failed transaction its sequence number is resynced from
+// the network first. The key must be returned to the pool using Release.
+func (p *ProposalKeyPool) Acquire() (*ProposalKey, error) {
+	key := <-p.keys
+	if !key.stale {
+		return key, nil
+	}
+
+	err := p.resync(key)
+	if err != nil {
+		p.keys <- key // return key so it can be resynced again
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// Release returns the key to the pool.
+//
+// The sequence number is incremented if the transaction using the key succeeded,
+// otherwise the key is marked to be resynced from the network.
+func (p *ProposalKeyPool) Release(key *ProposalKey, failed bool) {
+	if failed {
+		key.stale = true
+	} else {
+		key.SequenceNumber++
+	}
+
+	p.keys <- key
+}
+
+// resync fetches the current sequence number of the key from the network.
+func (p *ProposalKeyPool) resync(key *ProposalKey) error {
+	account, err := p.fetch(p.address)
+	if err != nil {
+		return fmt.Errorf("failed to resync proposal key %d: %w", key.Index, err)
+	}
+
+	for _, k := range account.Keys {
+		if k.Index == key.Index {
+			key.SequenceNumber = k.SequenceNumber
+			key.stale = false
+			return nil
+		}
+	}
+
+	return fmt.Errorf("proposal key %d no longer exists on account %s", key.Index, p.address)
+}
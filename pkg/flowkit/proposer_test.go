@@ -0,0 +1,109 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/tests"
+)
+
+func TestProposalKeyPool(t *testing.T) {
+	pubKeys := tests.PubKeys()
+	address := flow.HexToAddress("01")
+
+	newAccount := func() *flow.Account {
+		return &flow.Account{
+			Address: address,
+			Keys: []*flow.AccountKey{
+				{Index: 0, PublicKey: pubKeys[0], SequenceNumber: 3, Weight: 1000},
+				{Index: 1, PublicKey: pubKeys[0], SequenceNumber: 5, Weight: 1000},
+				{Index: 2, PublicKey: pubKeys[1], SequenceNumber: 1, Weight: 1000},
+				{Index: 3, PublicKey: pubKeys[0], SequenceNumber: 7, Weight: 1000, Revoked: true},
+			},
+		}
+	}
+
+	t.Run("Only Matching Keys", func(t *testing.T) {
+		pool, err := flowkit.NewProposalKeyPool(newAccount(), 0, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, pool.Size())
+
+		a, _ := pool.Acquire()
+		b, _ := pool.Acquire()
+		assert.Equal(t, 0, a.Index)
+		assert.Equal(t, uint64(3), a.SequenceNumber)
+		assert.Equal(t, 1, b.Index)
+		assert.Equal(t, uint64(5), b.SequenceNumber)
+	})
+
+	t.Run("Track Sequence Numbers", func(t *testing.T) {
+		pool, _ := flowkit.NewProposalKeyPool(newAccount(), 2, nil)
+		assert.Equal(t, 1, pool.Size())
+
+		for i := 0; i < 3; i++ {
+			key, err := pool.Acquire()
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(1+i), key.SequenceNumber)
+			pool.Release(key, false)
+		}
+	})
+
+	t.Run("Resync After Failure", func(t *testing.T) {
+		fetched := 0
+		fetch := func(flow.Address) (*flow.Account, error) {
+			fetched++
+			return newAccount(), nil
+		}
+
+		pool, _ := flowkit.NewProposalKeyPool(newAccount(), 2, fetch)
+		key, _ := pool.Acquire()
+		pool.Release(key, false)
+		key, _ = pool.Acquire()
+		pool.Release(key, true)
+
+		key, err := pool.Acquire()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, fetched)
+		assert.Equal(t, uint64(1), key.SequenceNumber)
+	})
+
+	t.Run("Resync Error", func(t *testing.T) {
+		fetch := func(flow.Address) (*flow.Account, error) {
+			return nil, fmt.Errorf("network down")
+		}
+
+		pool, _ := flowkit.NewProposalKeyPool(newAccount(), 2, fetch)
+		key, _ := pool.Acquire()
+		pool.Release(key, true)
+
+		_, err := pool.Acquire()
+		assert.EqualError(t, err, "failed to resync proposal key 2: network down")
+	})
+
+	t.Run("Invalid Index", func(t *testing.T) {
+		_, err := flowkit.NewProposalKeyPool(newAccount(), 5, nil)
+		assert.EqualError(t, err, "key index 5 does not exist on account 0000000000000001")
+	})
+}
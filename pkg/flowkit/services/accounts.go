@@ -105,6 +105,43 @@ func (a *Accounts) StakingInfo(address flow.Address) (*cadence.Value, *cadence.V
 	return &stakingValue, &delegationValue, nil
 }
 
+// KeyVerification is the result of comparing a configured account key with the on-chain key.
+type KeyVerification struct {
+	Account    *flowkit.Account
+	Mismatches []flowkit.KeyMismatch
+	Err        error
+}
+
+// Valid returns true if the configured key matches the on-chain key.
+func (k *KeyVerification) Valid() bool {
+	return k.Err == nil && len(k.Mismatches) == 0
+}
+
+// VerifyKeys compares the keys of the provided accounts with the keys on the network.
+//
+// Every account is verified even if some of them fail, so all the mismatches are reported at once.
+func (a *Accounts) VerifyKeys(accounts []*flowkit.Account) []KeyVerification {
+	a.logger.StartProgress("Verifying account keys...")
+	defer a.logger.StopProgress()
+
+	verifications := make([]KeyVerification, 0, len(accounts))
+	for _, account := range accounts {
+		verification := KeyVerification{Account: account}
+
+		onChain, err := a.gateway.GetAccount(account.Address())
+		if err != nil {
+			verification.Err = fmt.Errorf("failed to get account %s: %w", account.Address(), err)
+			verifications = append(verifications, verification)
+			continue
+		}
+
+		verification.Mismatches, verification.Err = account.VerifyKey(onChain)
+		verifications = append(verifications, verification)
+	}
+
+	return verifications
+}
+
 // Create creates and returns a new account.
 //
 // The new account is created with the given public keys and contracts.
@@ -145,38 +182,91 @@ func (a *Accounts) Create(
 			Weight:    weight,
 		}
 
-		err := accKey.Validate()
-		if err != nil {
-			return nil, fmt.Errorf("invalid account key: %w", err)
-		}
-
 		accKeys = append(accKeys, accKey)
 	}
 
-	contracts := make([]templates.Contract, 0)
-	for _, contract := range contractArgs {
-		contractFlagContent := strings.SplitN(contract, ":", 2)
-		if len(contractFlagContent) != 2 {
-			return nil, fmt.Errorf("wrong format for contract. Correct format is name:path, but got: %s", contract)
-		}
+	return a.CreateWithAccountKeys(signer, accKeys, contractArgs)
+}
 
-		contractSource, err := a.state.ReadFile(contractFlagContent[1])
-		if err != nil {
-			return nil, err
-		}
+// CreateAndSave creates an account with a newly generated key and saves it with the name to the configuration.
+//
+// The private key is saved in the configuration, or to the key file if its location is provided.
+// Nothing is saved if creating the account fails.
+func (a *Accounts) CreateAndSave(
+	signer *flowkit.Account,
+	name string,
+	keyFile string,
+	weight int,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+	contractArgs []string,
+	configPaths []string,
+) (*flow.Account, error) {
+	if a.state == nil {
+		return nil, config.ErrDoesNotExist
+	}
 
-		contracts = append(contracts, templates.Contract{
-			Name:   contractFlagContent[0],
-			Source: string(contractSource),
-		})
+	if name == "" {
+		return nil, fmt.Errorf("name must be provided when saving the account")
+	}
+	if _, err := a.state.Accounts().ByName(name); err == nil {
+		return nil, fmt.Errorf("account with name %s already exists in the configuration", name)
 	}
 
-	tx, err := flowkit.NewCreateAccountTransaction(signer, accKeys, contracts)
+	seed, err := util.RandomSeed(crypto.MinSeedLength)
 	if err != nil {
 		return nil, err
 	}
 
-	tx, err = a.prepareTransaction(tx, signer)
+	privateKey, err := crypto.GeneratePrivateKey(sigAlgo, seed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate private key: %w", err)
+	}
+
+	account, err := a.Create(
+		signer,
+		[]crypto.PublicKey{privateKey.PublicKey()},
+		[]int{weight},
+		sigAlgo,
+		hashAlgo,
+		contractArgs,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var key flowkit.AccountKey = flowkit.NewHexAccountKeyFromPrivateKey(0, hashAlgo, privateKey)
+	if keyFile != "" {
+		err = a.state.ReaderWriter().WriteFile(keyFile, []byte(privateKey.String()), 0600)
+		if err != nil {
+			return nil, fmt.Errorf("account %s was created but saving the key file %s failed: %w", account.Address, keyFile, err)
+		}
+		key = flowkit.NewFileAccountKey(keyFile, 0, hashAlgo, privateKey)
+	}
+
+	saved := &flowkit.Account{}
+	saved.SetName(name)
+	saved.SetAddress(account.Address)
+	saved.SetKey(key)
+	a.state.Accounts().AddOrUpdate(saved)
+
+	err = a.state.SaveEdited(configPaths)
+	if err != nil {
+		return nil, fmt.Errorf("account %s was created but saving it to the configuration failed: %w", account.Address, err)
+	}
+
+	return account, nil
+}
+
+// CreateWithAccountKeys creates and returns a new account with the given account keys and contracts.
+//
+// Account keys can have different algorithms and weights, which allows using keys decoded from RLP.
+func (a *Accounts) CreateWithAccountKeys(
+	signer *flowkit.Account,
+	accKeys []*flow.AccountKey,
+	contractArgs []string,
+) (*flow.Account, error) {
+	tx, err := a.buildCreate(signer, accKeys, contractArgs)
 	if err != nil {
 		return nil, err
 	}
@@ -213,54 +303,81 @@ func (a *Accounts) Create(
 	return a.gateway.GetAccount(*newAccountAddress)
 }
 
-// AddContract deploys a contract code to the account provided with possible update flag.
-func (a *Accounts) AddContract(
-	account *flowkit.Account,
-	contractName string,
-	contractSource []byte,
-	updateExisting bool,
-) (*flow.Account, error) {
-	tx, err := flowkit.NewAddAccountContractTransaction(
-		account,
-		contractName,
-		string(contractSource),
-		[]cadence.Value{}, // TODO(sideninja) add support for args on account add-contract
-	)
+// SubmitCreate sends the transaction creating a new account with the given account keys and contracts
+// and returns the sent transaction without waiting for the result.
+func (a *Accounts) SubmitCreate(
+	signer *flowkit.Account,
+	accKeys []*flow.AccountKey,
+	contractArgs []string,
+) (*flow.Transaction, error) {
+	tx, err := a.buildCreate(signer, accKeys, contractArgs)
 	if err != nil {
 		return nil, err
 	}
 
-	// if we are updating contract
-	if updateExisting {
-		tx, err = flowkit.NewUpdateAccountContractTransaction(
-			account,
-			contractName,
-			string(contractSource),
-		)
+	a.logger.StartProgress("Creating account...")
+	defer a.logger.StopProgress()
+
+	return a.gateway.SendSignedTransaction(tx)
+}
+
+// buildCreate builds and signs the transaction creating a new account with the given account keys and contracts.
+func (a *Accounts) buildCreate(
+	signer *flowkit.Account,
+	accKeys []*flow.AccountKey,
+	contractArgs []string,
+) (*flowkit.Transaction, error) {
+	if a.state == nil {
+		return nil, config.ErrDoesNotExist
+	}
+
+	for _, accKey := range accKeys {
+		err := accKey.Validate()
+		if err != nil {
+			return nil, fmt.Errorf("invalid account key: %w", err)
+		}
+	}
+
+	contracts := make([]templates.Contract, 0)
+	for _, contract := range contractArgs {
+		contractFlagContent := strings.SplitN(contract, ":", 2)
+		if len(contractFlagContent) != 2 {
+			return nil, fmt.Errorf("wrong format for contract. Correct format is name:path, but got: %s", contract)
+		}
+
+		contractSource, err := a.state.ReadFile(contractFlagContent[1])
 		if err != nil {
 			return nil, err
 		}
+
+		contracts = append(contracts, templates.Contract{
+			Name:   contractFlagContent[0],
+			Source: string(contractSource),
+		})
 	}
 
-	tx, err = a.prepareTransaction(tx, account)
+	tx, err := flowkit.NewCreateAccountTransaction(signer, accKeys, contracts)
 	if err != nil {
 		return nil, err
 	}
 
-	a.logger.Info(fmt.Sprintf("Transaction ID: %s", tx.FlowTransaction().ID()))
+	return a.prepareTransaction(tx, signer)
+}
 
-	status := "Adding contract '%s' to account '%s'..."
-	if updateExisting {
-		status = "Updating contract '%s' on account '%s'..."
+// AddContract deploys a contract code to the account provided with possible update flag.
+func (a *Accounts) AddContract(
+	account *flowkit.Account,
+	contractName string,
+	contractSource []byte,
+	updateExisting bool,
+) (*flow.Account, error) {
+	tx, err := a.buildAddContract(account, contractName, contractSource, updateExisting)
+	if err != nil {
+		return nil, err
 	}
 
-	a.logger.StartProgress(
-		fmt.Sprintf(
-			status,
-			contractName,
-			account.Address(),
-		),
-	)
+	a.logger.Info(fmt.Sprintf("Transaction ID: %s", tx.FlowTransaction().ID()))
+	a.logger.StartProgress(addContractStatus(account, contractName, updateExisting))
 	defer a.logger.StopProgress()
 
 	// send transaction with contract
@@ -301,17 +418,73 @@ func (a *Accounts) AddContract(
 	return update, err
 }
 
-// RemoveContract removes a contract from an account and returns the updated account.
-func (a *Accounts) RemoveContract(
+// SubmitAddContract sends the transaction deploying a contract code to the account provided with possible update flag
+// and returns the sent transaction without waiting for the result.
+func (a *Accounts) SubmitAddContract(
 	account *flowkit.Account,
 	contractName string,
-) (*flow.Account, error) {
-	tx, err := flowkit.NewRemoveAccountContractTransaction(account, contractName)
+	contractSource []byte,
+	updateExisting bool,
+) (*flow.Transaction, error) {
+	tx, err := a.buildAddContract(account, contractName, contractSource, updateExisting)
+	if err != nil {
+		return nil, err
+	}
+
+	a.logger.StartProgress(addContractStatus(account, contractName, updateExisting))
+	defer a.logger.StopProgress()
+
+	return a.gateway.SendSignedTransaction(tx)
+}
+
+// addContractStatus returns the progress status for adding or updating a contract.
+func addContractStatus(account *flowkit.Account, contractName string, updateExisting bool) string {
+	status := "Adding contract '%s' to account '%s'..."
+	if updateExisting {
+		status = "Updating contract '%s' on account '%s'..."
+	}
+
+	return fmt.Sprintf(status, contractName, account.Address())
+}
+
+// buildAddContract builds and signs the transaction deploying a contract code to the account.
+func (a *Accounts) buildAddContract(
+	account *flowkit.Account,
+	contractName string,
+	contractSource []byte,
+	updateExisting bool,
+) (*flowkit.Transaction, error) {
+	tx, err := flowkit.NewAddAccountContractTransaction(
+		account,
+		contractName,
+		string(contractSource),
+		[]cadence.Value{}, // TODO(sideninja) add support for args on account add-contract
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	tx, err = a.prepareTransaction(tx, account)
+	// if we are updating contract
+	if updateExisting {
+		tx, err = flowkit.NewUpdateAccountContractTransaction(
+			account,
+			contractName,
+			string(contractSource),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return a.prepareTransaction(tx, account)
+}
+
+// RemoveContract removes a contract from an account and returns the updated account.
+func (a *Accounts) RemoveContract(
+	account *flowkit.Account,
+	contractName string,
+) (*flow.Account, error) {
+	tx, err := a.buildRemoveContract(account, contractName)
 	if err != nil {
 		return nil, err
 	}
@@ -346,6 +519,38 @@ func (a *Accounts) RemoveContract(
 	return a.gateway.GetAccount(account.Address())
 }
 
+// SubmitRemoveContract sends the transaction removing a contract from an account
+// and returns the sent transaction without waiting for the result.
+func (a *Accounts) SubmitRemoveContract(
+	account *flowkit.Account,
+	contractName string,
+) (*flow.Transaction, error) {
+	tx, err := a.buildRemoveContract(account, contractName)
+	if err != nil {
+		return nil, err
+	}
+
+	a.logger.StartProgress(
+		fmt.Sprintf("Removing Contract %s from %s...", contractName, account.Address()),
+	)
+	defer a.logger.StopProgress()
+
+	return a.gateway.SendSignedTransaction(tx)
+}
+
+// buildRemoveContract builds and signs the transaction removing a contract from an account.
+func (a *Accounts) buildRemoveContract(
+	account *flowkit.Account,
+	contractName string,
+) (*flowkit.Transaction, error) {
+	tx, err := flowkit.NewRemoveAccountContractTransaction(account, contractName)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.prepareTransaction(tx, account)
+}
+
 // prepareTransaction prepares transaction for sending with data from network
 func (a *Accounts) prepareTransaction(
 	tx *flowkit.Transaction,
@@ -365,6 +570,15 @@ func (a *Accounts) prepareTransaction(
 	tx.SetBlockReference(block).
 		SetProposer(proposer, account.Key().Index())
 
+	err = tx.SetSigner(account)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.state != nil {
+		tx.SetAuditLog(a.state.AuditLog())
+	}
+
 	tx, err = tx.Sign()
 	if err != nil {
 		return nil, err
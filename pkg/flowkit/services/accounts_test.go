@@ -19,6 +19,7 @@
 package services
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -32,6 +33,7 @@ import (
 	"github.com/onflow/flow-cli/pkg/flowkit/output"
 
 	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
 
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
@@ -50,6 +52,13 @@ func setup() (*flowkit.State, *Services, *tests.TestGateway) {
 	gw := tests.DefaultMockGateway()
 	s := NewServices(gw.Mock, state, output.NewStdoutLogger(output.NoneLog))
 
+	// accounts on the mocked network use the emulator service key so signers match the on-chain key
+	serviceAcc, _ := state.EmulatorServiceAccount()
+	gw.GetAccount.Run(func(args mock.Arguments) {
+		addr := args.Get(0).(flow.Address)
+		gw.GetAccount.Return(tests.NewAccountWithKey(addr.String(), serviceAcc.Key()), nil)
+	})
+
 	return state, s, gw
 }
 
@@ -69,13 +78,14 @@ func TestAccounts(t *testing.T) {
 	})
 
 	t.Run("Create an Account", func(t *testing.T) {
-		_, s, gw := setup()
+		state, s, gw := setup()
+		serviceAcc, _ := state.EmulatorServiceAccount()
 		newAddress := flow.HexToAddress("192440c99cb17282")
 
 		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
 			tx := args.Get(0).(*flowkit.Transaction)
 			assert.Equal(t, tx.FlowTransaction().Authorizers[0], serviceAddress)
-			assert.Equal(t, tx.Signer().Address(), serviceAddress)
+			assert.Equal(t, tx.Signers()[0].Address(), serviceAddress)
 
 			gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
 		})
@@ -86,7 +96,7 @@ func TestAccounts(t *testing.T) {
 			assert.Equal(t, address, compareAddress)
 			compareAddress = newAddress
 			gw.GetAccount.Return(
-				tests.NewAccountWithAddress(address.String()), nil,
+				tests.NewAccountWithKey(address.String(), serviceAcc.Key()), nil,
 			)
 		})
 
@@ -114,13 +124,14 @@ func TestAccounts(t *testing.T) {
 	})
 
 	t.Run("Create an Account with Contract", func(t *testing.T) {
-		_, s, gw := setup()
+		state, s, gw := setup()
+		serviceAcc, _ := state.EmulatorServiceAccount()
 		newAddress := flow.HexToAddress("192440c99cb17281")
 
 		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
 			tx := args.Get(0).(*flowkit.Transaction)
 			assert.Equal(t, tx.FlowTransaction().Authorizers[0], serviceAddress)
-			assert.Equal(t, tx.Signer().Address(), serviceAddress)
+			assert.Equal(t, tx.Signers()[0].Address(), serviceAddress)
 			assert.True(t, strings.Contains(string(tx.FlowTransaction().Script), "acct.contracts.add"))
 
 			gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
@@ -150,10 +161,11 @@ func TestAccounts(t *testing.T) {
 	})
 
 	t.Run("Contract Add for Account", func(t *testing.T) {
-		_, s, gw := setup()
+		state, s, gw := setup()
+		serviceAcc, _ := state.EmulatorServiceAccount()
 		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
 			tx := args.Get(0).(*flowkit.Transaction)
-			assert.Equal(t, tx.Signer().Address(), serviceAddress)
+			assert.Equal(t, tx.Signers()[0].Address(), serviceAddress)
 			assert.True(t, strings.Contains(string(tx.FlowTransaction().Script), "signer.contracts.add"))
 
 			gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
@@ -175,10 +187,11 @@ func TestAccounts(t *testing.T) {
 	})
 
 	t.Run("Contract Update for Account", func(t *testing.T) {
-		_, s, gw := setup()
+		state, s, gw := setup()
+		serviceAcc, _ := state.EmulatorServiceAccount()
 		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
 			tx := args.Get(0).(*flowkit.Transaction)
-			assert.Equal(t, tx.Signer().Address(), serviceAddress)
+			assert.Equal(t, tx.Signers()[0].Address(), serviceAddress)
 			assert.True(t, strings.Contains(string(tx.FlowTransaction().Script), "signer.contracts.update__experimental"))
 
 			gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
@@ -200,10 +213,11 @@ func TestAccounts(t *testing.T) {
 	})
 
 	t.Run("Contract Remove for Account", func(t *testing.T) {
-		_, s, gw := setup()
+		state, s, gw := setup()
+		serviceAcc, _ := state.EmulatorServiceAccount()
 		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
 			tx := args.Get(0).(*flowkit.Transaction)
-			assert.Equal(t, tx.Signer().Address(), serviceAddress)
+			assert.Equal(t, tx.Signers()[0].Address(), serviceAddress)
 			assert.True(t, strings.Contains(string(tx.FlowTransaction().Script), "signer.contracts.remove"))
 
 			gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
@@ -511,6 +525,105 @@ func TestAccountsRemoveContract_Integration(t *testing.T) {
 	})
 }
 
+func TestAccountsCreateWithAccountKeys_Integration(t *testing.T) {
+	t.Parallel()
+
+	state, s := setupIntegration()
+	srvAcc, _ := state.EmulatorServiceAccount()
+
+	rlpKey, _ := s.Keys.DecodeRLP("f847b84084d716c14b051ad6b001624f738f5d302636e6b07cc75e4530af7776a4368a2b586dbefc0564ee28384c2696f178cbed52e62811bcc9ecb59568c996d342db2402038203e8")
+	secpKey, _ := crypto.GeneratePrivateKey(crypto.ECDSA_secp256k1, []byte("seedseedseedseedseedseedseedseedseedseedseedseedseed"))
+
+	keys := []*flow.AccountKey{rlpKey, {
+		PublicKey: secpKey.PublicKey(),
+		SigAlgo:   crypto.ECDSA_secp256k1,
+		HashAlgo:  crypto.SHA2_256,
+		Weight:    500,
+	}}
+
+	acc, err := s.Accounts.CreateWithAccountKeys(srvAcc, keys, nil)
+	assert.NoError(t, err)
+	assert.Len(t, acc.Keys, 2)
+
+	for i, k := range acc.Keys {
+		assert.True(t, k.PublicKey.Equals(keys[i].PublicKey))
+		assert.Equal(t, keys[i].SigAlgo, k.SigAlgo)
+		assert.Equal(t, keys[i].HashAlgo, k.HashAlgo)
+		assert.Equal(t, keys[i].Weight, k.Weight)
+	}
+}
+
+func TestAccountsCreateAndSave_Integration(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Save Hex Key", func(t *testing.T) {
+		t.Parallel()
+		state, s := setupIntegration()
+		srvAcc, _ := state.EmulatorServiceAccount()
+
+		acc, err := s.Accounts.CreateAndSave(
+			srvAcc, "Alice", "", 1000, crypto.ECDSA_P256, crypto.SHA3_256, nil, []string{"flow.json"},
+		)
+		assert.NoError(t, err)
+
+		loaded, err := flowkit.Load([]string{"flow.json"}, state.ReaderWriter())
+		assert.NoError(t, err)
+		alice, err := loaded.Accounts().ByName("Alice")
+		assert.NoError(t, err)
+		assert.Equal(t, acc.Address, alice.Address())
+		assert.Equal(t, config.KeyTypeHex, alice.Key().Type())
+
+		_, err = s.Accounts.CreateAndSave(
+			srvAcc, "Alice", "", 1000, crypto.ECDSA_P256, crypto.SHA3_256, nil, []string{"flow.json"},
+		)
+		assert.EqualError(t, err, "account with name Alice already exists in the configuration")
+	})
+
+	t.Run("Save Key File", func(t *testing.T) {
+		t.Parallel()
+		state, s := setupIntegration()
+		srvAcc, _ := state.EmulatorServiceAccount()
+
+		acc, err := s.Accounts.CreateAndSave(
+			srvAcc, "Bob", "bob.pkey", 1000, crypto.ECDSA_secp256k1, crypto.SHA2_256, nil, []string{"flow.json"},
+		)
+		assert.NoError(t, err)
+
+		saved, err := state.ReaderWriter().ReadFile("flow.json")
+		assert.NoError(t, err)
+		assert.Contains(t, string(saved), `"location": "bob.pkey"`)
+		assert.NotContains(t, string(saved), `"privateKey"`)
+
+		loaded, err := flowkit.Load([]string{"flow.json"}, state.ReaderWriter())
+		assert.NoError(t, err)
+		bob, err := loaded.Accounts().ByName("Bob")
+		assert.NoError(t, err)
+		assert.Equal(t, acc.Address, bob.Address())
+
+		// the saved key can sign for the new account
+		verifications := s.Accounts.VerifyKeys([]*flowkit.Account{bob})
+		assert.NoError(t, verifications[0].Err)
+		assert.True(t, verifications[0].Valid())
+	})
+
+	t.Run("Nothing Saved On Failure", func(t *testing.T) {
+		t.Parallel()
+		state, s := setupIntegration()
+
+		_, err := s.Accounts.CreateAndSave(
+			tests.Alice(), "Charlie", "charlie.pkey", 1000, crypto.ECDSA_P256, crypto.SHA3_256, nil, []string{"flow.json"},
+		)
+		assert.Error(t, err)
+
+		_, err = state.Accounts().ByName("Charlie")
+		assert.Error(t, err)
+		_, err = state.ReaderWriter().ReadFile("charlie.pkey")
+		assert.Error(t, err)
+		_, err = state.ReaderWriter().ReadFile("flow.json")
+		assert.Error(t, err)
+	})
+}
+
 func TestAccountsGet_Integration(t *testing.T) {
 	t.Parallel()
 
@@ -545,3 +658,78 @@ func TestAccountsStakingInfo_Integration(t *testing.T) {
 		assert.Equal(t, err.Error(), "emulator chain not supported")
 	})
 }
+
+func TestAccountsVerifyKeys_Integration(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Verify Keys", func(t *testing.T) {
+		t.Parallel()
+		state, s := setupIntegration()
+		srvAcc, _ := state.EmulatorServiceAccount()
+		setupAccount(state, s, tests.Alice())
+		alice, _ := state.Accounts().ByName(tests.Alice().Name())
+
+		verifications := s.Accounts.VerifyKeys([]*flowkit.Account{srvAcc, alice})
+
+		assert.Len(t, verifications, 2)
+		for _, v := range verifications {
+			assert.NoError(t, v.Err)
+			assert.Empty(t, v.Mismatches)
+			assert.True(t, v.Valid())
+		}
+	})
+
+	t.Run("Verify Keys Mismatch", func(t *testing.T) {
+		t.Parallel()
+		state, s := setupIntegration()
+		setupAccount(state, s, tests.Alice())
+		alice, _ := state.Accounts().ByName(tests.Alice().Name())
+
+		// configure alice with the key of bob
+		wrong := &flowkit.Account{}
+		wrong.SetName(alice.Name())
+		wrong.SetAddress(alice.Address())
+		wrong.SetKey(tests.Bob().Key())
+
+		verifications := s.Accounts.VerifyKeys([]*flowkit.Account{wrong})
+
+		assert.Len(t, verifications, 1)
+		assert.NoError(t, verifications[0].Err)
+		assert.False(t, verifications[0].Valid())
+		assert.Len(t, verifications[0].Mismatches, 1)
+		assert.Equal(t, flowkit.KeyFieldPublicKey, verifications[0].Mismatches[0].Field)
+
+		_, _, err := s.Transactions.Send(wrong, tests.TransactionSimple.Source, "", gasLimit, nil, "")
+		var mismatchErr *flowkit.KeyMismatchError
+		assert.True(t, errors.As(err, &mismatchErr))
+		assert.Equal(t, alice.Address(), mismatchErr.Address)
+
+		// keys of signers other than the proposer are verified too
+		srvAcc, _ := state.EmulatorServiceAccount()
+		_, _, err = s.Transactions.SendWithRoles(
+			&flowkit.TransactionAccountRoles{
+				Proposer:    srvAcc,
+				Authorizers: []*flowkit.Account{srvAcc},
+				Payer:       wrong,
+			},
+			tests.TransactionSimple.Source,
+			"",
+			gasLimit,
+			nil,
+			"",
+		)
+		assert.True(t, errors.As(err, &mismatchErr))
+		assert.Equal(t, alice.Address(), mismatchErr.Address)
+	})
+
+	t.Run("Verify Keys Missing Account", func(t *testing.T) {
+		t.Parallel()
+		_, s := setupIntegration()
+
+		verifications := s.Accounts.VerifyKeys([]*flowkit.Account{tests.Bob()})
+
+		assert.Len(t, verifications, 1)
+		assert.Error(t, verifications[0].Err)
+		assert.False(t, verifications[0].Valid())
+	})
+}
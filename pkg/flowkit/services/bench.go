@@ -0,0 +1,340 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+)
+
+// benchPollInterval is the interval between requests for the result of a benchmark transaction.
+const benchPollInterval = 100 * time.Millisecond
+
+// BenchSample is the outcome of a single transaction sent in a benchmark.
+//
+// Submit latency is the time it took to send the transaction and seal latency is the time
+// from sending the transaction until its sealed result was received.
+type BenchSample struct {
+	Tx            *flow.Transaction
+	Result        *flow.TransactionResult
+	Err           error
+	SubmitLatency time.Duration
+	SealLatency   time.Duration
+}
+
+// Failed returns true if the transaction wasn't sealed or its execution failed.
+func (s *BenchSample) Failed() bool {
+	return s.Err != nil ||
+		s.Result == nil ||
+		s.Result.Error != nil ||
+		s.Result.Status != flow.TransactionStatusSealed
+}
+
+// BenchResult contains the samples of all the transactions sent in a benchmark.
+type BenchResult struct {
+	Samples      []BenchSample
+	Duration     time.Duration
+	ProposalKeys int
+}
+
+// Failed returns the number of failed transactions.
+func (r *BenchResult) Failed() int {
+	failed := 0
+	for _, s := range r.Samples {
+		if s.Failed() {
+			failed++
+		}
+	}
+
+	return failed
+}
+
+// Throughput returns the number of successfully sealed transactions per second.
+func (r *BenchResult) Throughput() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+
+	return float64(len(r.Samples)-r.Failed()) / r.Duration.Seconds()
+}
+
+// SubmitLatencies returns the sorted submit latencies of all the submitted transactions.
+func (r *BenchResult) SubmitLatencies() []time.Duration {
+	latencies := make([]time.Duration, 0, len(r.Samples))
+	for _, s := range r.Samples {
+		if s.Tx != nil {
+			latencies = append(latencies, s.SubmitLatency)
+		}
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	return latencies
+}
+
+// SealLatencies returns the sorted seal latencies of all the sealed transactions.
+func (r *BenchResult) SealLatencies() []time.Duration {
+	latencies := make([]time.Duration, 0, len(r.Samples))
+	for _, s := range r.Samples {
+		if s.Result != nil && s.Result.Status == flow.TransactionStatusSealed {
+			latencies = append(latencies, s.SealLatency)
+		}
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	return latencies
+}
+
+// Failures returns the number of failed transactions by the reason of the failure.
+//
+// Execution errors are grouped by the error code and other errors by the first line of the error.
+func (r *BenchResult) Failures() map[string]int {
+	failures := make(map[string]int)
+	for _, s := range r.Samples {
+		if !s.Failed() {
+			continue
+		}
+
+		var reason string
+		switch {
+		case s.Err != nil:
+			reason = strings.SplitN(s.Err.Error(), "\n", 2)[0]
+		case s.Result == nil:
+			reason = "missing transaction result"
+		case s.Result.Error == nil:
+			reason = fmt.Sprintf("transaction %s", strings.ToLower(s.Result.Status.String()))
+		case flowkit.ExecutionErrorCode(s.Result.Error) != 0:
+			reason = fmt.Sprintf("execution error code %d", flowkit.ExecutionErrorCode(s.Result.Error))
+		default:
+			reason = strings.SplitN(s.Result.Error.Error(), "\n", 2)[0]
+		}
+
+		failures[reason]++
+	}
+
+	return failures
+}
+
+// LatencyPercentile returns the percentile of the sorted latencies using the nearest rank method.
+func LatencyPercentile(latencies []time.Duration, percentile float64) time.Duration {
+	if len(latencies) == 0 {
+		return 0
+	}
+
+	rank := int(math.Ceil(percentile / 100 * float64(len(latencies))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(latencies) {
+		rank = len(latencies)
+	}
+
+	return latencies[rank-1]
+}
+
+// Bench sends the transaction code count times and measures the latency of each transaction.
+//
+// Transactions are signed by the signers in turns, each using distinct proposal keys of the signer account
+// matching the configured key. At most concurrency transactions are in flight at the same time and if the rate
+// is greater than zero transactions are started at most at that rate per second. Transactions not sealed
+// within the timeout are counted as failed.
+func (t *Transactions) Bench(
+	signers []*flowkit.Account,
+	code []byte,
+	codeFilename string,
+	gasLimit uint64,
+	args []cadence.Value,
+	network string,
+	count int,
+	concurrency int,
+	rate float64,
+	timeout time.Duration,
+) (*BenchResult, error) {
+	if t.state == nil {
+		return nil, fmt.Errorf("missing configuration, initialize it: flow state init")
+	}
+
+	if len(signers) == 0 {
+		return nil, fmt.Errorf("at least one signer is required")
+	}
+	if count < 1 {
+		return nil, fmt.Errorf("number of transactions to send must be greater than zero")
+	}
+	if concurrency < 1 {
+		return nil, fmt.Errorf("concurrency must be greater than zero")
+	}
+	if rate < 0 {
+		return nil, fmt.Errorf("rate can not be negative")
+	}
+	if timeout <= 0 {
+		return nil, fmt.Errorf("timeout must be greater than zero")
+	}
+
+	code, sourceMap, err := t.resolveImports(code, codeFilename, network)
+	if err != nil {
+		return nil, err
+	}
+
+	args, err = resolveArgumentTypes(t.state, args, codeFilename, network)
+	if err != nil {
+		return nil, err
+	}
+
+	pools := make([]*flowkit.ProposalKeyPool, 0, len(signers))
+	poolSigners := make([]map[int]*flowkit.Account, 0, len(signers))
+	proposalKeys := 0
+	for _, signer := range signers {
+		account, err := t.gateway.GetAccount(signer.Address())
+		if err != nil {
+			return nil, err
+		}
+
+		pool, err := flowkit.NewProposalKeyPool(account, signer.Key().Index(), t.gateway.GetAccount)
+		if err != nil {
+			return nil, err
+		}
+
+		keySigners, err := keySigners(signer, account)
+		if err != nil {
+			return nil, err
+		}
+
+		pools = append(pools, pool)
+		poolSigners = append(poolSigners, keySigners)
+		proposalKeys += pool.Size()
+	}
+
+	policy, err := t.loadPolicy(network)
+	if err != nil {
+		return nil, err
+	}
+
+	referenceBlock := t.referenceBlocks()
+
+	t.logger.StartProgress(fmt.Sprintf(
+		"Sending %d transactions using %d signers with %d proposal keys...", count, len(signers), proposalKeys,
+	))
+	defer t.logger.StopProgress()
+
+	var ticker *time.Ticker
+	if rate > 0 {
+		ticker = time.NewTicker(time.Duration(float64(time.Second) / rate))
+		defer ticker.Stop()
+	}
+
+	start := time.Now()
+	samples := make([]BenchSample, count)
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		if ticker != nil && i > 0 {
+			<-ticker.C
+		}
+		slots <- struct{}{}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			pool := pools[i%len(pools)]
+			key, err := pool.Acquire()
+			if err != nil {
+				samples[i] = BenchSample{Err: err}
+				return
+			}
+
+			sample := t.benchTransaction(poolSigners[i%len(pools)][key.Index], key, referenceBlock, code, gasLimit, args, policy, timeout)
+			pool.Release(key, sample.Failed())
+			if sample.Result != nil {
+				mapExecutionError(sample.Result, sourceMap)
+			}
+			samples[i] = sample
+		}(i)
+	}
+	wg.Wait()
+
+	return &BenchResult{
+		Samples:      samples,
+		Duration:     time.Since(start),
+		ProposalKeys: proposalKeys,
+	}, nil
+}
+
+// benchTransaction sends a single benchmark transaction and measures the submit and seal latency.
+//
+// Waiting for the transaction to be sealed stops when the timeout expires, in which case the sample has an error.
+func (t *Transactions) benchTransaction(
+	signer *flowkit.Account,
+	key *flowkit.ProposalKey,
+	referenceBlock func() (*flow.Block, error),
+	code []byte,
+	gasLimit uint64,
+	args []cadence.Value,
+	policy *flowkit.Policy,
+	timeout time.Duration,
+) BenchSample {
+	block, err := referenceBlock()
+	if err != nil {
+		return BenchSample{Err: err}
+	}
+
+	signed, err := t.signWithProposalKey(signer, key, block, code, gasLimit, args, policy)
+	if err != nil {
+		return BenchSample{Err: err}
+	}
+
+	submitted := time.Now()
+	sentTx, err := t.gateway.SendSignedTransaction(signed)
+	sample := BenchSample{SubmitLatency: time.Since(submitted)}
+	if err != nil {
+		sample.Err = err
+		return sample
+	}
+	sample.Tx = sentTx
+
+	// poll for the result instead of waiting using the gateway to measure the seal latency more precisely
+	deadline := submitted.Add(timeout)
+	for {
+		sample.Result, sample.Err = t.gateway.GetTransactionResult(sentTx, false)
+		if sample.Err != nil || sample.Result.Status >= flow.TransactionStatusSealed {
+			break
+		}
+
+		if time.Now().Add(benchPollInterval).After(deadline) {
+			sample.Err = fmt.Errorf(
+				"timeout waiting for transaction to be sealed, last status is %s",
+				strings.ToLower(sample.Result.Status.String()),
+			)
+			break
+		}
+		time.Sleep(benchPollInterval)
+	}
+	sample.SealLatency = time.Since(submitted)
+
+	return sample
+}
@@ -0,0 +1,136 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/tests"
+)
+
+func TestTransactionsBench(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Bench Timeout", func(t *testing.T) {
+		t.Parallel()
+		state, s, gw := setup()
+		srvAcc, _ := state.EmulatorServiceAccount()
+
+		gw.GetTransactionResult.Return(&flow.TransactionResult{Status: flow.TransactionStatusPending}, nil)
+
+		result, err := s.Transactions.Bench(
+			[]*flowkit.Account{srvAcc},
+			tests.TransactionSimple.Source,
+			tests.TransactionSimple.Filename,
+			gasLimit,
+			nil,
+			"",
+			2,
+			1,
+			0,
+			300*time.Millisecond,
+		)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 2, result.Failed())
+		assert.Empty(t, result.SealLatencies())
+		assert.Equal(t, map[string]int{"timeout waiting for transaction to be sealed, last status is pending": 2}, result.Failures())
+	})
+}
+
+func TestTransactionsBench_Integration(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Bench", func(t *testing.T) {
+		t.Parallel()
+		state, s := setupIntegration()
+		setupAccounts(state, s)
+
+		a, _ := state.Accounts().ByName("Alice")
+		b, _ := state.Accounts().ByName("Bob")
+
+		result, err := s.Transactions.Bench(
+			[]*flowkit.Account{a, b},
+			tests.TransactionArgString.Source,
+			tests.TransactionArgString.Filename,
+			gasLimit,
+			[]cadence.Value{cadence.NewString("Foo")},
+			"",
+			6,
+			3,
+			0,
+			time.Minute,
+		)
+		assert.NoError(t, err)
+
+		assert.Len(t, result.Samples, 6)
+		assert.Equal(t, 0, result.Failed())
+		assert.Equal(t, 2, result.ProposalKeys)
+		assert.Len(t, result.SubmitLatencies(), 6)
+		assert.Len(t, result.SealLatencies(), 6)
+		assert.Greater(t, result.Throughput(), float64(0))
+		assert.Empty(t, result.Failures())
+		assert.Equal(t, a.Address(), result.Samples[0].Tx.Payer)
+		assert.Equal(t, b.Address(), result.Samples[1].Tx.Payer)
+	})
+
+	t.Run("Bench Failing", func(t *testing.T) {
+		t.Parallel()
+		state, s := setupIntegration()
+		srvAcc, _ := state.EmulatorServiceAccount()
+
+		result, err := s.Transactions.Bench(
+			[]*flowkit.Account{srvAcc},
+			tests.TransactionSimple.Source,
+			tests.TransactionSimple.Filename,
+			gasLimit,
+			nil,
+			"",
+			3,
+			1,
+			100,
+			time.Minute,
+		)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 3, result.Failed())
+		assert.Len(t, result.SealLatencies(), 3)
+		assert.Equal(t, float64(0), result.Throughput())
+		assert.Equal(t, map[string]int{"execution error code 1101": 3}, result.Failures())
+	})
+}
+
+func TestLatencyPercentile(t *testing.T) {
+	latencies := make([]time.Duration, 0, 100)
+	for i := 1; i <= 100; i++ {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	assert.Equal(t, 50*time.Millisecond, LatencyPercentile(latencies, 50))
+	assert.Equal(t, 99*time.Millisecond, LatencyPercentile(latencies, 99))
+	assert.Equal(t, 100*time.Millisecond, LatencyPercentile(latencies, 100))
+	assert.Equal(t, time.Millisecond, LatencyPercentile(latencies, 0))
+	assert.Equal(t, time.Duration(0), LatencyPercentile(nil, 50))
+}
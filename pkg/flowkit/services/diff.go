@@ -0,0 +1,300 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/gateway"
+)
+
+// accountSnapshotScript returns the balance and the storage used by the account.
+const accountSnapshotScript = `
+pub fun main(address: Address): [AnyStruct] {
+	let account = getAccount(address)
+	return [account.balance, account.storageUsed]
+}`
+
+// AccountSnapshot is the state of an account at a point in time.
+type AccountSnapshot struct {
+	Address     flow.Address
+	Exists      bool
+	Balance     uint64
+	StorageUsed uint64
+	Contracts   map[string]string // contract name to the hash of its code
+	Keys        []*flow.AccountKey
+	Paths       []string // nil if the gateway can't list the stored paths
+}
+
+// AccountDiff is the change of an account between two snapshots.
+type AccountDiff struct {
+	Address           flow.Address
+	Created           bool
+	BalanceBefore     uint64
+	BalanceAfter      uint64
+	StorageUsedBefore uint64
+	StorageUsedAfter  uint64
+	ContractsAdded    []string
+	ContractsUpdated  []string
+	ContractsRemoved  []string
+	KeysAdded         []*flow.AccountKey
+	KeysRevoked       []int
+	PathsAvailable    bool
+	PathsAdded        []string
+	PathsRemoved      []string
+}
+
+// NewAccountDiff compares the snapshots of an account taken before and after a change.
+func NewAccountDiff(before *AccountSnapshot, after *AccountSnapshot) *AccountDiff {
+	diff := &AccountDiff{
+		Address:           after.Address,
+		Created:           !before.Exists && after.Exists,
+		BalanceBefore:     before.Balance,
+		BalanceAfter:      after.Balance,
+		StorageUsedBefore: before.StorageUsed,
+		StorageUsedAfter:  after.StorageUsed,
+		PathsAvailable:    after.Paths != nil,
+	}
+
+	for name, hash := range after.Contracts {
+		beforeHash, ok := before.Contracts[name]
+		if !ok {
+			diff.ContractsAdded = append(diff.ContractsAdded, name)
+		} else if beforeHash != hash {
+			diff.ContractsUpdated = append(diff.ContractsUpdated, name)
+		}
+	}
+	for name := range before.Contracts {
+		if _, ok := after.Contracts[name]; !ok {
+			diff.ContractsRemoved = append(diff.ContractsRemoved, name)
+		}
+	}
+	sort.Strings(diff.ContractsAdded)
+	sort.Strings(diff.ContractsUpdated)
+	sort.Strings(diff.ContractsRemoved)
+
+	// keys can't be removed from an account, only added or revoked
+	beforeKeys := make(map[int]*flow.AccountKey)
+	for _, key := range before.Keys {
+		beforeKeys[key.Index] = key
+	}
+	for _, key := range after.Keys {
+		beforeKey, ok := beforeKeys[key.Index]
+		if !ok {
+			diff.KeysAdded = append(diff.KeysAdded, key)
+		} else if key.Revoked && !beforeKey.Revoked {
+			diff.KeysRevoked = append(diff.KeysRevoked, key.Index)
+		}
+	}
+
+	if diff.PathsAvailable {
+		diff.PathsAdded = difference(after.Paths, before.Paths)
+		diff.PathsRemoved = difference(before.Paths, after.Paths)
+	}
+
+	return diff
+}
+
+// Changed returns true if anything changed on the account.
+func (d *AccountDiff) Changed() bool {
+	return d.Created ||
+		d.BalanceBefore != d.BalanceAfter ||
+		d.StorageUsedBefore != d.StorageUsedAfter ||
+		len(d.ContractsAdded)+len(d.ContractsUpdated)+len(d.ContractsRemoved) > 0 ||
+		len(d.KeysAdded)+len(d.KeysRevoked) > 0 ||
+		len(d.PathsAdded)+len(d.PathsRemoved) > 0
+}
+
+// difference returns the sorted values of a missing in b.
+func difference(a []string, b []string) []string {
+	present := make(map[string]bool)
+	for _, value := range b {
+		present[value] = true
+	}
+
+	var values []string
+	for _, value := range a {
+		if !present[value] {
+			values = append(values, value)
+		}
+	}
+	sort.Strings(values)
+
+	return values
+}
+
+// SendWithDiff sends a transaction code like SendWithRoles and compares the accounts touched by the transaction
+// before and after its execution.
+//
+// The touched accounts are the proposer, payer, authorizers, accounts passed as address arguments and accounts
+// created by the transaction. Stored paths are only compared if the gateway can inspect the account storage.
+func (t *Transactions) SendWithDiff(
+	accounts *flowkit.TransactionAccountRoles,
+	code []byte,
+	codeFilename string,
+	gasLimit uint64,
+	args []cadence.Value,
+	network string,
+) (*flow.Transaction, *flow.TransactionResult, []*AccountDiff, error) {
+	addresses := []flow.Address{accounts.Proposer.Address(), accounts.Payer.Address()}
+	for _, authorizer := range accounts.Authorizers {
+		addresses = append(addresses, authorizer.Address())
+	}
+	for _, arg := range args {
+		addresses = append(addresses, addressValues(arg)...)
+	}
+
+	t.logger.StartProgress("Taking snapshot of accounts...")
+	before, err := t.snapshotAccounts(uniqueAddresses(addresses))
+	t.logger.StopProgress()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	tx, result, err := t.SendWithRoles(accounts, code, codeFilename, gasLimit, args, network)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	for _, event := range result.Events {
+		if event.Type == flow.EventAccountCreated {
+			addresses = append(addresses, flow.AccountCreatedEvent(event).Address())
+		}
+	}
+
+	t.logger.StartProgress("Taking snapshot of accounts...")
+	after, err := t.snapshotAccounts(uniqueAddresses(addresses))
+	t.logger.StopProgress()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	diffs := make([]*AccountDiff, 0, len(after))
+	for i, snapshot := range after {
+		previous := &AccountSnapshot{Address: snapshot.Address}
+		if i < len(before) {
+			previous = before[i]
+		}
+		diffs = append(diffs, NewAccountDiff(previous, snapshot))
+	}
+
+	return tx, result, diffs, nil
+}
+
+// snapshotAccounts takes a snapshot of every account in the same order as the addresses.
+func (t *Transactions) snapshotAccounts(addresses []flow.Address) ([]*AccountSnapshot, error) {
+	snapshots := make([]*AccountSnapshot, 0, len(addresses))
+	for _, address := range addresses {
+		snapshot, err := t.snapshotAccount(address)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, nil
+}
+
+func (t *Transactions) snapshotAccount(address flow.Address) (*AccountSnapshot, error) {
+	snapshot := &AccountSnapshot{
+		Address:   address,
+		Contracts: make(map[string]string),
+	}
+
+	if inspector, ok := t.gateway.(gateway.StorageInspector); ok {
+		paths, err := inspector.StoredPaths(address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get stored paths of account %s: %w", address, err)
+		}
+		snapshot.Paths = paths
+	}
+
+	// address arguments may refer to accounts that don't exist
+	account, err := t.gateway.GetAccount(address)
+	if err != nil {
+		return snapshot, nil
+	}
+
+	snapshot.Exists = true
+	snapshot.Keys = account.Keys
+	for name, code := range account.Contracts {
+		hash := sha256.Sum256(code)
+		snapshot.Contracts[name] = hex.EncodeToString(hash[:])
+	}
+
+	value, err := t.gateway.ExecuteScript(
+		[]byte(accountSnapshotScript),
+		[]cadence.Value{cadence.NewAddress(address)},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance of account %s: %w", address, err)
+	}
+
+	values, ok := value.(cadence.Array)
+	if !ok || len(values.Values) != 2 {
+		return nil, fmt.Errorf("failed to get balance of account %s: unexpected result %s", address, value)
+	}
+	balance, _ := values.Values[0].(cadence.UFix64)
+	storageUsed, _ := values.Values[1].(cadence.UInt64)
+	snapshot.Balance = uint64(balance)
+	snapshot.StorageUsed = uint64(storageUsed)
+
+	return snapshot, nil
+}
+
+// addressValues returns the addresses in the argument value, including addresses in arrays and optionals.
+func addressValues(value cadence.Value) []flow.Address {
+	switch v := value.(type) {
+	case cadence.Address:
+		return []flow.Address{flow.Address(v)}
+	case cadence.Optional:
+		if v.Value != nil {
+			return addressValues(v.Value)
+		}
+	case cadence.Array:
+		var addresses []flow.Address
+		for _, element := range v.Values {
+			addresses = append(addresses, addressValues(element)...)
+		}
+		return addresses
+	}
+
+	return nil
+}
+
+// uniqueAddresses removes duplicate addresses keeping the order of the first occurrence.
+func uniqueAddresses(addresses []flow.Address) []flow.Address {
+	seen := make(map[flow.Address]bool)
+	unique := make([]flow.Address, 0, len(addresses))
+	for _, address := range addresses {
+		if !seen[address] {
+			seen[address] = true
+			unique = append(unique, address)
+		}
+	}
+
+	return unique
+}
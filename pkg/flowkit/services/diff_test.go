@@ -0,0 +1,114 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package services
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+)
+
+func TestTransactionsSendWithDiff_Integration(t *testing.T) {
+	t.Parallel()
+
+	state, s := setupIntegration()
+	srvAcc, _ := state.EmulatorServiceAccount()
+
+	code := []byte(`
+		transaction(other: Address) {
+			prepare(signer: AuthAccount) {
+				signer.save<String>("Foo", to: /storage/foo)
+				signer.link<&String>(/public/foo, target: /storage/foo)
+				AuthAccount(payer: signer)
+			}
+		}`)
+
+	other := flow.HexToAddress("0x0000000000000009")
+	_, result, diffs, err := s.Transactions.SendWithDiff(
+		flowkit.NewTransactionSingleAccountRole(srvAcc),
+		code,
+		"diff.cdc",
+		gasLimit,
+		[]cadence.Value{cadence.NewAddress(other)},
+		"",
+	)
+	assert.NoError(t, err)
+	assert.NoError(t, result.Error)
+	assert.Len(t, diffs, 3)
+
+	signer := diffs[0]
+	assert.Equal(t, srvAcc.Address(), signer.Address)
+	assert.False(t, signer.Created)
+	assert.True(t, signer.Changed())
+	assert.True(t, signer.PathsAvailable)
+	assert.Equal(t, []string{"/public/foo", "/storage/foo"}, signer.PathsAdded)
+	assert.Greater(t, signer.StorageUsedAfter, signer.StorageUsedBefore)
+	assert.Greater(t, signer.BalanceBefore, signer.BalanceAfter)
+
+	assert.Equal(t, other, diffs[1].Address)
+	assert.False(t, diffs[1].Changed())
+
+	created := diffs[2]
+	assert.True(t, created.Created)
+	assert.Greater(t, created.StorageUsedAfter, uint64(0))
+	assert.Contains(t, created.PathsAdded, "/storage/flowTokenVault")
+}
+
+func TestAccountDiff(t *testing.T) {
+	key := func(index int, revoked bool) *flow.AccountKey {
+		return &flow.AccountKey{Index: index, Revoked: revoked}
+	}
+
+	before := &AccountSnapshot{
+		Exists:    true,
+		Balance:   10,
+		Contracts: map[string]string{"Foo": "a", "Bar": "b"},
+		Keys:      []*flow.AccountKey{key(0, false), key(1, false)},
+		Paths:     []string{"/storage/a", "/storage/b"},
+	}
+	after := &AccountSnapshot{
+		Exists:    true,
+		Balance:   10,
+		Contracts: map[string]string{"Foo": "c", "Baz": "d"},
+		Keys:      []*flow.AccountKey{key(0, false), key(1, true), key(2, false)},
+		Paths:     []string{"/storage/b", "/storage/c"},
+	}
+
+	diff := NewAccountDiff(before, after)
+	assert.True(t, diff.Changed())
+	assert.False(t, diff.Created)
+	assert.Equal(t, []string{"Baz"}, diff.ContractsAdded)
+	assert.Equal(t, []string{"Foo"}, diff.ContractsUpdated)
+	assert.Equal(t, []string{"Bar"}, diff.ContractsRemoved)
+	assert.Len(t, diff.KeysAdded, 1)
+	assert.Equal(t, 2, diff.KeysAdded[0].Index)
+	assert.Equal(t, []int{1}, diff.KeysRevoked)
+	assert.Equal(t, []string{"/storage/c"}, diff.PathsAdded)
+	assert.Equal(t, []string{"/storage/a"}, diff.PathsRemoved)
+
+	unchanged := NewAccountDiff(before, before)
+	assert.False(t, unchanged.Changed())
+
+	unavailable := NewAccountDiff(&AccountSnapshot{}, &AccountSnapshot{})
+	assert.False(t, unavailable.PathsAvailable)
+}
@@ -0,0 +1,510 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package services
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	flowGo "github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/contracts"
+	"github.com/onflow/flow-cli/pkg/flowkit/gateway"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+// forkPlaceholderBatch is the number of placeholder accounts created by a single transaction.
+const forkPlaceholderBatch = 50
+
+const createPlaceholderAccounts = `
+transaction(count: Int) {
+	prepare(signer: AuthAccount) {
+		var i = 0
+		while i < count {
+			AuthAccount(payer: signer)
+			i = i + 1
+		}
+	}
+}`
+
+const mintForkBalance = `
+import FungibleToken from 0xee82856bf20e2aa6
+import FlowToken from 0x0ae53cb6e3f42a79
+
+transaction(recipient: Address, amount: UFix64) {
+	prepare(signer: AuthAccount) {
+		let admin = signer.borrow<&FlowToken.Administrator>(from: /storage/flowTokenAdmin)
+			?? panic("missing FLOW token administrator")
+
+		let minter <- admin.createNewMinter(allowedAmount: amount)
+		let vault <- minter.mintTokens(amount: amount)
+		destroy minter
+
+		let receiver = getAccount(recipient).getCapability(/public/flowTokenReceiver)
+			.borrow<&{FungibleToken.Receiver}>()
+			?? panic("missing FLOW token receiver")
+
+		receiver.deposit(from: <-vault)
+	}
+}`
+
+// Fork copies the accounts signing the transaction and the contracts it imports from the network
+// to a new in-process emulator and returns the transactions service for the emulator.
+//
+// The copied accounts only have a generated key, the returned roles sign with it instead of the
+// configured keys. Contracts are copied with the contracts they import and FLOW balances are minted,
+// other storage is not copied. Only accounts on the emulator network can be copied.
+func (t *Transactions) Fork(
+	accounts *flowkit.TransactionAccountRoles,
+	code []byte,
+	codeFilename string,
+	network string,
+) (*Transactions, *flowkit.TransactionAccountRoles, error) {
+	if t.state == nil {
+		return nil, nil, fmt.Errorf("missing configuration, initialize it: flow state init")
+	}
+
+	t.logger.StartProgress("Copying accounts to the in-process emulator...")
+	defer t.logger.StopProgress()
+
+	code, _, err := t.resolveImports(code, codeFilename, network)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resolver, err := contracts.NewResolver(code)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	addresses := resolver.AddressImports()
+	for _, signer := range accounts.Signers() {
+		addresses = append(addresses, signer.Address())
+	}
+
+	forkGateway, privateKey, err := forkAccounts(t.gateway, addresses)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fork := &Transactions{
+		gateway: forkGateway,
+		state:   t.state,
+		logger:  t.logger,
+		forked:  true,
+	}
+
+	forked := make(map[flow.Address]*flowkit.Account)
+	for _, signer := range accounts.Signers() {
+		if forked[signer.Address()] != nil {
+			continue
+		}
+
+		account := forkAccount(signer.Address(), privateKey)
+		account.SetName(signer.Name())
+
+		onChain, err := forkGateway.GetAccount(signer.Address())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		mismatches, err := account.VerifyKey(onChain)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(mismatches) > 0 {
+			return nil, nil, fmt.Errorf("account %s can not sign transactions executed in-process", signer.Address())
+		}
+
+		forked[signer.Address()] = account
+	}
+
+	roles := &flowkit.TransactionAccountRoles{
+		Proposer: forked[accounts.Proposer.Address()],
+		Payer:    forked[accounts.Payer.Address()],
+	}
+	for _, authorizer := range accounts.Authorizers {
+		roles.Authorizers = append(roles.Authorizers, forked[authorizer.Address()])
+	}
+
+	return fork, roles, nil
+}
+
+// Fork copies the accounts of the contracts imported by the script from the network to a new
+// in-process emulator and returns the scripts service for the emulator, which captures the script logs.
+//
+// FLOW balances are copied, other storage of the accounts is not. Only accounts on the emulator network can be copied.
+func (s *Scripts) Fork(code []byte, scriptPath string, network string) (*Scripts, error) {
+	s.logger.StartProgress("Copying contracts to the in-process emulator...")
+	defer s.logger.StopProgress()
+
+	code, _, err := s.resolveImports(code, scriptPath, network)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver, err := contracts.NewResolver(code)
+	if err != nil {
+		return nil, err
+	}
+
+	forkGateway, _, err := forkAccounts(s.gateway, resolver.AddressImports())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Scripts{
+		gateway: forkGateway,
+		state:   s.state,
+		logger:  s.logger,
+	}, nil
+}
+
+// forkAccounts copies the accounts and the contracts they import from the gateway to a new in-process emulator.
+//
+// The copied accounts and the service account of the emulator only have the returned generated key.
+// FLOW balances are copied, other storage of the accounts is not.
+func forkAccounts(source gateway.Gateway, addresses []flow.Address) (*gateway.EmulatorGateway, crypto.PrivateKey, error) {
+	copied, err := fetchForkAccounts(source, addresses)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	seed, err := util.RandomSeed(crypto.MinSeedLength)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, seed)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate in-process emulator key: %w", err)
+	}
+
+	service := forkAccount(flow.ServiceAddress(flow.Emulator), privateKey)
+	fork := gateway.NewEmulatorGateway(service)
+
+	err = copyAccounts(fork, service, copied, privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = copyContracts(fork, service, copied, privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = copyBalances(fork, service, copied)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return fork, privateKey, nil
+}
+
+// fetchForkAccounts fetches the accounts and the accounts of all the contracts imported by their contracts.
+func fetchForkAccounts(source gateway.Gateway, addresses []flow.Address) (map[flow.Address]*flow.Account, error) {
+	accounts := make(map[flow.Address]*flow.Account)
+
+	for len(addresses) > 0 {
+		address := addresses[0]
+		addresses = addresses[1:]
+
+		if accounts[address] != nil {
+			continue
+		}
+
+		if !address.IsValid(flow.Emulator) {
+			return nil, fmt.Errorf("account %s is not an emulator account, only accounts on the emulator network can be copied to the in-process emulator", address)
+		}
+
+		account, err := source.GetAccount(address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch account %s: %w", address, err)
+		}
+		accounts[address] = account
+
+		for _, code := range account.Contracts {
+			resolver, err := contracts.NewResolver(code)
+			if err != nil {
+				return nil, err
+			}
+
+			addresses = append(addresses, resolver.AddressImports()...)
+		}
+	}
+
+	return accounts, nil
+}
+
+// copyAccounts creates the accounts in the fork at the same addresses with the generated key.
+//
+// Addresses are assigned in order, so placeholder accounts are created for the addresses in between.
+func copyAccounts(
+	fork *gateway.EmulatorGateway,
+	service *flowkit.Account,
+	accounts map[flow.Address]*flow.Account,
+	privateKey crypto.PrivateKey,
+) error {
+	chain := flowGo.Emulator.Chain()
+
+	indexes := make([]uint64, 0, len(accounts))
+	for address := range accounts {
+		index, err := chain.IndexFromAddress(flowGo.Address(address))
+		if err != nil {
+			return err
+		}
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+
+	next, err := nextAccountIndex(fork)
+	if err != nil {
+		return err
+	}
+
+	key := &flow.AccountKey{
+		PublicKey: privateKey.PublicKey(),
+		SigAlgo:   crypto.ECDSA_P256,
+		HashAlgo:  crypto.SHA3_256,
+		Weight:    flow.AccountKeyWeightThreshold,
+	}
+
+	for _, index := range indexes {
+		if index < next { // created when the emulator was bootstrapped
+			continue
+		}
+
+		for next < index {
+			count := index - next
+			if count > forkPlaceholderBatch {
+				count = forkPlaceholderBatch
+			}
+
+			tx := flowkit.NewTransaction()
+			err := tx.SetScriptWithArgs([]byte(createPlaceholderAccounts), []cadence.Value{cadence.NewInt(int(count))})
+			if err != nil {
+				return err
+			}
+			tx.AddAuthorizers([]flow.Address{service.Address()})
+
+			_, err = sendForked(fork, tx, service)
+			if err != nil {
+				return fmt.Errorf("failed to create in-process emulator accounts: %w", err)
+			}
+			next += count
+		}
+
+		tx, err := flowkit.NewCreateAccountTransaction(service, []*flow.AccountKey{key}, nil)
+		if err != nil {
+			return err
+		}
+
+		result, err := sendForked(fork, tx, service)
+		if err != nil {
+			return fmt.Errorf("failed to create in-process emulator account: %w", err)
+		}
+
+		address, _ := chain.AddressAtIndex(index)
+		events := flowkit.EventsFromTransaction(result)
+		if created := events.GetAddress(); created == nil || *created != flow.Address(address) {
+			return fmt.Errorf("failed to create account %s on the in-process emulator", address)
+		}
+		next++
+	}
+
+	return nil
+}
+
+// nextAccountIndex returns the index of the next address the emulator assigns to a created account.
+func nextAccountIndex(fork *gateway.EmulatorGateway) (uint64, error) {
+	chain := flowGo.Emulator.Chain()
+
+	for index := uint64(1); ; index++ {
+		address, err := chain.AddressAtIndex(index)
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = fork.GetAccount(flow.Address(address))
+		if err != nil {
+			return index, nil
+		}
+	}
+}
+
+// copyContracts deploys the contracts of the accounts to the fork.
+//
+// Contracts are deployed once the contracts they import are, contracts the emulator
+// was bootstrapped with are not deployed again.
+func copyContracts(
+	fork *gateway.EmulatorGateway,
+	service *flowkit.Account,
+	accounts map[flow.Address]*flow.Account,
+	privateKey crypto.PrivateKey,
+) error {
+	type contract struct {
+		account flow.Address
+		name    string
+		code    []byte
+	}
+
+	pending := make([]contract, 0)
+	for address, account := range accounts {
+		existing, err := fork.GetAccount(address)
+		if err != nil {
+			return err
+		}
+
+		for name, code := range account.Contracts {
+			if _, ok := existing.Contracts[name]; !ok {
+				pending = append(pending, contract{account: address, name: name, code: code})
+			}
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].account.Hex()+pending[i].name < pending[j].account.Hex()+pending[j].name
+	})
+
+	for len(pending) > 0 {
+		var failed []contract
+		var lastErr error
+
+		for _, c := range pending {
+			signer := forkAccount(c.account, privateKey)
+			if c.account == service.Address() {
+				signer = service
+			}
+
+			tx, err := flowkit.NewAddAccountContractTransaction(signer, c.name, string(c.code), nil)
+			if err != nil {
+				return err
+			}
+
+			_, err = sendForked(fork, tx, signer)
+			if err != nil {
+				failed = append(failed, c)
+				lastErr = fmt.Errorf("contract %s of account %s can not be copied: %w", c.name, c.account, err)
+			}
+		}
+
+		if len(failed) == len(pending) {
+			return lastErr
+		}
+		pending = failed
+	}
+
+	return nil
+}
+
+// copyBalances mints FLOW to the accounts in the fork, so they hold at least their balance on the network.
+func copyBalances(
+	fork *gateway.EmulatorGateway,
+	service *flowkit.Account,
+	accounts map[flow.Address]*flow.Account,
+) error {
+	addresses := make([]flow.Address, 0, len(accounts))
+	for address := range accounts {
+		if address != service.Address() { // the service account is funded when the emulator is bootstrapped
+			addresses = append(addresses, address)
+		}
+	}
+	sort.Slice(addresses, func(i, j int) bool { return addresses[i].Hex() < addresses[j].Hex() })
+
+	for _, address := range addresses {
+		existing, err := fork.GetAccount(address)
+		if err != nil {
+			return err
+		}
+
+		balance := accounts[address].Balance
+		if balance <= existing.Balance {
+			continue
+		}
+
+		tx := flowkit.NewTransaction()
+		err = tx.SetScriptWithArgs([]byte(mintForkBalance), []cadence.Value{
+			cadence.NewAddress(address),
+			cadence.UFix64(balance - existing.Balance),
+		})
+		if err != nil {
+			return err
+		}
+		tx.AddAuthorizers([]flow.Address{service.Address()})
+
+		_, err = sendForked(fork, tx, service)
+		if err != nil {
+			return fmt.Errorf("failed to copy balance of account %s: %w", address, err)
+		}
+	}
+
+	return nil
+}
+
+// sendForked signs the transaction with the signer as proposer, payer and authorizer, and sends it to the fork.
+func sendForked(fork *gateway.EmulatorGateway, tx *flowkit.Transaction, signer *flowkit.Account) (*flow.TransactionResult, error) {
+	block, err := fork.GetLatestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	proposer, err := fork.GetAccount(signer.Address())
+	if err != nil {
+		return nil, err
+	}
+
+	tx.SetBlockReference(block).
+		SetProposer(proposer, signer.Key().Index()).
+		SetPayer(signer.Address()).
+		SetGasLimit(flowkit.MaxGasLimit)
+
+	err = tx.SetSigner(signer)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err = tx.Sign()
+	if err != nil {
+		return nil, err
+	}
+
+	sentTx, err := fork.SendSignedTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := fork.GetTransactionResult(sentTx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, result.Error
+}
+
+// forkAccount returns the account signing with the generated key of the fork.
+func forkAccount(address flow.Address, privateKey crypto.PrivateKey) *flowkit.Account {
+	account := &flowkit.Account{}
+	account.SetAddress(address)
+	account.SetKey(flowkit.NewHexAccountKeyFromPrivateKey(0, crypto.SHA3_256, privateKey))
+
+	return account
+}
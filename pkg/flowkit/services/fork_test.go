@@ -0,0 +1,153 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/tests"
+)
+
+const transferFlow = `
+import FungibleToken from 0xee82856bf20e2aa6
+import FlowToken from 0x0ae53cb6e3f42a79
+
+transaction(amount: UFix64, to: Address) {
+	prepare(signer: AuthAccount) {
+		let vault = signer.borrow<&FlowToken.Vault>(from: /storage/flowTokenVault)!
+		getAccount(to).getCapability(/public/flowTokenReceiver)
+			.borrow<&{FungibleToken.Receiver}>()!
+			.deposit(from: <-vault.withdraw(amount: amount))
+	}
+}`
+
+func TestTransactionsFork_Integration(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Fork Accounts and Contracts", func(t *testing.T) {
+		t.Parallel()
+		state, s := setupIntegration()
+		setupAccounts(state, s)
+
+		a, _ := state.Accounts().ByName("Alice")
+		b, _ := state.Accounts().ByName("Bob")
+		c, _ := state.Accounts().ByName("Charlie")
+
+		_, err := s.Accounts.AddContract(b, tests.ContractA.Name, tests.ContractA.Source, false)
+		assert.NoError(t, err)
+
+		_, err = s.Accounts.AddContract(c, "ContractD", []byte(fmt.Sprintf(`
+			import ContractA from 0x%s
+			pub contract ContractD {
+				pub fun hello(): String { return "Hello" }
+			}`, b.Address())), false)
+		assert.NoError(t, err)
+
+		code := []byte(fmt.Sprintf(`
+			import ContractD from 0x%s
+			transaction {
+				prepare(signer: AuthAccount) { log(ContractD.hello()) }
+			}`, c.Address()))
+
+		fork, roles, err := s.Transactions.Fork(flowkit.NewTransactionSingleAccountRole(a), code, "", "")
+		assert.NoError(t, err)
+		assert.Equal(t, a.Address(), roles.Proposer.Address())
+
+		result, err := fork.DryRun(roles, code, "", gasLimit, nil, "")
+		assert.NoError(t, err)
+		assert.NoError(t, result.Result.Error)
+		assert.Greater(t, result.ComputationUsed, uint64(0))
+		assert.Equal(t, []string{`"Hello"`}, fork.Logs(result.Tx.ID()))
+
+		original, _ := s.Accounts.Get(a.Address())
+		assert.Equal(t, uint64(0), original.Keys[0].SequenceNumber)
+	})
+
+	t.Run("Fork Account After Other Accounts", func(t *testing.T) {
+		t.Parallel()
+		state, s := setupIntegration()
+		setupAccounts(state, s)
+
+		c, _ := state.Accounts().ByName("Charlie")
+		code := []byte(`transaction { prepare(signer: AuthAccount) {} }`)
+
+		fork, roles, err := s.Transactions.Fork(flowkit.NewTransactionSingleAccountRole(c), code, "", "")
+		assert.NoError(t, err)
+
+		result, err := fork.DryRun(roles, code, "", gasLimit, nil, "")
+		assert.NoError(t, err)
+		assert.NoError(t, result.Result.Error)
+		assert.Equal(t, c.Address(), result.Tx.Payer)
+	})
+
+	t.Run("Fork Account Balance", func(t *testing.T) {
+		t.Parallel()
+		state, s := setupIntegration()
+		setupAccounts(state, s)
+
+		srvAcc, _ := state.EmulatorServiceAccount()
+		a, _ := state.Accounts().ByName("Alice")
+
+		_, result, err := s.Transactions.Send(
+			srvAcc,
+			[]byte(transferFlow),
+			"",
+			gasLimit,
+			[]cadence.Value{cadence.UFix64(1000_00000000), cadence.NewAddress(a.Address())},
+			"",
+		)
+		assert.NoError(t, err)
+		assert.NoError(t, result.Error)
+
+		fork, roles, err := s.Transactions.Fork(flowkit.NewTransactionSingleAccountRole(a), []byte(transferFlow), "", "")
+		assert.NoError(t, err)
+
+		dryRun, err := fork.DryRun(
+			roles,
+			[]byte(transferFlow),
+			"",
+			gasLimit,
+			[]cadence.Value{cadence.UFix64(500_00000000), cadence.NewAddress(srvAcc.Address())},
+			"",
+		)
+		assert.NoError(t, err)
+		assert.NoError(t, dryRun.Result.Error)
+	})
+
+	t.Run("Fork Non Emulator Account", func(t *testing.T) {
+		t.Parallel()
+		state, s := setupIntegration()
+		srvAcc, _ := state.EmulatorServiceAccount()
+
+		_, _, err := s.Transactions.Fork(
+			flowkit.NewTransactionSingleAccountRole(srvAcc),
+			[]byte(`import FungibleToken from 0x9a0766d93b6608b7
+			transaction {}`),
+			"",
+			"",
+		)
+
+		assert.EqualError(t, err, "account 9a0766d93b6608b7 is not an emulator account, only accounts on the emulator network can be copied to the in-process emulator")
+	})
+}
@@ -19,8 +19,13 @@
 package services
 
 import (
+	"context"
+	"crypto/x509/pkix"
+	"encoding/asn1"
 	"encoding/hex"
+	"encoding/pem"
 	"fmt"
+	"strings"
 
 	"github.com/onflow/flow-cli/pkg/flowkit"
 
@@ -102,3 +107,222 @@ func (k *Keys) DecodePEM(key string, sigAlgo crypto.SignatureAlgorithm) (*flow.A
 		Weight:    -1,
 	}, nil
 }
+
+// ParsePublicKey decodes a public key in hex, RLP or PEM format.
+//
+// RLP encoded account keys contain the algorithms and weight, for hex and PEM keys
+// the provided signature algorithm is used and the weight is unknown (-1).
+func (k *Keys) ParsePublicKey(key string, sigAlgo crypto.SignatureAlgorithm) (*flow.AccountKey, error) {
+	key = strings.TrimSpace(key)
+
+	if strings.HasPrefix(key, "-----BEGIN") {
+		return k.DecodePEM(key, sigAlgo)
+	}
+
+	key = strings.TrimPrefix(key, "0x")
+	keyBytes, err := hex.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode public key, valid formats are hex, RLP and PEM: %w", err)
+	}
+
+	// raw ECDSA public keys are 64 bytes long, anything else is expected to be an RLP encoded account key
+	if len(keyBytes) == 64 {
+		pk, err := crypto.DecodePublicKey(sigAlgo, keyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode public key: %w", err)
+		}
+
+		return &flow.AccountKey{
+			PublicKey: pk,
+			SigAlgo:   sigAlgo,
+			Weight:    -1,
+		}, nil
+	}
+
+	return k.DecodeRLP(key)
+}
+
+// EncodeRLP encodes the account key with its algorithms and weight in RLP format.
+func (k *Keys) EncodeRLP(accountKey *flow.AccountKey) ([]byte, error) {
+	err := accountKey.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid account key: %w", err)
+	}
+
+	return accountKey.Encode(), nil
+}
+
+var (
+	oidPublicKeyECDSA      = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidNamedCurveP256      = asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+	oidNamedCurveSECP256K1 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+)
+
+// EncodePEM encodes the public key in PEM format using the PKIX, ASN.1 DER form.
+func (k *Keys) EncodePEM(publicKey crypto.PublicKey) ([]byte, error) {
+	var curve asn1.ObjectIdentifier
+	switch publicKey.Algorithm() {
+	case crypto.ECDSA_P256:
+		curve = oidNamedCurveP256
+	case crypto.ECDSA_secp256k1:
+		curve = oidNamedCurveSECP256K1
+	default:
+		return nil, fmt.Errorf("PEM encoding not supported for signature algorithm %s", publicKey.Algorithm())
+	}
+
+	params, err := asn1.Marshal(curve)
+	if err != nil {
+		return nil, err
+	}
+
+	// uncompressed point is prefixed with 0x04
+	point := append([]byte{4}, publicKey.Encode()...)
+
+	der, err := asn1.Marshal(struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}{
+		Algorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  oidPublicKeyECDSA,
+			Parameters: asn1.RawValue{FullBytes: params},
+		},
+		PublicKey: asn1.BitString{Bytes: point, BitLength: len(point) * 8},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode public key: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+// Inspect derives the public key and algorithms of the account key.
+//
+// The account key can be a private key or a KMS key, in which case the public key is fetched from KMS.
+func (k *Keys) Inspect(key flowkit.AccountKey) (*flow.AccountKey, error) {
+	publicKey, err := key.PublicKey(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive public key: %w", err)
+	}
+
+	return &flow.AccountKey{
+		Index:     key.Index(),
+		PublicKey: publicKey,
+		SigAlgo:   publicKey.Algorithm(),
+		HashAlgo:  key.HashAlgo(),
+		Weight:    -1,
+	}, nil
+}
+
+// userMessage prefixes the message with the user domain tag.
+func userMessage(message []byte) []byte {
+	return append(flow.UserDomainTag[:], message...)
+}
+
+// SignMessage signs the message with the account key.
+//
+// The message is prefixed with the user domain tag before signing.
+func (k *Keys) SignMessage(account *flowkit.Account, message []byte) ([]byte, error) {
+	signer, err := account.Key().Signer(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := signer.Sign(userMessage(message))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign message: %w", err)
+	}
+
+	return signature, nil
+}
+
+// VerifySignature verifies the message signature using the public key.
+func (k *Keys) VerifySignature(
+	publicKey crypto.PublicKey,
+	hashAlgo crypto.HashAlgorithm,
+	message []byte,
+	signature []byte,
+) (bool, error) {
+	hasher, err := crypto.NewHasher(hashAlgo)
+	if err != nil {
+		return false, err
+	}
+
+	return publicKey.Verify(signature, userMessage(message), hasher)
+}
+
+// KeySignature is a message signature made by the account key at the index.
+type KeySignature struct {
+	KeyIndex  int
+	Signature []byte
+}
+
+// VerifiedSignature is a verified message signature with the weight of the account key.
+type VerifiedSignature struct {
+	KeySignature
+	Weight  int
+	Revoked bool
+	Valid   bool
+	Error   error
+}
+
+// SignatureVerification is the result of verifying message signatures against account keys.
+type SignatureVerification struct {
+	Address    flow.Address
+	Signatures []VerifiedSignature
+	Weight     int
+}
+
+// Valid checks if the valid signatures add up to the account key weight threshold.
+func (s *SignatureVerification) Valid() bool {
+	return s.Weight >= flow.AccountKeyWeightThreshold
+}
+
+// VerifyAccountSignatures verifies message signatures using the account keys fetched from the network.
+//
+// Weights of keys with valid signatures are added up, revoked keys and
+// keys signing more than once are counted only once or not at all.
+func (k *Keys) VerifyAccountSignatures(
+	address flow.Address,
+	message []byte,
+	signatures []KeySignature,
+) (*SignatureVerification, error) {
+	k.logger.StartProgress(fmt.Sprintf("Fetching keys for %s...", address))
+	account, err := k.gateway.GetAccount(address)
+	k.logger.StopProgress()
+	if err != nil {
+		return nil, err
+	}
+
+	verification := &SignatureVerification{Address: address}
+	counted := make(map[int]bool)
+
+	for _, sig := range signatures {
+		verified := VerifiedSignature{KeySignature: sig}
+
+		var key *flow.AccountKey
+		for _, accountKey := range account.Keys {
+			if accountKey.Index == sig.KeyIndex {
+				key = accountKey
+			}
+		}
+
+		if key == nil {
+			verified.Error = fmt.Errorf("key index %d does not exist on account %s", sig.KeyIndex, address)
+			verification.Signatures = append(verification.Signatures, verified)
+			continue
+		}
+
+		verified.Weight = key.Weight
+		verified.Revoked = key.Revoked
+		verified.Valid, verified.Error = k.VerifySignature(key.PublicKey, key.HashAlgo, message, sig.Signature)
+
+		if verified.Valid && !key.Revoked && !counted[key.Index] {
+			verification.Weight += key.Weight
+			counted[key.Index] = true
+		}
+
+		verification.Signatures = append(verification.Signatures, verified)
+	}
+
+	return verification, nil
+}
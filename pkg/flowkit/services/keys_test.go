@@ -19,10 +19,13 @@
 package services
 
 import (
+	"encoding/hex"
 	"testing"
 
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-cli/tests"
 )
 
 func TestKeys(t *testing.T) {
@@ -109,4 +112,126 @@ func TestKeys(t *testing.T) {
 		_, err := s.Keys.DecodePEM("nope", crypto.ECDSA_P256)
 		assert.Equal(t, err.Error(), "crypto: failed to parse PEM string, not all bytes in PEM key were decoded: 6e6f7065")
 	})
+
+	t.Run("Encode PEM Key", func(t *testing.T) {
+		t.Parallel()
+
+		_, s, _ := setup()
+		pem := "-----BEGIN PUBLIC KEY-----\nMFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAE1HmzzcntvdsZXLErNRYa3oJrAypk\nvdQGLMh/s7p+ccnPZG/yOZC7RTLKRcRFx+kIzvJ4ssRhU2ADmmZgo2apXw==\n-----END PUBLIC KEY-----\n"
+		dkey, _ := s.Keys.DecodePEM(pem, crypto.ECDSA_P256)
+
+		encoded, err := s.Keys.EncodePEM(dkey.PublicKey)
+		assert.NoError(t, err)
+		assert.Equal(t, pem, string(encoded))
+
+		// secp256k1 keys round trip
+		pk, _ := crypto.GeneratePrivateKey(crypto.ECDSA_secp256k1, []byte("seedseedseedseedseedseedseedseedseedseedseedseedseed"))
+		encoded, err = s.Keys.EncodePEM(pk.PublicKey())
+		assert.NoError(t, err)
+		decoded, err := s.Keys.DecodePEM(string(encoded), crypto.ECDSA_secp256k1)
+		assert.NoError(t, err)
+		assert.True(t, decoded.PublicKey.Equals(pk.PublicKey()))
+	})
+
+	t.Run("Encode RLP Key", func(t *testing.T) {
+		t.Parallel()
+
+		_, s, _ := setup()
+		rlp := "f847b84084d716c14b051ad6b001624f738f5d302636e6b07cc75e4530af7776a4368a2b586dbefc0564ee28384c2696f178cbed52e62811bcc9ecb59568c996d342db2402038203e8"
+		dkey, _ := s.Keys.DecodeRLP(rlp)
+
+		encoded, err := s.Keys.EncodeRLP(dkey)
+		assert.NoError(t, err)
+		assert.Equal(t, rlp, hex.EncodeToString(encoded))
+
+		dkey.HashAlgo = crypto.UnknownHashAlgorithm
+		_, err = s.Keys.EncodeRLP(dkey)
+		assert.Error(t, err)
+	})
+
+	t.Run("Parse Public Key", func(t *testing.T) {
+		t.Parallel()
+
+		_, s, _ := setup()
+		inputs := []string{
+			"0x84d716c14b051ad6b001624f738f5d302636e6b07cc75e4530af7776a4368a2b586dbefc0564ee28384c2696f178cbed52e62811bcc9ecb59568c996d342db24",
+			"f847b84084d716c14b051ad6b001624f738f5d302636e6b07cc75e4530af7776a4368a2b586dbefc0564ee28384c2696f178cbed52e62811bcc9ecb59568c996d342db2402038203e8",
+		}
+		weights := []int{-1, 1000}
+
+		for i, in := range inputs {
+			key, err := s.Keys.ParsePublicKey(in, crypto.ECDSA_P256)
+			assert.NoError(t, err)
+			assert.Equal(t, "0x84d716c14b051ad6b001624f738f5d302636e6b07cc75e4530af7776a4368a2b586dbefc0564ee28384c2696f178cbed52e62811bcc9ecb59568c996d342db24", key.PublicKey.String())
+			assert.Equal(t, weights[i], key.Weight)
+		}
+
+		key, err := s.Keys.ParsePublicKey("-----BEGIN PUBLIC KEY-----\nMFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAE1HmzzcntvdsZXLErNRYa3oJrAypk\nvdQGLMh/s7p+ccnPZG/yOZC7RTLKRcRFx+kIzvJ4ssRhU2ADmmZgo2apXw==\n-----END PUBLIC KEY-----", crypto.ECDSA_P256)
+		assert.NoError(t, err)
+		assert.Equal(t, key.PublicKey.String(), "0xd479b3cdc9edbddb195cb12b35161ade826b032a64bdd4062cc87fb3ba7e71c9cf646ff23990bb4532ca45c445c7e908cef278b2c4615360039a6660a366a95f")
+
+		_, err = s.Keys.ParsePublicKey("nope", crypto.ECDSA_P256)
+		assert.Error(t, err)
+	})
+
+	t.Run("Inspect Key", func(t *testing.T) {
+		t.Parallel()
+
+		_, s, _ := setup()
+		alice := tests.Alice()
+		pk, _ := alice.Key().PrivateKey()
+
+		key, err := s.Keys.Inspect(alice.Key())
+		assert.NoError(t, err)
+		assert.True(t, key.PublicKey.Equals((*pk).PublicKey()))
+		assert.Equal(t, crypto.ECDSA_P256, key.SigAlgo)
+		assert.Equal(t, crypto.SHA3_256, key.HashAlgo)
+	})
+}
+
+func TestKeysSignMessage_Integration(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Sign and Verify", func(t *testing.T) {
+		t.Parallel()
+		state, s := setupIntegration()
+		srvAcc, _ := state.EmulatorServiceAccount()
+		message := []byte("I own this account")
+
+		sig, err := s.Keys.SignMessage(srvAcc, message)
+		assert.NoError(t, err)
+
+		pk, _ := srvAcc.Key().PrivateKey()
+		valid, err := s.Keys.VerifySignature((*pk).PublicKey(), srvAcc.Key().HashAlgo(), message, sig)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+
+		verification, err := s.Keys.VerifyAccountSignatures(
+			srvAcc.Address(),
+			message,
+			[]KeySignature{{KeyIndex: 0, Signature: sig}},
+		)
+		assert.NoError(t, err)
+		assert.True(t, verification.Valid())
+		assert.Equal(t, 1000, verification.Weight)
+	})
+
+	t.Run("Verify Invalid", func(t *testing.T) {
+		t.Parallel()
+		state, s := setupIntegration()
+		srvAcc, _ := state.EmulatorServiceAccount()
+
+		sig, _ := s.Keys.SignMessage(srvAcc, []byte("foo"))
+
+		verification, err := s.Keys.VerifyAccountSignatures(
+			srvAcc.Address(),
+			[]byte("bar"),
+			[]KeySignature{{KeyIndex: 0, Signature: sig}, {KeyIndex: 3, Signature: sig}},
+		)
+		assert.NoError(t, err)
+		assert.False(t, verification.Valid())
+		assert.Equal(t, 0, verification.Weight)
+		assert.False(t, verification.Signatures[0].Valid)
+		assert.EqualError(t, verification.Signatures[1].Error, "key index 3 does not exist on account f8d6e0586b0a20c7")
+	})
 }
@@ -53,6 +53,16 @@ func NewProject(
 	}
 }
 
+// AddressResolver returns the resolver of address references like @alice in arguments on the network,
+// nil is returned without a configuration as references can't be resolved.
+func (p *Project) AddressResolver(network string) flowkit.AddressResolver {
+	if p.state == nil {
+		return nil
+	}
+
+	return p.state.AddressResolver(network)
+}
+
 // Init initializes a new project using the properties provided.
 func (p *Project) Init(
 	readerWriter flowkit.ReaderWriter,
@@ -208,7 +218,14 @@ func (p *Project) Deploy(network string, update bool) ([]*contracts.Contract, er
 		tx.SetBlockReference(block).
 			SetProposer(targetAccountInfo, targetAccount.Key().Index())
 
-		tx, err = tx.Sign()
+		err = tx.SetSigner(targetAccount)
+		if err != nil {
+			p.logger.Error(fmt.Sprintf("%s error: %s", contract.Name(), err))
+			deployErr = true
+			continue
+		}
+
+		tx, err = tx.SetAuditLog(p.state.AuditLog()).Sign()
 		if err != nil {
 			p.logger.Error(fmt.Sprintf("%s error: %s", contract.Name(), err))
 			deployErr = true
@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -94,6 +95,11 @@ func TestProject(t *testing.T) {
 		}
 		state.Deployments().AddOrUpdate(d)
 
+		gw.GetAccount.Run(func(args mock.Arguments) {
+			addr := args.Get(0).(flow.Address)
+			gw.GetAccount.Return(tests.NewAccountWithKey(addr.String(), a.Key()), nil)
+		})
+
 		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
 			tx := args.Get(0).(*flowkit.Transaction)
 			assert.Equal(t, tx.FlowTransaction().Payer, a.Address())
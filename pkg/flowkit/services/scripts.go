@@ -20,6 +20,10 @@ package services
 
 import (
 	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/flow-go-sdk"
 
 	"github.com/onflow/flow-cli/pkg/flowkit"
 
@@ -53,26 +57,141 @@ func NewScripts(
 }
 
 // Execute script code with passed arguments on the selected network.
+//
+// Cadence errors are returned as flowkit.ExecutionError with positions mapped to the script source.
 func (s *Scripts) Execute(code []byte, args []cadence.Value, scriptPath string, network string) (cadence.Value, error) {
-	resolver, err := contracts.NewResolver(code)
+	value, _, err := s.ExecuteWithLogs(code, args, scriptPath, network)
+	return value, err
+}
+
+// ExecuteWithLogs executes script code like Execute and also returns the output of the Cadence log function.
+//
+// Logs are only captured by the emulator gateway, for other gateways the returned logs are nil.
+func (s *Scripts) ExecuteWithLogs(
+	code []byte,
+	args []cadence.Value,
+	scriptPath string,
+	network string,
+) (cadence.Value, []string, error) {
+	code, sourceMap, err := s.resolveImports(code, scriptPath, network)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	args, err = resolveArgumentTypes(s.state, args, scriptPath, network)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	programLogger, ok := s.gateway.(gateway.ProgramLogger)
+	if !ok {
+		value, err := s.gateway.ExecuteScript(code, args)
+		return value, nil, flowkit.NewExecutionError(err, sourceMap)
+	}
+
+	value, logs, err := programLogger.ExecuteScriptWithLogs(code, args)
+	if logs == nil {
+		logs = []string{}
+	}
+
+	return value, logs, flowkit.NewExecutionError(err, sourceMap)
+}
+
+// ScriptBatchResult is the result of a script executed with one of the argument sets in a batch.
+type ScriptBatchResult struct {
+	Index int
+	Value cadence.Value
+	Err   error
+}
+
+// ExecuteBatch executes script code with every set of arguments on the selected network,
+// with at most concurrency scripts executed at the same time.
+//
+// Imports are resolved only once. Results are sent on the returned channel in the order of the argument sets,
+// each as soon as it and all the results before it are available, and the channel is closed after the last result.
+func (s *Scripts) ExecuteBatch(
+	code []byte,
+	args [][]cadence.Value,
+	scriptPath string,
+	network string,
+	concurrency int,
+) (<-chan *ScriptBatchResult, error) {
+	code, sourceMap, err := s.resolveImports(code, scriptPath, network)
 	if err != nil {
 		return nil, err
 	}
 
+	resolvedArgs := make([][]cadence.Value, 0, len(args))
+	for _, arguments := range args {
+		arguments, err = resolveArgumentTypes(s.state, arguments, scriptPath, network)
+		if err != nil {
+			return nil, err
+		}
+		resolvedArgs = append(resolvedArgs, arguments)
+	}
+	args = resolvedArgs
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	slots := make(chan struct{}, concurrency)
+	pending := make(chan chan *ScriptBatchResult, concurrency)
+
+	go func() {
+		for i, arguments := range args {
+			slots <- struct{}{}
+			done := make(chan *ScriptBatchResult, 1)
+			pending <- done
+
+			go func(index int, arguments []cadence.Value) {
+				value, err := s.gateway.ExecuteScript(code, arguments)
+				<-slots
+
+				done <- &ScriptBatchResult{
+					Index: index,
+					Value: value,
+					Err:   flowkit.NewExecutionError(err, sourceMap),
+				}
+			}(i, arguments)
+		}
+		close(pending)
+	}()
+
+	results := make(chan *ScriptBatchResult)
+	go func() {
+		for done := range pending {
+			results <- <-done
+		}
+		close(results)
+	}()
+
+	return results, nil
+}
+
+// resolveImports replaces file imports in the script code with the addresses of the contracts on the network
+// and returns the source map of the resolved code.
+func (s *Scripts) resolveImports(code []byte, scriptPath string, network string) ([]byte, *flowkit.SourceMap, error) {
+	resolver, err := contracts.NewResolver(code)
+	if err != nil {
+		return nil, nil, err
+	}
+	sourceMap := flowkit.NewSourceMap(scriptPath, code)
+
 	if resolver.HasFileImports() {
 		if s.state == nil {
-			return nil, config.ErrDoesNotExist
+			return nil, nil, config.ErrDoesNotExist
 		}
 		if network == "" {
-			return nil, fmt.Errorf("missing network, specify which network to use to resolve imports in script code")
+			return nil, nil, fmt.Errorf("missing network, specify which network to use to resolve imports in script code")
 		}
 		if scriptPath == "" {
-			return nil, fmt.Errorf("resolving imports in scripts not supported")
+			return nil, nil, fmt.Errorf("resolving imports in scripts not supported")
 		}
 
 		contractsNetwork, err := s.state.DeploymentContractsByNetwork(network)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		code, err = resolver.ResolveImports(
@@ -81,9 +200,53 @@ func (s *Scripts) Execute(code []byte, args []cadence.Value, scriptPath string,
 			s.state.AliasesForNetwork(network),
 		)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
+		sourceMap = resolver.SourceMap()
 	}
 
-	return s.gateway.ExecuteScript(code, args)
+	return code, sourceMap, nil
+}
+
+// resolveArgumentTypes replaces the file locations of struct types in the arguments, parsed from the code
+// with an argument parser, with the addresses of the contracts declaring the types on the network.
+func resolveArgumentTypes(
+	state *flowkit.State,
+	args []cadence.Value,
+	codePath string,
+	network string,
+) ([]cadence.Value, error) {
+	var addresses map[string]flow.Address
+
+	return flowkit.ResolveArgumentTypes(
+		args,
+		func(location common.StringLocation, qualifiedIdentifier string) (common.Location, error) {
+			if string(location) == codePath {
+				return nil, fmt.Errorf("type %s declared in %s can not be used for arguments, declare it in a contract", qualifiedIdentifier, location)
+			}
+
+			if addresses == nil {
+				if state == nil {
+					return nil, config.ErrDoesNotExist
+				}
+
+				contractsNetwork, err := state.DeploymentContractsByNetwork(network)
+				if err != nil {
+					return nil, err
+				}
+
+				addresses = contracts.ContractAddresses(contractsNetwork, state.AliasesForNetwork(network))
+			}
+
+			address, ok := addresses[string(location)]
+			if !ok {
+				return nil, fmt.Errorf("type %s of arguments could not be resolved, %s is not deployed on the network", qualifiedIdentifier, location)
+			}
+
+			return common.AddressLocation{
+				Address: common.Address(address),
+				Name:    strings.Split(qualifiedIdentifier, ".")[0],
+			}, nil
+		},
+	)
 }
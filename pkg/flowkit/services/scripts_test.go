@@ -19,12 +19,18 @@
 package services
 
 import (
+	"fmt"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/onflow/flow-cli/pkg/flowkit"
 	"github.com/onflow/flow-cli/pkg/flowkit/config"
 	"github.com/onflow/flow-cli/tests"
 )
@@ -49,6 +55,63 @@ func TestScripts(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("Execute Batch", func(t *testing.T) {
+		_, s, gw := setup()
+
+		var running, maxRunning int32
+		gw.ExecuteScript.Run(func(args mock.Arguments) {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+					break
+				}
+			}
+
+			// later arguments finish first to check the results keep the order of the arguments
+			name := args.Get(1).([]cadence.Value)[0].(cadence.String)
+			time.Sleep(time.Duration(10-len(name)) * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+		}).Return(cadence.NewString("Hello"), nil)
+
+		argSets := make([][]cadence.Value, 0)
+		for i := 0; i < 8; i++ {
+			argSets = append(argSets, []cadence.Value{cadence.NewString(strings.Repeat("a", i))})
+		}
+
+		results, err := s.Scripts.ExecuteBatch(tests.ScriptArgString.Source, argSets, "", "", 3)
+		assert.NoError(t, err)
+
+		index := 0
+		for result := range results {
+			assert.Equal(t, index, result.Index)
+			assert.NoError(t, result.Err)
+			assert.Equal(t, cadence.NewString("Hello"), result.Value)
+			index++
+		}
+
+		assert.Equal(t, 8, index)
+		assert.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(3))
+		gw.Mock.AssertNumberOfCalls(t, "ExecuteScript", 8)
+	})
+
+	t.Run("Execute Batch Invalid Imports", func(t *testing.T) {
+		_, s, _ := setup()
+
+		_, err := s.Scripts.ExecuteBatch(tests.ScriptImport.Source, nil, tests.ScriptImport.Filename, "", 2)
+		assert.EqualError(t, err, "missing network, specify which network to use to resolve imports in script code")
+	})
+
+	t.Run("Execute Script Without Logs", func(t *testing.T) {
+		_, s, gw := setup()
+		gw.ExecuteScript.Return(cadence.MustConvertValue(""), nil)
+
+		_, logs, err := s.Scripts.ExecuteWithLogs(tests.ScriptArgString.Source, nil, "", "")
+
+		assert.NoError(t, err)
+		assert.Nil(t, logs)
+	})
+
 }
 
 func TestScripts_Integration(t *testing.T) {
@@ -81,6 +144,24 @@ func TestScripts_Integration(t *testing.T) {
 
 	})
 
+	t.Run("Execute report error with source location", func(t *testing.T) {
+		t.Parallel()
+		_, s := setupIntegration()
+		args := []cadence.Value{
+			cadence.NewString("Foo"),
+		}
+		res, err := s.Scripts.Execute(tests.ScriptWithError.Source, args, tests.ScriptWithError.Filename, "")
+
+		assert.Error(t, err)
+		assert.Nil(t, res)
+
+		var executionErr *flowkit.ExecutionError
+		assert.ErrorAs(t, err, &executionErr)
+		assert.Equal(t, tests.ScriptWithError.Filename, executionErr.Errors[0].Location)
+		assert.Equal(t, 2, executionErr.Errors[0].Line)
+		assert.Contains(t, err.Error(), "2 | \t    \tpub fun main(name: String): Strin {")
+	})
+
 	t.Run("Execute With Imports", func(t *testing.T) {
 		t.Parallel()
 		state, s := setupIntegration()
@@ -116,6 +197,56 @@ func TestScripts_Integration(t *testing.T) {
 		assert.Equal(t, res.String(), "\"Hello Hello, World!\"")
 	})
 
+	t.Run("Execute With Struct Argument", func(t *testing.T) {
+		t.Parallel()
+		state, s := setupIntegration()
+		srvAcc, _ := state.EmulatorServiceAccount()
+
+		c := config.Contract{
+			Name:    tests.ContractPeople.Name,
+			Source:  tests.ContractPeople.Filename,
+			Network: "emulator",
+		}
+		state.Contracts().AddOrUpdate(c.Name, c)
+
+		d := config.Deployment{
+			Network: "emulator",
+			Account: srvAcc.Name(),
+			Contracts: []config.ContractDeployment{{
+				Name: c.Name,
+				Args: nil,
+			}},
+		}
+		state.Deployments().AddOrUpdate(d)
+		_, err := s.Accounts.AddContract(srvAcc, tests.ContractPeople.Name, tests.ContractPeople.Source, false)
+		assert.NoError(t, err)
+
+		// struct as parsed by the argument parser with the location of the contract file
+		person := cadence.NewStruct([]cadence.Value{cadence.NewString("Alice")}).WithType(&cadence.StructType{
+			Location:            common.StringLocation(tests.ContractPeople.Filename),
+			QualifiedIdentifier: "People.Person",
+			Fields:              []cadence.Field{{Identifier: "name", Type: cadence.StringType{}}},
+		})
+
+		res, err := s.Scripts.Execute(
+			tests.ScriptPersonArg.Source,
+			[]cadence.Value{person},
+			tests.ScriptPersonArg.Filename,
+			"emulator",
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, "\"Hello Alice\"", res.String())
+
+		person.StructType.Location = common.StringLocation("other.cdc")
+		_, err = s.Scripts.Execute(
+			tests.ScriptPersonArg.Source,
+			[]cadence.Value{person},
+			tests.ScriptPersonArg.Filename,
+			"emulator",
+		)
+		assert.EqualError(t, err, "type People.Person of arguments could not be resolved, other.cdc is not deployed on the network")
+	})
+
 	t.Run("Execute Script Invalid", func(t *testing.T) {
 		t.Parallel()
 		_, s := setupIntegration()
@@ -138,4 +269,48 @@ func TestScripts_Integration(t *testing.T) {
 		}
 
 	})
+	t.Run("Execute With Logs", func(t *testing.T) {
+		t.Parallel()
+		_, s := setupIntegration()
+
+		code := []byte(`
+			pub fun main(name: String): String {
+				log(name)
+				log("done")
+				return name
+			}`)
+		res, logs, err := s.Scripts.ExecuteWithLogs(code, []cadence.Value{cadence.NewString("Foo")}, "", "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "\"Foo\"", res.String())
+		assert.Equal(t, []string{"\"Foo\"", "\"done\""}, logs)
+	})
+
+	t.Run("Execute Forked With Logs", func(t *testing.T) {
+		t.Parallel()
+		state, s := setupIntegration()
+		setupAccounts(state, s)
+
+		a, _ := state.Accounts().ByName("Alice")
+		_, err := s.Accounts.AddContract(a, "Greeter", []byte(`
+			pub contract Greeter {
+				pub fun greet(): String {
+					log("greeting")
+					return "Hello"
+				}
+			}`), false)
+		assert.NoError(t, err)
+
+		code := []byte(fmt.Sprintf(`
+			import Greeter from 0x%s
+			pub fun main(): String { return Greeter.greet() }`, a.Address()))
+
+		fork, err := s.Scripts.Fork(code, "", "")
+		assert.NoError(t, err)
+
+		res, logs, err := fork.ExecuteWithLogs(code, nil, "", "")
+		assert.NoError(t, err)
+		assert.Equal(t, "\"Hello\"", res.String())
+		assert.Equal(t, []string{"\"greeting\""}, logs)
+	})
 }
@@ -19,7 +19,12 @@
 package services
 
 import (
+	"encoding/hex"
 	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/onflow/flow-cli/pkg/flowkit"
 
@@ -28,6 +33,8 @@ import (
 	"github.com/onflow/flow-cli/pkg/flowkit/contracts"
 
 	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	flowGo "github.com/onflow/flow-go/model/flow"
 
 	"github.com/onflow/flow-cli/pkg/flowkit/gateway"
 	"github.com/onflow/flow-cli/pkg/flowkit/output"
@@ -38,6 +45,7 @@ type Transactions struct {
 	gateway gateway.Gateway
 	state   *flowkit.State
 	logger  output.Logger
+	forked  bool // signatures of forked transactions use generated keys and are not recorded in the audit log
 }
 
 // NewTransactions returns a new transactions service.
@@ -104,34 +112,82 @@ func (t *Transactions) Build(
 		SetProposer(proposerAccount, proposerKeyIndex).
 		AddAuthorizers(authorizers).
 		SetGasLimit(gasLimit).
-		SetBlockReference(latestBlock)
+		SetBlockReference(latestBlock).
+		SetAccountGetter(t.gateway.GetAccount)
 
-	resolver, err := contracts.NewResolver(code)
+	return t.setScript(tx, code, codeFilename, args, network)
+}
+
+// Context fetches the latest block and the proposer key sequence number needed to build a transaction offline.
+func (t *Transactions) Context(proposer flow.Address, proposerKeyIndex int) (*flowkit.TransactionContext, error) {
+	latestBlock, err := t.gateway.GetLatestBlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest sealed block: %w", err)
+	}
+
+	proposerAccount, err := t.gateway.GetAccount(proposer)
 	if err != nil {
 		return nil, err
 	}
 
-	if resolver.HasFileImports() {
-		if network == "" {
-			return nil, fmt.Errorf("missing network, specify which network to use to resolve imports in transaction code")
-		}
-		if codeFilename == "" { // when used as lib with code we don't support imports
-			return nil, fmt.Errorf("resolving imports in transactions not supported")
-		}
+	if proposerKeyIndex < 0 || proposerKeyIndex >= len(proposerAccount.Keys) {
+		return nil, fmt.Errorf("key index %d does not exist on account %s", proposerKeyIndex, proposer)
+	}
 
-		contractsNetwork, err := t.state.DeploymentContractsByNetwork(network)
-		if err != nil {
-			return nil, err
-		}
+	return &flowkit.TransactionContext{
+		ReferenceBlockID: latestBlock.ID.String(),
+		Proposer:         proposer,
+		KeyIndex:         proposerKeyIndex,
+		SequenceNumber:   proposerAccount.Keys[proposerKeyIndex].SequenceNumber,
+	}, nil
+}
 
-		code, err = resolver.ResolveImports(
-			codeFilename,
-			contractsNetwork,
-			t.state.AliasesForNetwork(network),
-		)
-		if err != nil {
-			return nil, err
-		}
+// BuildOffline builds a transaction using the provided context instead of fetching it from the network.
+func (t *Transactions) BuildOffline(
+	ctx *flowkit.TransactionContext,
+	authorizers []flow.Address,
+	payer flow.Address,
+	code []byte,
+	codeFilename string,
+	gasLimit uint64,
+	args []cadence.Value,
+	network string,
+) (*flowkit.Transaction, error) {
+	blockID, err := hex.DecodeString(strings.TrimPrefix(ctx.ReferenceBlockID, "0x"))
+	if err != nil || len(blockID) != len(flow.EmptyID) {
+		return nil, fmt.Errorf("invalid reference block ID: %s", ctx.ReferenceBlockID)
+	}
+
+	tx := flowkit.NewTransaction().
+		SetPayer(payer).
+		SetProposalKey(&flowkit.ProposalKey{
+			Address:        ctx.Proposer,
+			Index:          ctx.KeyIndex,
+			SequenceNumber: ctx.SequenceNumber,
+		}).
+		AddAuthorizers(authorizers).
+		SetGasLimit(gasLimit).
+		SetReferenceBlockID(flow.BytesToID(blockID))
+
+	return t.setScript(tx, code, codeFilename, args, network)
+}
+
+// setScript resolves imports in the code and sets it with arguments on the transaction.
+func (t *Transactions) setScript(
+	tx *flowkit.Transaction,
+	code []byte,
+	codeFilename string,
+	args []cadence.Value,
+	network string,
+) (*flowkit.Transaction, error) {
+	code, sourceMap, err := t.resolveImports(code, codeFilename, network)
+	if err != nil {
+		return nil, err
+	}
+
+	args, err = resolveArgumentTypes(t.state, args, codeFilename, network)
+	if err != nil {
+		return nil, err
 	}
 
 	err = tx.SetScriptWithArgs(code, args)
@@ -139,29 +195,122 @@ func (t *Transactions) Build(
 		return nil, err
 	}
 
-	return tx, nil
+	return tx.SetSourceMap(sourceMap), nil
+}
+
+// resolveImports replaces file imports in the transaction code with addresses for the network.
+//
+// The returned source map maps positions in the resolved code back to the original code.
+func (t *Transactions) resolveImports(code []byte, codeFilename string, network string) ([]byte, *flowkit.SourceMap, error) {
+	resolver, err := contracts.NewResolver(code)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !resolver.HasFileImports() {
+		return code, flowkit.NewSourceMap(codeFilename, code), nil
+	}
+
+	if network == "" {
+		return nil, nil, fmt.Errorf("missing network, specify which network to use to resolve imports in transaction code")
+	}
+	if codeFilename == "" { // when used as lib with code we don't support imports
+		return nil, nil, fmt.Errorf("resolving imports in transactions not supported")
+	}
+
+	contractsNetwork, err := t.state.DeploymentContractsByNetwork(network)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resolved, err := resolver.ResolveImports(
+		codeFilename,
+		contractsNetwork,
+		t.state.AliasesForNetwork(network),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resolved, resolver.SourceMap(), nil
+}
+
+// mapExecutionError replaces the transaction result error with an execution error mapped to the original source code.
+func mapExecutionError(result *flow.TransactionResult, sourceMap *flowkit.SourceMap) {
+	if result != nil {
+		result.Error = flowkit.NewExecutionError(result.Error, sourceMap)
+	}
 }
 
 // Sign transaction payload using the signer account.
+//
+// The payload can be a transaction envelope or a RLP encoded transaction. If a signing policy
+// is configured the transaction is evaluated before signing, templates in the policy are resolved
+// for the network of the envelope.
+//
+// The signer key is compared with the on-chain key of the signer account before signing.
 func (t *Transactions) Sign(
 	signer *flowkit.Account,
 	payload []byte,
 	approveSigning bool,
+) (*flowkit.Transaction, error) {
+	return t.SignForNetwork(signer, payload, approveSigning, "")
+}
+
+// SignForNetwork signs the transaction payload like Sign, templates in the signing policy are
+// resolved for the provided network if the payload has no envelope.
+func (t *Transactions) SignForNetwork(
+	signer *flowkit.Account,
+	payload []byte,
+	approveSigning bool,
+	network string,
+) (*flowkit.Transaction, error) {
+	return t.sign(signer, payload, approveSigning, network, t.gateway.GetAccount)
+}
+
+// SignOffline signs the transaction payload like SignForNetwork without network access,
+// the signer key is not compared with the on-chain key.
+func (t *Transactions) SignOffline(
+	signer *flowkit.Account,
+	payload []byte,
+	approveSigning bool,
+	network string,
+) (*flowkit.Transaction, error) {
+	return t.sign(signer, payload, approveSigning, network, nil)
+}
+
+func (t *Transactions) sign(
+	signer *flowkit.Account,
+	payload []byte,
+	approveSigning bool,
+	network string,
+	getAccount func(flow.Address) (*flow.Account, error),
 ) (*flowkit.Transaction, error) {
 	if t.state == nil {
 		return nil, fmt.Errorf("missing configuration, initialize it: flow state init")
 	}
 
-	tx, err := flowkit.NewTransactionFromPayload(payload)
+	envelope, err := flowkit.ParseTransactionEnvelope(payload)
 	if err != nil {
 		return nil, err
 	}
+	tx := envelope.Transaction()
 
-	err = tx.SetSigner(signer)
+	if envelope.Network != "" {
+		network = envelope.Network
+	}
+
+	err = t.checkPolicy(tx, network)
 	if err != nil {
 		return nil, err
 	}
 
+	err = tx.SetAccountGetter(getAccount).SetSigner(signer)
+	if err != nil {
+		return nil, err
+	}
+	tx.SetAuditLog(t.auditLog())
+
 	if approveSigning {
 		return tx.Sign()
 	}
@@ -174,31 +323,311 @@ func (t *Transactions) Sign(
 }
 
 // SendSigned sends the transaction that is already signed.
+//
+// The payload can be a transaction envelope or a RLP encoded transaction, the transaction
+// is only sent if all the required signers signed it and it didn't expire.
 func (t *Transactions) SendSigned(
 	payload []byte,
 ) (*flow.Transaction, *flow.TransactionResult, error) {
-	tx, err := flowkit.NewTransactionFromPayload(payload)
+	sentTx, err := t.SubmitSigned(payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	t.logger.StartProgress("Waiting for transaction to be sealed...")
+	defer t.logger.StopProgress()
+
+	res, err := t.gateway.GetTransactionResult(sentTx, true)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	return sentTx, res, nil
+}
+
+// SubmitSigned sends the transaction that is already signed and returns the sent transaction without waiting for the result.
+//
+// The same checks as in SendSigned are done before the transaction is sent.
+func (t *Transactions) SubmitSigned(payload []byte) (*flow.Transaction, error) {
+	envelope, err := flowkit.ParseTransactionEnvelope(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	// check expiry first since collecting the missing signatures is pointless for an expired transaction
+	err = t.CheckExpiry(envelope.Transaction().FlowTransaction())
+	if err != nil {
+		return nil, err
+	}
+
+	missing := envelope.MissingSigners()
+	if len(missing) > 0 {
+		signers := make([]string, 0, len(missing))
+		for _, s := range missing {
+			signers = append(signers, s.String())
+		}
+
+		return nil, fmt.Errorf("transaction is missing signatures from: %s", strings.Join(signers, ", "))
+	}
+	tx := envelope.Transaction()
+
 	t.logger.StartProgress(fmt.Sprintf("Sending transaction with ID: %s", tx.FlowTransaction().ID()))
 	defer t.logger.StopProgress()
 
-	sentTx, err := t.gateway.SendSignedTransaction(tx)
+	return t.gateway.SendSignedTransaction(tx)
+}
+
+// WaitPollInterval is the interval between requests for the transaction result while waiting for a status.
+var WaitPollInterval = time.Second
+
+// WaitResult is the outcome of waiting for a single transaction.
+type WaitResult struct {
+	ID     flow.Identifier
+	Tx     *flow.Transaction
+	Result *flow.TransactionResult
+	Err    error
+}
+
+// Wait waits concurrently for all the transactions with the provided IDs to reach the status.
+//
+// Waiting for a transaction stops when the timeout expires, in which case the last fetched result
+// is returned together with an error. Expired transactions are returned as soon as they are reported expired.
+func (t *Transactions) Wait(
+	ids []flow.Identifier,
+	status flow.TransactionStatus,
+	timeout time.Duration,
+) []WaitResult {
+	t.logger.StartProgress(fmt.Sprintf(
+		"Waiting for %d transactions to be %s...", len(ids), strings.ToLower(status.String()),
+	))
+	defer t.logger.StopProgress()
+
+	deadline := time.Now().Add(timeout)
+	results := make([]WaitResult, len(ids))
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func(i int, id flow.Identifier) {
+			defer wg.Done()
+			tx, res, err := t.waitForStatus(id, status, deadline)
+			results[i] = WaitResult{ID: id, Tx: tx, Result: res, Err: err}
+		}(i, id)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// waitForStatus polls the transaction result until the transaction reaches the status or the deadline passes.
+func (t *Transactions) waitForStatus(
+	id flow.Identifier,
+	status flow.TransactionStatus,
+	deadline time.Time,
+) (*flow.Transaction, *flow.TransactionResult, error) {
+	var tx *flow.Transaction
+	var res *flow.TransactionResult
+	var err error
+
+	for {
+		if tx == nil {
+			tx, err = t.gateway.GetTransaction(id)
+		}
+		if tx != nil {
+			res, err = t.gateway.GetTransactionResult(tx, false)
+		}
+
+		if res != nil && (res.Status >= status || res.Status == flow.TransactionStatusExpired) {
+			return tx, res, nil
+		}
+
+		if time.Now().Add(WaitPollInterval).After(deadline) {
+			if err != nil {
+				return tx, res, fmt.Errorf("timeout waiting for transaction: %w", err)
+			}
+
+			last := flow.TransactionStatusUnknown
+			if res != nil {
+				last = res.Status
+			}
+
+			return tx, res, fmt.Errorf(
+				"timeout waiting for transaction to be %s, last status is %s",
+				strings.ToLower(status.String()),
+				strings.ToLower(last.String()),
+			)
+		}
+
+		time.Sleep(WaitPollInterval)
+	}
+}
+
+// TransactionExpiredError is returned when the reference block of the transaction is outside the expiry window.
+type TransactionExpiredError struct {
+	ReferenceHeight uint64
+	LatestHeight    uint64
+}
+
+func (e *TransactionExpiredError) Error() string {
+	return fmt.Sprintf(
+		"transaction expired, the reference block at height %d is %d blocks behind the latest block at height %d (expiry is %d blocks), rebuild it using: flow transactions refresh",
+		e.ReferenceHeight,
+		e.LatestHeight-e.ReferenceHeight,
+		e.LatestHeight,
+		flowGo.DefaultTransactionExpiry,
+	)
+}
+
+// CheckExpiry returns TransactionExpiredError if the reference block of the transaction is too far behind the latest block.
+func (t *Transactions) CheckExpiry(tx *flow.Transaction) error {
+	reference, err := t.gateway.GetBlockByID(tx.ReferenceBlockID)
+	if err != nil {
+		return fmt.Errorf("failed to get the reference block %s: %w", tx.ReferenceBlockID, err)
+	}
+
+	latest, err := t.gateway.GetLatestBlock()
+	if err != nil {
+		return err
+	}
+
+	if latest.Height >= reference.Height+flowGo.DefaultTransactionExpiry {
+		return &TransactionExpiredError{
+			ReferenceHeight: reference.Height,
+			LatestHeight:    latest.Height,
+		}
+	}
+
+	return nil
+}
+
+// Refresh rebuilds the transaction with the latest block as the reference block and the current proposer sequence number.
+//
+// The payload can be a transaction envelope or a RLP encoded transaction. The script, arguments and roles are kept,
+// but the collected signatures are removed because they are not valid for the rebuilt transaction.
+// The signers whose signatures were removed are returned, as they have to sign the transaction again.
+func (t *Transactions) Refresh(payload []byte) (*flowkit.TransactionEnvelope, []*flowkit.EnvelopeSigner, error) {
+	envelope, err := flowkit.ParseTransactionEnvelope(payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resign := make([]*flowkit.EnvelopeSigner, 0)
+	for _, s := range envelope.Signers {
+		if s.Signed {
+			resign = append(resign, s)
+		}
+	}
+
+	t.logger.StartProgress("Refreshing transaction...")
+	defer t.logger.StopProgress()
+
+	block, err := t.gateway.GetLatestBlock()
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := t.gateway.GetTransactionResult(sentTx, true)
+	tx := envelope.Transaction()
+	proposalKey := tx.FlowTransaction().ProposalKey
+
+	proposer, err := t.gateway.GetAccount(proposalKey.Address)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return sentTx, res, nil
+	var key *flow.AccountKey
+	for _, k := range proposer.Keys {
+		if k.Index == proposalKey.KeyIndex {
+			key = k
+		}
+	}
+	if key == nil {
+		return nil, nil, fmt.Errorf("proposal key %d does not exist on account %s", proposalKey.KeyIndex, proposalKey.Address)
+	}
+
+	tx.FlowTransaction().PayloadSignatures = nil
+	tx.FlowTransaction().EnvelopeSignatures = nil
+	tx.SetBlockReference(block).
+		SetProposalKey(&flowkit.ProposalKey{
+			Address:        proposalKey.Address,
+			Index:          key.Index,
+			SequenceNumber: key.SequenceNumber,
+		})
+
+	envelope.SetTransaction(tx)
+
+	return envelope, resign, nil
+}
+
+// TransactionSignatureVerification is the result of verifying a transaction signature with the on-chain account key.
+type TransactionSignatureVerification struct {
+	Signature flow.TransactionSignature
+	// Type is either payload or envelope signature.
+	Type  string
+	Valid bool
+	// Err is set if the signature couldn't be verified, for example if the key doesn't exist.
+	Err error
+}
+
+// VerifySignatures verifies all the transaction signatures with the account keys fetched from the network.
+func (t *Transactions) VerifySignatures(tx *flow.Transaction) []*TransactionSignatureVerification {
+	t.logger.StartProgress("Verifying signatures...")
+	defer t.logger.StopProgress()
+
+	accounts := make(map[flow.Address]*flow.Account)
+	verify := func(signature flow.TransactionSignature, message []byte) (bool, error) {
+		account, ok := accounts[signature.Address]
+		if !ok {
+			var err error
+			account, err = t.gateway.GetAccount(signature.Address)
+			if err != nil {
+				return false, fmt.Errorf("failed to get account %s: %w", signature.Address, err)
+			}
+			accounts[signature.Address] = account
+		}
+
+		if signature.KeyIndex < 0 || signature.KeyIndex >= len(account.Keys) {
+			return false, fmt.Errorf("key index %d does not exist on account %s", signature.KeyIndex, signature.Address)
+		}
+
+		key := account.Keys[signature.KeyIndex]
+		if key.Revoked {
+			return false, fmt.Errorf("key index %d is revoked on account %s", signature.KeyIndex, signature.Address)
+		}
+
+		hasher, err := crypto.NewHasher(key.HashAlgo)
+		if err != nil {
+			return false, err
+		}
+
+		return key.PublicKey.Verify(signature.Signature, append(flow.TransactionDomainTag[:], message...), hasher)
+	}
+
+	verifications := make([]*TransactionSignatureVerification, 0)
+	for _, s := range tx.PayloadSignatures {
+		valid, err := verify(s, tx.PayloadMessage())
+		verifications = append(verifications, &TransactionSignatureVerification{
+			Signature: s,
+			Type:      flowkit.SignaturePayload,
+			Valid:     valid,
+			Err:       err,
+		})
+	}
+
+	for _, s := range tx.EnvelopeSignatures {
+		valid, err := verify(s, tx.EnvelopeMessage())
+		verifications = append(verifications, &TransactionSignatureVerification{
+			Signature: s,
+			Type:      flowkit.SignatureEnvelope,
+			Valid:     valid,
+			Err:       err,
+		})
+	}
+
+	return verifications
 }
 
 // Send a transaction code using the signer account and arguments for the specified network.
+//
+// The signer is the proposer, payer and the only authorizer of the transaction.
 func (t *Transactions) Send(
 	signer *flowkit.Account,
 	code []byte,
@@ -207,33 +636,92 @@ func (t *Transactions) Send(
 	args []cadence.Value,
 	network string,
 ) (*flow.Transaction, *flow.TransactionResult, error) {
-	if t.state == nil {
-		return nil, nil, fmt.Errorf("missing configuration, initialize it: flow state init")
-	}
-
-	signerKeyIndex := signer.Key().Index()
-
-	tx, err := t.Build(
-		signer.Address(),
-		[]flow.Address{signer.Address()},
-		signer.Address(),
-		signerKeyIndex,
+	return t.SendWithRoles(
+		flowkit.NewTransactionSingleAccountRole(signer),
 		code,
 		codeFilename,
 		gasLimit,
 		args,
 		network,
 	)
+}
+
+// SendWithRoles sends a transaction code using the accounts for transaction roles and arguments for the specified network.
+//
+// Accounts having multiple roles sign the transaction only once.
+func (t *Transactions) SendWithRoles(
+	accounts *flowkit.TransactionAccountRoles,
+	code []byte,
+	codeFilename string,
+	gasLimit uint64,
+	args []cadence.Value,
+	network string,
+) (*flow.Transaction, *flow.TransactionResult, error) {
+	signed, sentTx, err := t.submitWithRoles(accounts, code, codeFilename, gasLimit, args, network)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	err = tx.SetSigner(signer)
+	t.logger.StartProgress("Waiting for transaction to be sealed...")
+
+	res, err := t.gateway.GetTransactionResult(sentTx, true)
+	mapExecutionError(res, signed.SourceMap())
+
+	t.logger.StopProgress()
+
+	return sentTx, res, err
+}
+
+// Logs returns the output of the Cadence log function of a transaction sent or simulated by the service.
+//
+// Logs are only captured by the emulator gateway, for other gateways nil is returned.
+func (t *Transactions) Logs(id flow.Identifier) []string {
+	programLogger, ok := t.gateway.(gateway.ProgramLogger)
+	if !ok {
+		return nil
+	}
+
+	logs := programLogger.TransactionLogs(id)
+	if logs == nil {
+		return []string{}
+	}
+
+	return logs
+}
+
+// SubmitWithRoles sends a transaction code using the accounts for transaction roles and arguments for the specified network
+// and returns the sent transaction without waiting for the result.
+func (t *Transactions) SubmitWithRoles(
+	accounts *flowkit.TransactionAccountRoles,
+	code []byte,
+	codeFilename string,
+	gasLimit uint64,
+	args []cadence.Value,
+	network string,
+) (*flow.Transaction, error) {
+	_, sentTx, err := t.submitWithRoles(accounts, code, codeFilename, gasLimit, args, network)
+	return sentTx, err
+}
+
+// submitWithRoles builds, signs and sends the transaction, both the signed and the sent transaction are returned.
+func (t *Transactions) submitWithRoles(
+	accounts *flowkit.TransactionAccountRoles,
+	code []byte,
+	codeFilename string,
+	gasLimit uint64,
+	args []cadence.Value,
+	network string,
+) (*flowkit.Transaction, *flow.Transaction, error) {
+	if t.state == nil {
+		return nil, nil, fmt.Errorf("missing configuration, initialize it: flow state init")
+	}
+
+	policy, err := t.loadPolicy(network)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	signed, err := tx.Sign()
+	signed, err := t.buildSigned(accounts, code, codeFilename, gasLimit, args, network, policy, t.auditLog())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -247,11 +735,589 @@ func (t *Transactions) Send(
 		return nil, nil, err
 	}
 
-	t.logger.StartProgress("Waiting for transaction to be sealed...")
-
-	res, err := t.gateway.GetTransactionResult(sentTx, true)
-
-	t.logger.StopProgress()
+	return signed, sentTx, nil
+}
+
+// buildSigned builds the transaction and signs it with all the accounts for transaction roles.
+//
+// The transaction is evaluated against the policy before signing if the policy is not nil,
+// and the signatures are recorded in the audit log if the audit log is not nil.
+func (t *Transactions) buildSigned(
+	accounts *flowkit.TransactionAccountRoles,
+	code []byte,
+	codeFilename string,
+	gasLimit uint64,
+	args []cadence.Value,
+	network string,
+	policy *flowkit.Policy,
+	auditLog *flowkit.AuditLog,
+) (*flowkit.Transaction, error) {
+	tx, err := t.Build(
+		accounts.Proposer.Address(),
+		accounts.AuthorizerAddresses(),
+		accounts.Payer.Address(),
+		accounts.Proposer.Key().Index(),
+		code,
+		codeFilename,
+		gasLimit,
+		args,
+		network,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, signer := range accounts.Signers() {
+		err = tx.AddSigner(signer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if policy != nil {
+		err = policy.Evaluate(tx.FlowTransaction())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tx.SetAuditLog(auditLog).Sign()
+}
+
+// loadPolicy loads the signing policy from the configuration, nil is returned if no policy is configured.
+//
+// Templates in the policy are resolved for the network.
+func (t *Transactions) loadPolicy(network string) (*flowkit.Policy, error) {
+	path := t.state.Config().Policy
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := t.state.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing policy from %s: %w", path, err)
+	}
+
+	policy, err := flowkit.ParsePolicy(data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = policy.Resolve(t.state.Accounts(), func(template string) ([]byte, error) {
+		code, err := t.state.ReadFile(template)
+		if err != nil {
+			return nil, err
+		}
+
+		resolved, _, err := t.resolveImports(code, template, network)
+		return resolved, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// checkPolicy evaluates the transaction against the configured signing policy.
+func (t *Transactions) checkPolicy(tx *flowkit.Transaction, network string) error {
+	policy, err := t.loadPolicy(network)
+	if err != nil || policy == nil {
+		return err
+	}
+
+	return policy.Evaluate(tx.FlowTransaction())
+}
+
+// auditLog returns the audit log recording signatures, nil if signatures are not recorded.
+func (t *Transactions) auditLog() *flowkit.AuditLog {
+	if t.forked {
+		return nil
+	}
+
+	return t.state.AuditLog()
+}
+
+// DryRunResult is the outcome of a transaction executed without submitting it.
+type DryRunResult struct {
+	Tx     *flow.Transaction
+	Result *flow.TransactionResult
+	// ComputationUsed is the lowest gas limit the transaction succeeds with, zero if it fails with any limit.
+	ComputationUsed uint64
+}
+
+// DryRun executes the transaction with the gas limit without submitting it and estimates the computation used.
+//
+// The gateway must be able to simulate transactions, which is only supported by the emulator gateway.
+func (t *Transactions) DryRun(
+	accounts *flowkit.TransactionAccountRoles,
+	code []byte,
+	codeFilename string,
+	gasLimit uint64,
+	args []cadence.Value,
+	network string,
+) (*DryRunResult, error) {
+	if t.state == nil {
+		return nil, fmt.Errorf("missing configuration, initialize it: flow state init")
+	}
+
+	simulator, ok := t.gateway.(gateway.Simulator)
+	if !ok {
+		return nil, fmt.Errorf("dry run is not supported by the gateway, use the emulator gateway")
+	}
+
+	policy, err := t.loadPolicy(network)
+	if err != nil {
+		return nil, err
+	}
+
+	t.logger.StartProgress("Executing transaction...")
+	defer t.logger.StopProgress()
+
+	simulate := func(gasLimit uint64, policy *flowkit.Policy) (*flowkit.Transaction, *flow.TransactionResult, error) {
+		tx, err := t.buildSigned(accounts, code, codeFilename, gasLimit, args, network, policy, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		result, err := simulator.SimulateTransaction(tx)
+		return tx, result, err
+	}
+
+	// the policy is only evaluated for the requested transaction, estimating only changes the gas limit
+	tx, result, err := simulate(gasLimit, policy)
+	if err != nil {
+		return nil, err
+	}
+	mapExecutionError(result, tx.SourceMap())
+
+	dryRun := &DryRunResult{
+		Tx:     tx.FlowTransaction(),
+		Result: result,
+	}
+
+	t.logger.StartProgress("Estimating computation used...")
+
+	// the transaction fails for another reason than the gas limit
+	_, result, err = simulate(flowkit.MaxGasLimit, nil)
+	if err != nil {
+		return nil, err
+	}
+	if result.Error != nil {
+		return dryRun, nil
+	}
+
+	// find the lowest gas limit the transaction succeeds with
+	low, high := uint64(0), flowkit.MaxGasLimit
+	for low+1 < high {
+		mid := (low + high) / 2
+
+		_, result, err = simulate(mid, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if result.Error == nil {
+			high = mid
+		} else {
+			low = mid
+		}
+	}
+
+	dryRun.ComputationUsed = high
+	return dryRun, nil
+}
+
+// BatchResult is the outcome of a single transaction sent in a batch.
+type BatchResult struct {
+	Tx     *flow.Transaction
+	Result *flow.TransactionResult
+	Err    error
+}
+
+// SendBatch sends the transaction code count times concurrently using the signer account.
+//
+// Every transaction uses a distinct proposal key from the keys on the signer account
+// matching the configured key, so the number of keys limits how many transactions are in flight.
+func (t *Transactions) SendBatch(
+	signer *flowkit.Account,
+	code []byte,
+	codeFilename string,
+	gasLimit uint64,
+	args []cadence.Value,
+	network string,
+	count int,
+) ([]BatchResult, error) {
+	if t.state == nil {
+		return nil, fmt.Errorf("missing configuration, initialize it: flow state init")
+	}
+
+	if count < 1 {
+		return nil, fmt.Errorf("number of transactions to send must be greater than zero")
+	}
+
+	code, sourceMap, err := t.resolveImports(code, codeFilename, network)
+	if err != nil {
+		return nil, err
+	}
+
+	args, err = resolveArgumentTypes(t.state, args, codeFilename, network)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := t.gateway.GetAccount(signer.Address())
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := flowkit.NewProposalKeyPool(account, signer.Key().Index(), t.gateway.GetAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	signers, err := keySigners(signer, account)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := t.loadPolicy(network)
+	if err != nil {
+		return nil, err
+	}
+
+	referenceBlock := t.referenceBlocks()
+
+	t.logger.StartProgress(fmt.Sprintf(
+		"Sending %d transactions using %d proposal keys...", count, pool.Size(),
+	))
+	defer t.logger.StopProgress()
+
+	results := make([]BatchResult, count)
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			key, err := pool.Acquire()
+			if err != nil {
+				results[i] = BatchResult{Err: err}
+				return
+			}
+
+			tx, res, err := t.sendWithProposalKey(signers[key.Index], key, referenceBlock, code, gasLimit, args, policy)
+			pool.Release(key, err != nil || (res != nil && res.Error != nil))
+			mapExecutionError(res, sourceMap)
+			results[i] = BatchResult{Tx: tx, Result: res, Err: err}
+		}(i)
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// referenceBlockRefresh is how long a reference block is used by concurrently sent transactions
+// before the latest block is fetched again, so transactions waiting for a proposal key don't expire.
+const referenceBlockRefresh = 30 * time.Second
+
+// referenceBlocks returns a function returning the latest block to reference by transactions,
+// the block is fetched again after it was used for the refresh interval.
+func (t *Transactions) referenceBlocks() func() (*flow.Block, error) {
+	var mu sync.Mutex
+	var block *flow.Block
+	var fetched time.Time
+
+	return func() (*flow.Block, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if block == nil || time.Since(fetched) > referenceBlockRefresh {
+			latest, err := t.gateway.GetLatestBlock()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get latest sealed block: %w", err)
+			}
+			block, fetched = latest, time.Now()
+		}
+
+		return block, nil
+	}
+}
+
+// keySigners returns signer accounts using the same key as the signer at the indexes of all the account keys.
+func keySigners(signer *flowkit.Account, account *flow.Account) (map[int]*flowkit.Account, error) {
+	signers := make(map[int]*flowkit.Account)
+	for _, key := range account.Keys {
+		keyConf := signer.Key().ToConfig()
+		keyConf.Index = key.Index
+
+		accountKey, err := flowkit.NewAccountKey(keyConf)
+		if err != nil {
+			return nil, err
+		}
+
+		keySigner := &flowkit.Account{}
+		keySigner.SetName(signer.Name())
+		keySigner.SetAddress(signer.Address())
+		keySigner.SetKey(accountKey)
+		signers[key.Index] = keySigner
+	}
+
+	return signers, nil
+}
+
+// ManifestResult is the outcome of a transaction from the manifest.
+//
+// Skipped transactions were not sent because a dependency failed or sending stopped after an error.
+type ManifestResult struct {
+	Name    string
+	Tx      *flow.Transaction
+	Result  *flow.TransactionResult
+	Err     error
+	Skipped bool
+}
+
+// Failed returns true if the transaction wasn't sent, wasn't sealed or its execution failed.
+func (r *ManifestResult) Failed() bool {
+	return r.Skipped || r.Err != nil || (r.Result != nil && r.Result.Error != nil)
+}
+
+// manifestTransaction is a transaction from the manifest with the loaded code, arguments and signer.
+type manifestTransaction struct {
+	*flowkit.ManifestTransaction
+	code   []byte
+	args   []cadence.Value
+	signer *flowkit.Account
+}
+
+// SendManifest sends all the transactions from the manifest and waits for them to be sealed.
+//
+// Transactions are sent after all their dependencies were sealed without an error and at most concurrency
+// transactions are in flight at the same time. Transactions signed by the same account are sent one after another
+// because they use the same proposal key. If continueOnError is false, no transactions are sent after the first failure,
+// otherwise only the transactions depending on a failed transaction are skipped.
+func (t *Transactions) SendManifest(
+	manifest *flowkit.TransactionManifest,
+	network string,
+	concurrency int,
+	continueOnError bool,
+) ([]ManifestResult, error) {
+	if t.state == nil {
+		return nil, fmt.Errorf("missing configuration, initialize it: flow state init")
+	}
+
+	if concurrency < 1 {
+		return nil, fmt.Errorf("concurrency must be greater than zero")
+	}
+
+	// load all the transactions first so invalid transactions are reported before anything is sent
+	txs := make([]*manifestTransaction, 0, len(manifest.Transactions))
+	for _, mtx := range manifest.Transactions {
+		tx, err := t.loadManifestTransaction(mtx, network)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %s: %w", mtx.Name, err)
+		}
+		txs = append(txs, tx)
+	}
+
+	policy, err := t.loadPolicy(network)
+	if err != nil {
+		return nil, err
+	}
+
+	t.logger.StartProgress(fmt.Sprintf("Sending %d transactions...", len(txs)))
+	defer t.logger.StopProgress()
+
+	results := make([]ManifestResult, len(txs))
+	done := make(map[string]chan struct{})
+	indexes := make(map[string]int)
+	signerLocks := make(map[flow.Address]*sync.Mutex)
+	for i, tx := range txs {
+		done[tx.Name] = make(chan struct{})
+		indexes[tx.Name] = i
+		signerLocks[tx.signer.Address()] = &sync.Mutex{}
+	}
+
+	slots := make(chan struct{}, concurrency)
+	var stopped int32
+
+	var wg sync.WaitGroup
+	for i, tx := range txs {
+		wg.Add(1)
+		go func(i int, tx *manifestTransaction) {
+			defer wg.Done()
+			defer close(done[tx.Name])
+
+			results[i] = ManifestResult{Name: tx.Name}
+
+			for _, dep := range tx.DependsOn {
+				<-done[dep]
+				if results[indexes[dep]].Failed() {
+					results[i].Skipped = true
+					results[i].Err = fmt.Errorf("dependency %s failed", dep)
+					return
+				}
+			}
+
+			lock := signerLocks[tx.signer.Address()]
+			lock.Lock()
+			defer lock.Unlock()
+
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			if atomic.LoadInt32(&stopped) == 1 {
+				results[i].Skipped = true
+				results[i].Err = fmt.Errorf("not sent because a previous transaction failed")
+				return
+			}
+
+			results[i].Tx, results[i].Result, results[i].Err = t.sendManifestTransaction(tx, network, policy)
+			if results[i].Failed() && !continueOnError {
+				atomic.StoreInt32(&stopped, 1)
+			}
+		}(i, tx)
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// loadManifestTransaction reads the code of the manifest transaction, parses the arguments and finds the signer.
+//
+// Address references in the arguments are resolved on the network.
+func (t *Transactions) loadManifestTransaction(mtx *flowkit.ManifestTransaction, network string) (*manifestTransaction, error) {
+	code, err := t.state.ReaderWriter().ReadFile(mtx.File)
+	if err != nil {
+		return nil, fmt.Errorf("error loading transaction file: %w", err)
+	}
+
+	var args []cadence.Value
+	if mtx.ArgsJSON != "" {
+		args, err = flowkit.ParseArgumentsWithResolver(nil, mtx.ArgsJSON, t.state.AddressResolver(network))
+	} else {
+		parser := flowkit.NewArgumentParserWithReader(mtx.File, code, t.state.ReaderWriter()).
+			SetAddressResolver(t.state.AddressResolver(network))
+		args, err = parser.Parse(mtx.Args)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing transaction arguments: %w", err)
+	}
+
+	if mtx.Signer == "" {
+		return nil, fmt.Errorf("missing signer")
+	}
+
+	signer, err := t.state.Accounts().ByName(mtx.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &manifestTransaction{
+		ManifestTransaction: mtx,
+		code:                code,
+		args:                args,
+		signer:              signer,
+	}, nil
+}
+
+// sendManifestTransaction builds, signs and sends the manifest transaction and waits for the result.
+func (t *Transactions) sendManifestTransaction(
+	tx *manifestTransaction,
+	network string,
+	policy *flowkit.Policy,
+) (*flow.Transaction, *flow.TransactionResult, error) {
+	signed, err := t.buildSigned(
+		flowkit.NewTransactionSingleAccountRole(tx.signer),
+		tx.code,
+		tx.File,
+		tx.GasLimit,
+		tx.args,
+		network,
+		policy,
+		t.auditLog(),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sentTx, err := t.gateway.SendSignedTransaction(signed)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := t.gateway.GetTransactionResult(sentTx, true)
+	mapExecutionError(res, signed.SourceMap())
 
 	return sentTx, res, err
 }
+
+// sendWithProposalKey builds, signs and sends a transaction with the provided proposal key and waits for the result.
+//
+// The transaction is evaluated against the policy before signing if the policy is not nil.
+func (t *Transactions) sendWithProposalKey(
+	signer *flowkit.Account,
+	key *flowkit.ProposalKey,
+	referenceBlock func() (*flow.Block, error),
+	code []byte,
+	gasLimit uint64,
+	args []cadence.Value,
+	policy *flowkit.Policy,
+) (*flow.Transaction, *flow.TransactionResult, error) {
+	block, err := referenceBlock()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signed, err := t.signWithProposalKey(signer, key, block, code, gasLimit, args, policy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sentTx, err := t.gateway.SendSignedTransaction(signed)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := t.gateway.GetTransactionResult(sentTx, true)
+	return sentTx, res, err
+}
+
+// signWithProposalKey builds and signs a transaction with the provided proposal key.
+//
+// The transaction is evaluated against the policy before signing if the policy is not nil.
+func (t *Transactions) signWithProposalKey(
+	signer *flowkit.Account,
+	key *flowkit.ProposalKey,
+	block *flow.Block,
+	code []byte,
+	gasLimit uint64,
+	args []cadence.Value,
+	policy *flowkit.Policy,
+) (*flowkit.Transaction, error) {
+	tx := flowkit.NewTransaction().
+		SetPayer(signer.Address()).
+		SetProposalKey(key).
+		AddAuthorizers([]flow.Address{signer.Address()}).
+		SetGasLimit(gasLimit).
+		SetBlockReference(block)
+
+	err := tx.SetScriptWithArgs(code, args)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.SetSigner(signer)
+	if err != nil {
+		return nil, err
+	}
+
+	if policy != nil {
+		err = policy.Evaluate(tx.FlowTransaction())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tx.SetAuditLog(t.auditLog()).Sign()
+}
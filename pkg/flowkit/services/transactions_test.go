@@ -19,9 +19,12 @@
 package services
 
 import (
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/onflow/flow-cli/pkg/flowkit/config"
 
@@ -29,6 +32,7 @@ import (
 
 	"github.com/onflow/cadence"
 	"github.com/onflow/flow-go-sdk"
+	flowGo "github.com/onflow/flow-go/model/flow"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
@@ -59,7 +63,8 @@ func TestTransactions(t *testing.T) {
 
 	t.Run("Send Transaction args", func(t *testing.T) {
 		t.Parallel()
-		_, s, gw := setup()
+		state, s, gw := setup()
+		serviceAcc, _ := state.EmulatorServiceAccount()
 
 		var txID flow.Identifier
 		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
@@ -67,7 +72,7 @@ func TestTransactions(t *testing.T) {
 			arg, err := tx.FlowTransaction().Argument(0)
 			assert.NoError(t, err)
 			assert.Equal(t, arg.String(), "\"Bar\"")
-			assert.Equal(t, tx.Signer().Address(), serviceAddress)
+			assert.Equal(t, tx.Signers()[0].Address(), serviceAddress)
 			assert.Equal(t, len(string(tx.FlowTransaction().Script)), 227)
 
 			t := tests.NewTransaction()
@@ -245,3 +250,698 @@ func TestTransactions_Integration(t *testing.T) {
 	})
 
 }
+
+func TestTransactionsSendWithRoles_Integration(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Send with Roles", func(t *testing.T) {
+		t.Parallel()
+		state, s := setupIntegration()
+		setupAccounts(state, s)
+
+		a, _ := state.Accounts().ByName("Alice")
+		b, _ := state.Accounts().ByName("Bob")
+		c, _ := state.Accounts().ByName("Charlie")
+
+		roles := &flowkit.TransactionAccountRoles{
+			Proposer:    a,
+			Authorizers: []*flowkit.Account{a, c},
+			Payer:       b,
+		}
+		assert.Len(t, roles.Signers(), 3)
+
+		tx, result, err := s.Transactions.SendWithRoles(
+			roles,
+			tests.TransactionTwoAuthorizers.Source,
+			tests.TransactionTwoAuthorizers.Filename,
+			gasLimit,
+			nil,
+			"",
+		)
+
+		assert.NoError(t, err)
+		assert.NoError(t, result.Error)
+		assert.Equal(t, a.Address(), tx.ProposalKey.Address)
+		assert.Equal(t, b.Address(), tx.Payer)
+		assert.Equal(t, []flow.Address{a.Address(), c.Address()}, tx.Authorizers)
+		assert.Len(t, tx.PayloadSignatures, 2)
+		assert.Len(t, tx.EnvelopeSignatures, 1)
+		assert.Equal(t, b.Address(), tx.EnvelopeSignatures[0].Address)
+	})
+
+	t.Run("Send with Payer as Authorizer", func(t *testing.T) {
+		t.Parallel()
+		state, s := setupIntegration()
+		setupAccounts(state, s)
+
+		a, _ := state.Accounts().ByName("Alice")
+		b, _ := state.Accounts().ByName("Bob")
+
+		roles := &flowkit.TransactionAccountRoles{
+			Proposer:    a,
+			Authorizers: []*flowkit.Account{a, b},
+			Payer:       a,
+		}
+		assert.Len(t, roles.Signers(), 2)
+
+		tx, result, err := s.Transactions.SendWithRoles(
+			roles,
+			tests.TransactionTwoAuthorizers.Source,
+			tests.TransactionTwoAuthorizers.Filename,
+			gasLimit,
+			nil,
+			"",
+		)
+
+		assert.NoError(t, err)
+		assert.NoError(t, result.Error)
+		assert.Len(t, tx.PayloadSignatures, 1)
+		assert.Equal(t, b.Address(), tx.PayloadSignatures[0].Address)
+		assert.Len(t, tx.EnvelopeSignatures, 1)
+		assert.Equal(t, a.Address(), tx.EnvelopeSignatures[0].Address)
+	})
+}
+
+func TestTransactionsSendBatch_Integration(t *testing.T) {
+	t.Parallel()
+
+	state, s := setupIntegration()
+	srvAcc, _ := state.EmulatorServiceAccount()
+
+	alice := tests.Alice()
+	pk, _ := alice.Key().PrivateKey()
+	pubKey := (*pk).PublicKey()
+	acc, err := s.Accounts.Create(
+		srvAcc,
+		[]crypto.PublicKey{pubKey, pubKey, pubKey},
+		[]int{flow.AccountKeyWeightThreshold, flow.AccountKeyWeightThreshold, flow.AccountKeyWeightThreshold},
+		alice.Key().SigAlgo(),
+		alice.Key().HashAlgo(),
+		nil,
+	)
+	assert.NoError(t, err)
+	alice.SetAddress(acc.Address)
+
+	results, err := s.Transactions.SendBatch(
+		alice,
+		tests.TransactionArgString.Source,
+		"",
+		gasLimit,
+		[]cadence.Value{cadence.NewString("Bar")},
+		"",
+		7,
+	)
+	assert.NoError(t, err)
+	assert.Len(t, results, 7)
+
+	ids := make(map[flow.Identifier]bool)
+	for _, r := range results {
+		assert.NoError(t, r.Err)
+		assert.NoError(t, r.Result.Error)
+		assert.Equal(t, flow.TransactionStatusSealed, r.Result.Status)
+		ids[r.Tx.ID()] = true
+	}
+	assert.Len(t, ids, 7)
+
+	updated, _ := s.Accounts.Get(acc.Address)
+	var sequences uint64
+	for _, key := range updated.Keys {
+		sequences += key.SequenceNumber
+	}
+	assert.Equal(t, uint64(7), sequences)
+}
+
+func TestTransactionsDryRun_Integration(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Dry Run", func(t *testing.T) {
+		t.Parallel()
+		state, s := setupIntegration()
+		srvAcc, _ := state.EmulatorServiceAccount()
+
+		before, _ := s.Accounts.Get(srvAcc.Address())
+
+		result, err := s.Transactions.DryRun(
+			flowkit.NewTransactionSingleAccountRole(srvAcc),
+			tests.TransactionArgString.Source,
+			"",
+			gasLimit,
+			[]cadence.Value{cadence.NewString("Bar")},
+			"",
+		)
+
+		assert.NoError(t, err)
+		assert.NoError(t, result.Result.Error)
+		assert.Greater(t, result.ComputationUsed, uint64(0))
+		assert.LessOrEqual(t, result.ComputationUsed, uint64(gasLimit))
+
+		after, _ := s.Accounts.Get(srvAcc.Address())
+		assert.Equal(t, before.Keys[0].SequenceNumber, after.Keys[0].SequenceNumber)
+	})
+
+	t.Run("Dry Run Gas Limit Too Low", func(t *testing.T) {
+		t.Parallel()
+		state, s := setupIntegration()
+		srvAcc, _ := state.EmulatorServiceAccount()
+
+		result, err := s.Transactions.DryRun(
+			flowkit.NewTransactionSingleAccountRole(srvAcc),
+			tests.TransactionArgString.Source,
+			"",
+			1,
+			[]cadence.Value{cadence.NewString("Bar")},
+			"",
+		)
+
+		assert.NoError(t, err)
+		assert.Error(t, result.Result.Error)
+		assert.Greater(t, result.ComputationUsed, uint64(1))
+	})
+
+	t.Run("Dry Run Failing", func(t *testing.T) {
+		t.Parallel()
+		state, s := setupIntegration()
+		srvAcc, _ := state.EmulatorServiceAccount()
+
+		result, err := s.Transactions.DryRun(
+			flowkit.NewTransactionSingleAccountRole(srvAcc),
+			[]byte(`transaction { execute { panic("fail") } }`),
+			"",
+			gasLimit,
+			nil,
+			"",
+		)
+
+		assert.NoError(t, err)
+		assert.Error(t, result.Result.Error)
+		assert.Equal(t, uint64(0), result.ComputationUsed)
+	})
+
+	t.Run("Dry Run Unsupported", func(t *testing.T) {
+		t.Parallel()
+		state, s, _ := setup()
+		srvAcc, _ := state.EmulatorServiceAccount()
+
+		_, err := s.Transactions.DryRun(
+			flowkit.NewTransactionSingleAccountRole(srvAcc),
+			tests.TransactionSimple.Source,
+			"",
+			gasLimit,
+			nil,
+			"",
+		)
+
+		assert.EqualError(t, err, "dry run is not supported by the gateway, use the emulator gateway")
+	})
+}
+
+func TestTransactionsSignEnvelope_Integration(t *testing.T) {
+	t.Parallel()
+
+	state, s := setupIntegration()
+	setupAccounts(state, s)
+
+	a, _ := state.Accounts().ByName("Alice")
+	b, _ := state.Accounts().ByName("Bob")
+
+	tx, err := s.Transactions.Build(
+		a.Address(),
+		[]flow.Address{a.Address()},
+		b.Address(),
+		0,
+		tests.TransactionArgString.Source,
+		tests.TransactionArgString.Filename,
+		gasLimit,
+		[]cadence.Value{cadence.NewString("Bar")},
+		"",
+	)
+	assert.NoError(t, err)
+
+	envelope := flowkit.NewTransactionEnvelope(tx, tests.TransactionArgString.Filename, "emulator")
+	assert.Len(t, envelope.Signers, 2)
+	assert.Equal(t, []string{flowkit.RoleProposer, flowkit.RoleAuthorizer}, envelope.Signers[0].Roles)
+	assert.Equal(t, []string{flowkit.RolePayer}, envelope.Signers[1].Roles)
+
+	built, _ := envelope.Encode()
+	_, _, err = s.Transactions.SendSigned(built)
+	assert.EqualError(t, err, fmt.Sprintf(
+		"transaction is missing signatures from: %s (proposer, authorizer), %s (payer)",
+		a.Address(), b.Address(),
+	))
+
+	// the key of a signer other than the proposer is compared with the on-chain key unless offline
+	wrong := &flowkit.Account{}
+	wrong.SetName(b.Name())
+	wrong.SetAddress(b.Address())
+	wrong.SetKey(a.Key())
+
+	_, err = s.Transactions.Sign(wrong, built, true)
+	var mismatchErr *flowkit.KeyMismatchError
+	assert.True(t, errors.As(err, &mismatchErr))
+	assert.Equal(t, b.Address(), mismatchErr.Address)
+
+	_, err = s.Transactions.SignOffline(wrong, built, true, "")
+	assert.NoError(t, err)
+
+	signed, err := s.Transactions.Sign(a, built, true)
+	assert.NoError(t, err)
+	assert.Equal(t, a, signed.Signer())
+
+	envelope.SetTransaction(signed)
+	assert.True(t, envelope.Signers[0].Signed)
+	assert.False(t, envelope.Signers[1].Signed)
+	assert.Len(t, envelope.Signatures, 1)
+
+	partial, _ := envelope.Encode()
+	_, _, err = s.Transactions.SendSigned(partial)
+	assert.EqualError(t, err, fmt.Sprintf("transaction is missing signatures from: %s (payer)", b.Address()))
+
+	signed, err = s.Transactions.Sign(b, partial, true)
+	assert.NoError(t, err)
+
+	envelope.SetTransaction(signed)
+	assert.Len(t, envelope.MissingSigners(), 0)
+
+	complete, _ := envelope.Encode()
+	parsed, err := flowkit.ParseTransactionEnvelope(complete)
+	assert.NoError(t, err)
+	assert.Equal(t, tests.TransactionArgString.Filename, parsed.Source)
+	assert.Equal(t, "emulator", parsed.Network)
+
+	sentTx, result, err := s.Transactions.SendSigned(complete)
+	assert.NoError(t, err)
+	assert.NoError(t, result.Error)
+	assert.Equal(t, signed.FlowTransaction().ID(), sentTx.ID())
+}
+
+func TestTransactionsVerifySignatures_Integration(t *testing.T) {
+	t.Parallel()
+
+	state, s := setupIntegration()
+	setupAccounts(state, s)
+
+	a, _ := state.Accounts().ByName("Alice")
+	b, _ := state.Accounts().ByName("Bob")
+
+	signed, err := s.Transactions.buildSigned(
+		&flowkit.TransactionAccountRoles{
+			Proposer:    a,
+			Authorizers: []*flowkit.Account{a},
+			Payer:       b,
+		},
+		tests.TransactionArgString.Source,
+		"",
+		gasLimit,
+		[]cadence.Value{cadence.NewString("Bar")},
+		"",
+		nil,
+		nil,
+	)
+	assert.NoError(t, err)
+	tx := signed.FlowTransaction()
+
+	verifications := s.Transactions.VerifySignatures(tx)
+	assert.Len(t, verifications, 2)
+	assert.Equal(t, flowkit.SignaturePayload, verifications[0].Type)
+	assert.Equal(t, a.Address(), verifications[0].Signature.Address)
+	assert.Equal(t, flowkit.SignatureEnvelope, verifications[1].Type)
+	assert.Equal(t, b.Address(), verifications[1].Signature.Address)
+	for _, v := range verifications {
+		assert.NoError(t, v.Err)
+		assert.True(t, v.Valid)
+	}
+
+	tx.PayloadSignatures[0].Signature[0] ^= 0xff
+	tx.EnvelopeSignatures[0].KeyIndex = 5
+
+	verifications = s.Transactions.VerifySignatures(tx)
+	assert.NoError(t, verifications[0].Err)
+	assert.False(t, verifications[0].Valid)
+	assert.EqualError(t, verifications[1].Err, fmt.Sprintf("key index 5 does not exist on account %s", b.Address()))
+}
+
+func TestTransactionsBuildOffline_Integration(t *testing.T) {
+	t.Parallel()
+
+	state, s := setupIntegration()
+	srvAcc, _ := state.EmulatorServiceAccount()
+
+	ctx, err := s.Transactions.Context(srvAcc.Address(), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, srvAcc.Address(), ctx.Proposer)
+
+	tx, err := s.Transactions.Build(
+		srvAcc.Address(),
+		[]flow.Address{srvAcc.Address()},
+		srvAcc.Address(),
+		0,
+		tests.TransactionSimple.Source,
+		"",
+		gasLimit,
+		nil,
+		"",
+	)
+	assert.NoError(t, err)
+
+	offline, err := s.Transactions.BuildOffline(
+		ctx,
+		[]flow.Address{srvAcc.Address()},
+		srvAcc.Address(),
+		tests.TransactionSimple.Source,
+		"",
+		gasLimit,
+		nil,
+		"",
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, tx.FlowTransaction().ID(), offline.FlowTransaction().ID())
+
+	_, err = s.Transactions.Context(srvAcc.Address(), 3)
+	assert.EqualError(t, err, fmt.Sprintf("key index 3 does not exist on account %s", srvAcc.Address()))
+
+	ctx.ReferenceBlockID = "0x01"
+	_, err = s.Transactions.BuildOffline(ctx, nil, srvAcc.Address(), tests.TransactionSimple.Source, "", gasLimit, nil, "")
+	assert.EqualError(t, err, "invalid reference block ID: 0x01")
+}
+
+func TestTransactionsPolicy_Integration(t *testing.T) {
+	t.Parallel()
+
+	state, s := setupIntegration()
+	srvAcc, _ := state.EmulatorServiceAccount()
+
+	_ = state.ReaderWriter().WriteFile("policy.json", []byte(fmt.Sprintf(`{
+		"templates": ["%s"],
+		"payers": ["%s"],
+		"maxGasLimit": 500
+	}`, tests.TransactionArgString.Filename, srvAcc.Name())), 0644)
+	state.Config().Policy = "policy.json"
+
+	t.Run("Send Allowed", func(t *testing.T) {
+		_, result, err := s.Transactions.Send(srvAcc, tests.TransactionArgString.Source, "", 100, []cadence.Value{cadence.NewString("Bar")}, "")
+		assert.NoError(t, err)
+		assert.NoError(t, result.Error)
+	})
+
+	t.Run("Send Violation", func(t *testing.T) {
+		_, _, err := s.Transactions.Send(srvAcc, tests.TransactionSimple.Source, "", 1000, nil, "")
+
+		var policyErr *flowkit.PolicyViolationError
+		assert.ErrorAs(t, err, &policyErr)
+		assert.Len(t, policyErr.Violations, 2)
+		assert.Equal(t, "script", policyErr.Violations[0].Rule)
+		assert.Equal(t, "maxGasLimit", policyErr.Violations[1].Rule)
+	})
+
+	t.Run("Sign Violation", func(t *testing.T) {
+		tx, err := s.Transactions.Build(
+			srvAcc.Address(),
+			[]flow.Address{srvAcc.Address()},
+			srvAcc.Address(),
+			0,
+			tests.TransactionArgString.Source,
+			"",
+			1000,
+			[]cadence.Value{cadence.NewString("Bar")},
+			"",
+		)
+		assert.NoError(t, err)
+
+		payload := []byte(hex.EncodeToString(tx.FlowTransaction().Encode()))
+		_, err = s.Transactions.Sign(srvAcc, payload, true)
+		assert.EqualError(t, err, "transaction violates the signing policy:\n  - maxGasLimit: gas limit 1000 exceeds the maximum 500")
+	})
+}
+
+func TestTransactionsSignPolicyNetwork_Integration(t *testing.T) {
+	t.Parallel()
+
+	state, s := setupIntegration()
+	srvAcc, _ := state.EmulatorServiceAccount()
+
+	c := config.Contract{
+		Name:    tests.ContractHelloString.Name,
+		Source:  tests.ContractHelloString.Filename,
+		Network: "emulator",
+	}
+	state.Contracts().AddOrUpdate(c.Name, c)
+	state.Deployments().AddOrUpdate(config.Deployment{
+		Network:   "emulator",
+		Account:   srvAcc.Name(),
+		Contracts: []config.ContractDeployment{{Name: c.Name}},
+	})
+
+	_ = state.ReaderWriter().WriteFile(tests.TransactionImports.Filename, tests.TransactionImports.Source, 0644)
+	_ = state.ReaderWriter().WriteFile("policy.json", []byte(fmt.Sprintf(`{
+		"templates": ["%s"]
+	}`, tests.TransactionImports.Filename)), 0644)
+	state.Config().Policy = "policy.json"
+
+	tx, err := s.Transactions.Build(
+		srvAcc.Address(),
+		[]flow.Address{srvAcc.Address()},
+		srvAcc.Address(),
+		0,
+		tests.TransactionImports.Source,
+		tests.TransactionImports.Filename,
+		1000,
+		nil,
+		"emulator",
+	)
+	assert.NoError(t, err)
+
+	// a raw payload has no network, the template imports are resolved for the provided network
+	payload := []byte(hex.EncodeToString(tx.FlowTransaction().Encode()))
+	_, err = s.Transactions.Sign(srvAcc, payload, true)
+	assert.Error(t, err)
+
+	signed, err := s.Transactions.SignForNetwork(srvAcc, payload, true, "emulator")
+	assert.NoError(t, err)
+	assert.Len(t, signed.FlowTransaction().EnvelopeSignatures, 1)
+}
+
+func TestTransactionsAuditLog_Integration(t *testing.T) {
+	t.Parallel()
+
+	state, s := setupIntegration()
+	srvAcc, _ := state.EmulatorServiceAccount()
+	state.Config().AuditLog = "audit.log"
+
+	tx, result, err := s.Transactions.Send(srvAcc, tests.TransactionArgString.Source, "", 1000, []cadence.Value{cadence.NewString("Bar")}, "")
+	assert.NoError(t, err)
+	assert.NoError(t, result.Error)
+
+	_, err = s.Accounts.AddContract(srvAcc, tests.ContractHelloString.Name, tests.ContractHelloString.Source, false)
+	assert.NoError(t, err)
+
+	data, _ := state.ReaderWriter().ReadFile("audit.log")
+	entries, _, err := flowkit.VerifyAuditLog(data)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, tx.ID().String(), entries[0].TransactionID)
+	assert.Equal(t, flowkit.SignatureEnvelope, entries[0].SignatureType)
+	assert.Equal(t, srvAcc.Address(), entries[1].Address)
+	assert.NotEmpty(t, entries[1].PreviousHash)
+}
+
+func TestTransactionsExpiry(t *testing.T) {
+	t.Parallel()
+
+	newBlock := func(height uint64) *flow.Block {
+		block := tests.NewBlock()
+		block.Height = height
+		return block
+	}
+
+	t.Run("Not Expired", func(t *testing.T) {
+		_, s, gw := setup()
+		gw.GetBlockByID.Return(newBlock(100), nil)
+		gw.GetLatestBlock.Return(newBlock(100+flowGo.DefaultTransactionExpiry-1), nil)
+
+		err := s.Transactions.CheckExpiry(tests.NewTransaction())
+		assert.NoError(t, err)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		_, s, gw := setup()
+		gw.GetBlockByID.Return(newBlock(100), nil)
+		gw.GetLatestBlock.Return(newBlock(800), nil)
+
+		err := s.Transactions.CheckExpiry(tests.NewTransaction())
+		var expiredErr *TransactionExpiredError
+		assert.ErrorAs(t, err, &expiredErr)
+		assert.Equal(t, uint64(100), expiredErr.ReferenceHeight)
+		assert.Equal(t, uint64(800), expiredErr.LatestHeight)
+
+		payload := []byte(hex.EncodeToString(tests.NewTransaction().Encode()))
+		_, _, err = s.Transactions.SendSigned(payload)
+		assert.ErrorAs(t, err, &expiredErr)
+		gw.Mock.AssertNotCalled(t, tests.SendSignedTransactionFunc, mock.Anything)
+	})
+
+	t.Run("Unknown Reference Block", func(t *testing.T) {
+		_, s := setupIntegration()
+
+		err := s.Transactions.CheckExpiry(tests.NewTransaction())
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get the reference block")
+	})
+
+	t.Run("Refresh", func(t *testing.T) {
+		_, s, gw := setup()
+		latest := newBlock(800)
+		gw.GetLatestBlock.Return(latest, nil)
+
+		tx := tests.NewTransaction()
+		tx.ProposalKey.KeyIndex = 0
+		payload := []byte(hex.EncodeToString(tx.Encode()))
+
+		envelope, resign, err := s.Transactions.Refresh(payload)
+		assert.NoError(t, err)
+
+		refreshed := envelope.Transaction().FlowTransaction()
+		assert.Equal(t, latest.ID, refreshed.ReferenceBlockID)
+		assert.Equal(t, tx.Script, refreshed.Script)
+		assert.Equal(t, tx.Arguments, refreshed.Arguments)
+		assert.Equal(t, tx.Authorizers, refreshed.Authorizers)
+		assert.Equal(t, tx.Payer, refreshed.Payer)
+		assert.Equal(t, tx.ProposalKey.Address, refreshed.ProposalKey.Address)
+		assert.Equal(t, tx.ProposalKey.KeyIndex, refreshed.ProposalKey.KeyIndex)
+		assert.Equal(t, uint64(0), refreshed.ProposalKey.SequenceNumber)
+		assert.Len(t, refreshed.PayloadSignatures, 0)
+		assert.Len(t, refreshed.EnvelopeSignatures, 0)
+
+		assert.NotEmpty(t, resign)
+		assert.Len(t, envelope.MissingSigners(), len(envelope.Signers))
+	})
+}
+
+func TestTransactionsWait(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Reached Status", func(t *testing.T) {
+		_, s, gw := setup()
+		ids := []flow.Identifier{tests.NewTransaction().ID(), tests.NewTransaction().ID()}
+
+		results := s.Transactions.Wait(ids, flow.TransactionStatusExecuted, time.Second)
+
+		assert.Len(t, results, 2)
+		for i, res := range results {
+			assert.NoError(t, res.Err)
+			assert.Equal(t, ids[i], res.ID)
+			assert.Equal(t, flow.TransactionStatusSealed, res.Result.Status)
+		}
+		gw.Mock.AssertNumberOfCalls(t, tests.GetTransactionResultFunc, 2)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		_, s, gw := setup()
+		pending := tests.NewTransactionResult(nil)
+		pending.Status = flow.TransactionStatusPending
+		gw.GetTransactionResult.Return(pending, nil)
+
+		results := s.Transactions.Wait([]flow.Identifier{tests.NewTransaction().ID()}, flow.TransactionStatusSealed, 0)
+
+		assert.Len(t, results, 1)
+		assert.Error(t, results[0].Err)
+		assert.Contains(t, results[0].Err.Error(), "last status is pending")
+		assert.Equal(t, flow.TransactionStatusPending, results[0].Result.Status)
+	})
+}
+
+func TestTransactionsSubmit_Integration(t *testing.T) {
+	t.Parallel()
+
+	state, s := setupIntegration()
+	srvAcc, _ := state.EmulatorServiceAccount()
+
+	tx, err := s.Transactions.SubmitWithRoles(
+		flowkit.NewTransactionSingleAccountRole(srvAcc),
+		tests.TransactionArgString.Source,
+		tests.TransactionArgString.Filename,
+		gasLimit,
+		[]cadence.Value{cadence.NewString("Bar")},
+		"",
+	)
+	assert.NoError(t, err)
+
+	results := s.Transactions.Wait([]flow.Identifier{tx.ID()}, flow.TransactionStatusSealed, time.Minute)
+	assert.NoError(t, results[0].Err)
+	assert.NoError(t, results[0].Result.Error)
+	assert.Equal(t, flow.TransactionStatusSealed, results[0].Result.Status)
+}
+
+func TestTransactionsSendManifest_Integration(t *testing.T) {
+	t.Parallel()
+
+	newManifest := func(t *testing.T) *flowkit.TransactionManifest {
+		manifest, err := flowkit.ParseTransactionManifest([]byte(`{
+			"transactions": [
+				{"name": "first", "file": "transactionArg.cdc", "signer": "Alice", "args": ["Foo"], "gasLimit": 1000},
+				{"name": "second", "file": "transactionArg.cdc", "signer": "Alice", "args": ["Bar"], "gasLimit": 1000, "dependsOn": ["first"]},
+				{"name": "failing", "file": "transactionSimple.cdc", "signer": "Bob", "gasLimit": 1000},
+				{"name": "after", "file": "transactionArg.cdc", "signer": "Bob", "args": ["Baz"], "gasLimit": 1000, "dependsOn": ["failing"]}
+			]
+		}`), "manifest.json")
+		assert.NoError(t, err)
+		return manifest
+	}
+
+	t.Run("Continue on Error", func(t *testing.T) {
+		t.Parallel()
+		state, s := setupIntegration()
+		setupAccounts(state, s)
+
+		results, err := s.Transactions.SendManifest(newManifest(t), "", 2, true)
+		assert.NoError(t, err)
+		assert.Len(t, results, 4)
+
+		assert.False(t, results[0].Failed())
+		assert.False(t, results[1].Failed())
+		assert.Equal(t, results[0].Tx.ProposalKey.SequenceNumber+1, results[1].Tx.ProposalKey.SequenceNumber)
+
+		assert.True(t, results[2].Failed())
+		assert.False(t, results[2].Skipped)
+		assert.Error(t, results[2].Result.Error)
+
+		assert.True(t, results[3].Skipped)
+		assert.Nil(t, results[3].Tx)
+		assert.Contains(t, results[3].Err.Error(), "dependency failing failed")
+	})
+
+	t.Run("Invalid Transaction", func(t *testing.T) {
+		t.Parallel()
+		state, s := setupIntegration()
+		setupAccounts(state, s)
+
+		manifest := newManifest(t)
+		manifest.Transactions[1].Signer = "Unknown"
+
+		_, err := s.Transactions.SendManifest(manifest, "", 2, true)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "transaction second")
+	})
+}
+
+func TestTransactionsLogs_Integration(t *testing.T) {
+	t.Parallel()
+
+	state, s := setupIntegration()
+	srvAcc, _ := state.EmulatorServiceAccount()
+
+	code := []byte(`
+		transaction(greeting: String) {
+			prepare(signer: AuthAccount) {
+				log(greeting)
+			}
+		}`)
+
+	tx, result, err := s.Transactions.Send(srvAcc, code, "", gasLimit, []cadence.Value{cadence.NewString("Hello")}, "")
+	assert.NoError(t, err)
+	assert.NoError(t, result.Error)
+	assert.Equal(t, []string{"\"Hello\""}, s.Transactions.Logs(tx.ID()))
+
+	tx, _, err = s.Transactions.Send(srvAcc, []byte(`transaction { prepare(signer: AuthAccount) {} }`), "", gasLimit, nil, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{}, s.Transactions.Logs(tx.ID()))
+}
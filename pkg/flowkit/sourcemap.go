@@ -0,0 +1,91 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"strings"
+)
+
+// SourceMap maps positions in code with resolved imports back to the original source code.
+//
+// Resolving imports replaces file imports with addresses, which only changes columns
+// on the lines with imports, so the replacements are recorded and reverted when mapping.
+type SourceMap struct {
+	Filename     string
+	Source       []byte
+	replacements []sourceReplacement
+}
+
+// sourceReplacement is a replacement of text at the line and column of the code at the time of the replacement.
+type sourceReplacement struct {
+	line           int
+	column         int
+	originalLength int
+	length         int
+}
+
+// NewSourceMap creates a source map for the original source code in the file.
+func NewSourceMap(filename string, source []byte) *SourceMap {
+	return &SourceMap{
+		Filename: filename,
+		Source:   source,
+	}
+}
+
+// AddReplacement records a replacement of text with original length by the text with length at the line and column.
+//
+// Replacements must be added in the order they are applied to the code.
+func (m *SourceMap) AddReplacement(line int, column int, originalLength int, length int) {
+	m.replacements = append(m.replacements, sourceReplacement{
+		line:           line,
+		column:         column,
+		originalLength: originalLength,
+		length:         length,
+	})
+}
+
+// OriginalPosition returns the position in the original source for the line and column in the code with replacements.
+//
+// Positions inside the replaced text are mapped to the start of the original text.
+func (m *SourceMap) OriginalPosition(line int, column int) (int, int) {
+	for i := len(m.replacements) - 1; i >= 0; i-- {
+		r := m.replacements[i]
+		if r.line != line || column < r.column {
+			continue
+		}
+
+		if column < r.column+r.length {
+			column = r.column
+		} else {
+			column += r.originalLength - r.length
+		}
+	}
+
+	return line, column
+}
+
+// Line returns the line of the original source, the line numbers start at 1.
+func (m *SourceMap) Line(line int) (string, bool) {
+	lines := strings.Split(string(m.Source), "\n")
+	if line < 1 || line > len(lines) {
+		return "", false
+	}
+
+	return strings.TrimRight(lines[line-1], "\r"), true
+}
@@ -54,6 +54,7 @@ type State struct {
 	confLoader   *config.Loader
 	readerWriter ReaderWriter
 	accounts     *Accounts
+	auditLog     *AuditLog
 }
 
 // ReaderWriter retrieve current file reader writer.
@@ -151,6 +152,19 @@ func (p *State) Config() *config.Config {
 	return p.conf
 }
 
+// AuditLog returns the signing audit log from the configuration, nil is returned if no audit log is configured.
+func (p *State) AuditLog() *AuditLog {
+	if p.conf.AuditLog == "" {
+		return nil
+	}
+
+	if p.auditLog == nil || p.auditLog.Path() != p.conf.AuditLog {
+		p.auditLog = NewAuditLog(p.readerWriter, p.conf.AuditLog)
+	}
+
+	return p.auditLog
+}
+
 // EmulatorServiceAccount returns the service account for the default emulator profile.
 func (p *State) EmulatorServiceAccount() (*Account, error) {
 	emulator := p.conf.Emulators.Default()
@@ -191,11 +205,16 @@ func (p *State) DeploymentContractsByNetwork(network string) ([]Contract, error)
 				return nil, err
 			}
 
+			args, err := p.deploymentArgs(deploymentContract, network)
+			if err != nil {
+				return nil, err
+			}
+
 			contract := Contract{
 				Name:   c.Name,
 				Source: path.Clean(c.Source),
 				Target: account.address,
-				Args:   deploymentContract.Args,
+				Args:   args,
 			}
 
 			contracts = append(contracts, contract)
@@ -205,6 +224,78 @@ func (p *State) DeploymentContractsByNetwork(network string) ([]Contract, error)
 	return contracts, nil
 }
 
+// deploymentArgs returns the args of the contract deployment with address references resolved on the network.
+func (p *State) deploymentArgs(deployment config.ContractDeployment, network string) ([]cadence.Value, error) {
+	if len(deployment.AddressReferences) == 0 {
+		return deployment.Args, nil
+	}
+
+	resolve := p.AddressResolver(network)
+	args := make([]cadence.Value, 0, deployment.ArgsCount())
+	values := deployment.Args
+
+	for index := 0; index < deployment.ArgsCount(); index++ {
+		reference, ok := deployment.AddressReferences[index]
+		if !ok {
+			if len(values) == 0 {
+				return nil, fmt.Errorf("invalid address references of contract %s deployment", deployment.Name)
+			}
+
+			args = append(args, values[0])
+			values = values[1:]
+			continue
+		}
+
+		name, _ := config.AddressReference(reference)
+		address, err := resolve(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid argument %s of contract %s deployment: %w", reference, deployment.Name, err)
+		}
+
+		args = append(args, cadence.NewAddress(address))
+	}
+
+	return args, nil
+}
+
+// AddressResolver returns a resolver of address references to the address of the configured account with the name,
+// or else to the address of the contract with the name deployed or aliased on the network.
+func (p *State) AddressResolver(network string) AddressResolver {
+	return func(name string) (flow.Address, error) {
+		account, err := p.accounts.ByName(name)
+		if err == nil {
+			return account.address, nil
+		}
+
+		for _, deploy := range p.conf.Deployments.ByNetwork(network) {
+			for _, deploymentContract := range deploy.Contracts {
+				if deploymentContract.Name != name {
+					continue
+				}
+
+				account, err := p.accounts.ByName(deploy.Account)
+				if err != nil {
+					return flow.EmptyAddress, err
+				}
+
+				return account.address, nil
+			}
+		}
+
+		for _, contract := range p.conf.Contracts.ByNetwork(network) {
+			if contract.Name == name && contract.IsAlias() {
+				return flow.HexToAddress(contract.Alias), nil
+			}
+		}
+
+		return flow.EmptyAddress, fmt.Errorf(
+			"could not find account or contract deployed on network %s with name %s in the configuration",
+			network,
+			name,
+		)
+	}
+}
+
 // AccountNamesForNetwork returns all configured account names for a network.
 func (p *State) AccountNamesForNetwork(network string) []string {
 	names := make([]string, 0)
@@ -280,7 +371,7 @@ func Init(readerWriter ReaderWriter, sigAlgo crypto.SignatureAlgorithm, hashAlgo
 
 // newProject creates a new project from a configuration object.
 func newProject(conf *config.Config, loader *config.Loader, readerWriter ReaderWriter) (*State, error) {
-	accounts, err := accountsFromConfig(conf)
+	accounts, err := accountsFromConfig(conf, readerWriter)
 	if err != nil {
 		return nil, err
 	}
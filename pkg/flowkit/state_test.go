@@ -25,6 +25,7 @@ import (
 
 	"github.com/onflow/flow-cli/pkg/flowkit/config/json"
 
+	"github.com/onflow/cadence"
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/spf13/afero"
@@ -501,6 +502,54 @@ func Test_GetAliasesComplex(t *testing.T) {
 	assert.Equal(t, cTestnet[1].Name, "FungibleToken")
 }
 
+func Test_AddressResolver(t *testing.T) {
+	p := generateAliasesComplexProject()
+
+	emulator := p.AddressResolver("emulator")
+	testnet := p.AddressResolver("testnet")
+
+	address, err := emulator("testnet-account")
+	assert.NoError(t, err)
+	assert.Equal(t, flow.HexToAddress("1e82856bf20e2aa6"), address)
+
+	address, err = emulator("NonFungibleToken")
+	assert.NoError(t, err)
+	assert.Equal(t, flow.ServiceAddress(flow.Emulator), address)
+
+	address, err = emulator("FungibleToken")
+	assert.NoError(t, err)
+	assert.Equal(t, flow.HexToAddress("ee82856bf20e2aa6"), address)
+
+	address, err = testnet("FungibleToken")
+	assert.NoError(t, err)
+	assert.Equal(t, flow.HexToAddress("1e82856bf20e2aa6"), address)
+
+	_, err = testnet("Unknown")
+	assert.EqualError(t, err, "could not find account or contract deployed on network testnet with name Unknown in the configuration")
+}
+
+func Test_DeploymentAddressReferences(t *testing.T) {
+	p := generateAliasesComplexProject()
+
+	p.conf.Deployments[1].Contracts[1].Args = []cadence.Value{
+		cadence.NewString("Kitty"),
+	}
+	p.conf.Deployments[1].Contracts[1].AddressReferences = map[int]string{1: "@NonFungibleToken"}
+
+	contracts, err := p.DeploymentContractsByNetwork("testnet")
+	assert.NoError(t, err)
+	assert.Equal(t, []cadence.Value{
+		cadence.NewString("Kitty"),
+		cadence.NewAddress(flow.HexToAddress("1e82856bf20e2aa6")),
+	}, contracts[1].Args)
+	// the configuration keeps the reference out of the args
+	assert.Len(t, p.conf.Deployments[1].Contracts[1].Args, 1)
+
+	p.conf.Deployments[1].Contracts[1].AddressReferences = map[int]string{1: "account:alice"}
+	_, err = p.DeploymentContractsByNetwork("testnet")
+	assert.EqualError(t, err, "invalid argument account:alice of contract FungibleToken deployment: could not find account or contract deployed on network testnet with name alice in the configuration")
+}
+
 func Test_ChangingState(t *testing.T) {
 	p := generateSimpleProject()
 
@@ -541,6 +590,14 @@ func Test_ChangingState(t *testing.T) {
 	assert.NotNil(t, bobo)
 	assert.NoError(t, err)
 
+	b := Account{}
+	b.SetName("bobo")
+	b.SetAddress(flow.HexToAddress("0x2"))
+	p.Accounts().AddOrUpdate(&b)
+	bobo, err = p.Accounts().ByName("bobo")
+	assert.NoError(t, err)
+	assert.Equal(t, flow.HexToAddress("0x2"), bobo.Address())
+
 	zoo2, _ := p.Accounts().ByName("zoo")
 	zoo2.SetName("emulator-account")
 	assert.Equal(t, "emulator-account", zoo2.name)
@@ -21,6 +21,7 @@ package flowkit
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 
 	jsoncdc "github.com/onflow/cadence/encoding/json"
@@ -31,7 +32,10 @@ import (
 	"github.com/onflow/flow-go-sdk"
 )
 
-const maxGasLimit uint64 = 9999
+// MaxGasLimit is the highest gas limit accepted by the network and the emulator.
+//
+// Transactions built from templates use the maximum, the fees don't depend on the gas limit.
+const MaxGasLimit uint64 = 9999
 
 // NewTransaction create new instance of transaction.
 func NewTransaction() *Transaction {
@@ -126,7 +130,7 @@ func addAccountContractWithArgs(
 
 	script := fmt.Sprintf(addAccountContractTemplate, txArgs, addArgs)
 	tx.SetScript([]byte(script))
-	tx.SetGasLimit(maxGasLimit)
+	tx.SetGasLimit(MaxGasLimit)
 
 	t := &Transaction{tx: tx}
 	err := t.SetSigner(signer)
@@ -159,21 +163,117 @@ func newTransactionFromTemplate(templateTx *flow.Transaction, signer *Account) (
 		return nil, err
 	}
 	tx.SetPayer(signer.Address())
-	tx.SetGasLimit(maxGasLimit) // todo change this to calculated limit
+	tx.SetGasLimit(MaxGasLimit)
 
 	return tx, nil
 }
 
+// TransactionAccountRoles define accounts for transaction roles.
+//
+// The same account can be used for multiple roles.
+type TransactionAccountRoles struct {
+	Proposer    *Account
+	Authorizers []*Account
+	Payer       *Account
+}
+
+// NewTransactionSingleAccountRole creates roles where the account is proposer, payer and the only authorizer.
+func NewTransactionSingleAccountRole(account *Account) *TransactionAccountRoles {
+	return &TransactionAccountRoles{
+		Proposer:    account,
+		Authorizers: []*Account{account},
+		Payer:       account,
+	}
+}
+
+// AuthorizerAddresses returns the addresses of authorizers.
+func (t *TransactionAccountRoles) AuthorizerAddresses() []flow.Address {
+	addresses := make([]flow.Address, 0, len(t.Authorizers))
+	for _, a := range t.Authorizers {
+		addresses = append(addresses, a.Address())
+	}
+
+	return addresses
+}
+
+// Signers returns all the accounts that need to sign the transaction.
+//
+// Accounts having multiple roles are only included once.
+func (t *TransactionAccountRoles) Signers() []*Account {
+	signers := make([]*Account, 0)
+	for _, account := range append([]*Account{t.Proposer}, append(t.Authorizers, t.Payer)...) {
+		if !containsSigner(signers, account) {
+			signers = append(signers, account)
+		}
+	}
+
+	return signers
+}
+
+// containsSigner checks if the signers already contain the account with the same key.
+func containsSigner(signers []*Account, account *Account) bool {
+	for _, s := range signers {
+		if s.Address() == account.Address() && s.Key().Index() == account.Key().Index() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TransactionContext is the network state needed to build a transaction without network access.
+type TransactionContext struct {
+	ReferenceBlockID string       `json:"referenceBlockId"`
+	Proposer         flow.Address `json:"proposer"`
+	KeyIndex         int          `json:"keyIndex"`
+	SequenceNumber   uint64       `json:"sequenceNumber"`
+}
+
+// ParseTransactionContext parses the transaction context in JSON format.
+func ParseTransactionContext(data []byte) (*TransactionContext, error) {
+	var ctx TransactionContext
+	err := json.Unmarshal(data, &ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse transaction context: %w", err)
+	}
+
+	if ctx.ReferenceBlockID == "" {
+		return nil, fmt.Errorf("missing reference block ID in transaction context")
+	}
+
+	return &ctx, nil
+}
+
 // Transaction builder of flow transactions.
 type Transaction struct {
-	signer   *Account
-	proposer *flow.Account
-	tx       *flow.Transaction
+	signers    []*Account
+	proposer   *flow.Account
+	tx         *flow.Transaction
+	auditLog   *AuditLog
+	sourceMap  *SourceMap
+	getAccount func(flow.Address) (*flow.Account, error)
 }
 
-// Signer get signer.
+// Signer get signer, the payer if it is one of the signers or else the first signer.
+//
+// Deprecated: transactions can have many signers, use Signers.
 func (t *Transaction) Signer() *Account {
-	return t.signer
+	for _, signer := range t.signers {
+		if signer.Address() == t.tx.Payer {
+			return signer
+		}
+	}
+
+	if len(t.signers) == 0 {
+		return nil
+	}
+
+	return t.signers[0]
+}
+
+// Signers get signers.
+func (t *Transaction) Signers() []*Account {
+	return t.signers
 }
 
 // Proposer get proposer.
@@ -191,14 +291,93 @@ func (t *Transaction) SetScriptWithArgs(script []byte, args []cadence.Value) err
 	return t.AddArguments(args)
 }
 
-// SetSigner sets the signer for transaction.
+// SetSourceMap sets the source map of the transaction script to the original source code.
+func (t *Transaction) SetSourceMap(sourceMap *SourceMap) *Transaction {
+	t.sourceMap = sourceMap
+	return t
+}
+
+// SourceMap returns the source map of the transaction script, nil if the script wasn't set from a source file.
+func (t *Transaction) SourceMap() *SourceMap {
+	return t.sourceMap
+}
+
+// SetAuditLog sets the audit log recording signatures, signatures are not recorded if the log is nil.
+func (t *Transaction) SetAuditLog(log *AuditLog) *Transaction {
+	t.auditLog = log
+	return t
+}
+
+// SetAccountGetter sets the function fetching on-chain accounts, used to compare the keys of
+// all signers with their on-chain keys. Only the proposer key is compared if it is not set.
+func (t *Transaction) SetAccountGetter(getAccount func(flow.Address) (*flow.Account, error)) *Transaction {
+	t.getAccount = getAccount
+	return t
+}
+
+// SetSigner sets the only signer for transaction.
 func (t *Transaction) SetSigner(account *Account) error {
+	t.signers = nil
+	return t.AddSigner(account)
+}
+
+// AddSigner adds a signer for transaction, signers already added are ignored.
+//
+// The configured key is compared with the on-chain key of the signer if the signer is the proposer
+// or the account getter is set, so a misconfigured key fails before signing.
+func (t *Transaction) AddSigner(account *Account) error {
 	err := account.Key().Validate()
 	if err != nil {
 		return err
 	}
 
-	t.signer = account
+	onChain := t.proposer
+	if (onChain == nil || onChain.Address != account.Address()) && t.getAccount != nil {
+		onChain, err = t.getAccount(account.Address())
+		if err != nil {
+			return err
+		}
+	}
+
+	if onChain != nil && onChain.Address == account.Address() {
+		err = verifySignerKey(account, onChain)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !containsSigner(t.signers, account) {
+		t.signers = append(t.signers, account)
+	}
+
+	return nil
+}
+
+// verifySignerKey returns a key mismatch error if the account key doesn't match the on-chain key.
+//
+// Key weight is not checked since a transaction can be signed by multiple keys.
+func verifySignerKey(account *Account, onChain *flow.Account) error {
+	mismatches, err := account.VerifyKey(onChain)
+	if err != nil {
+		return err
+	}
+
+	keyMismatches := make([]KeyMismatch, 0)
+	for _, m := range mismatches {
+		if m.Field != KeyFieldWeight {
+			keyMismatches = append(keyMismatches, m)
+		}
+	}
+
+	if len(keyMismatches) > 0 {
+		return &KeyMismatchError{
+			Account:    account.Name(),
+			Address:    account.Address(),
+			KeyIndex:   account.Key().Index(),
+			Mismatches: keyMismatches,
+		}
+	}
+
 	return nil
 }
 
@@ -216,6 +395,12 @@ func (t *Transaction) SetProposer(proposer *flow.Account, keyIndex int) *Transac
 	return t
 }
 
+// SetProposalKey sets the proposal key for transaction from a proposal key pool.
+func (t *Transaction) SetProposalKey(key *ProposalKey) *Transaction {
+	t.tx.SetProposalKey(key.Address, key.Index, key.SequenceNumber)
+	return t
+}
+
 // SetPayer sets the payer for transaction.
 func (t *Transaction) SetPayer(address flow.Address) *Transaction {
 	t.tx.SetPayer(address)
@@ -228,6 +413,12 @@ func (t *Transaction) SetBlockReference(block *flow.Block) *Transaction {
 	return t
 }
 
+// SetReferenceBlockID sets block reference for transaction using the block ID.
+func (t *Transaction) SetReferenceBlockID(id flow.Identifier) *Transaction {
+	t.tx.SetReferenceBlockID(id)
+	return t
+}
+
 // SetGasLimit sets the gas limit for transaction.
 func (t *Transaction) SetGasLimit(gasLimit uint64) *Transaction {
 	t.tx.SetGasLimit(gasLimit)
@@ -260,30 +451,74 @@ func (t *Transaction) AddAuthorizers(authorizers []flow.Address) *Transaction {
 	return t
 }
 
-// Sign signs transaction using signer account.
+// Sign signs transaction using signer accounts.
+//
+// Signers that are not the payer sign the payload first, the payer signs the envelope last.
+// If the audit log is set each signature is recorded after the transaction is signed.
 func (t *Transaction) Sign() (*Transaction, error) {
-	keyIndex := t.signer.Key().Index()
-	signer, err := t.signer.Key().Signer(context.Background())
-	if err != nil {
-		return nil, err
-	}
+	for _, signer := range t.signers {
+		if signer.Address() == t.tx.Payer {
+			continue
+		}
 
-	if t.shouldSignEnvelope() {
-		err = t.tx.SignEnvelope(t.signer.address, keyIndex, signer)
+		err := t.signPayload(signer)
 		if err != nil {
-			return nil, fmt.Errorf("failed to sign transaction: %s", err)
+			return nil, err
 		}
-	} else {
-		err = t.tx.SignPayload(t.signer.address, keyIndex, signer)
+	}
+
+	for _, signer := range t.signers {
+		if signer.Address() != t.tx.Payer {
+			continue
+		}
+
+		err := t.signEnvelope(signer)
 		if err != nil {
-			return nil, fmt.Errorf("failed to sign transaction: %s", err)
+			return nil, err
+		}
+	}
+
+	if t.auditLog != nil {
+		for _, signer := range t.signers {
+			signatureType := SignaturePayload
+			if signer.Address() == t.tx.Payer {
+				signatureType = SignatureEnvelope
+			}
+
+			err := t.auditLog.Record(t.tx, signer, signatureType)
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 
 	return t, nil
 }
 
-// shouldSignEnvelope checks if signer should sign envelope or payload
-func (t *Transaction) shouldSignEnvelope() bool {
-	return t.signer.address == t.tx.Payer
+func (t *Transaction) signPayload(account *Account) error {
+	signer, err := account.Key().Signer(context.Background())
+	if err != nil {
+		return err
+	}
+
+	err = t.tx.SignPayload(account.Address(), account.Key().Index(), signer)
+	if err != nil {
+		return fmt.Errorf("failed to sign transaction: %s", err)
+	}
+
+	return nil
+}
+
+func (t *Transaction) signEnvelope(account *Account) error {
+	signer, err := account.Key().Signer(context.Background())
+	if err != nil {
+		return err
+	}
+
+	err = t.tx.SignEnvelope(account.Address(), account.Key().Index(), signer)
+	if err != nil {
+		return fmt.Errorf("failed to sign transaction: %s", err)
+	}
+
+	return nil
 }
@@ -19,6 +19,9 @@
 package flowkit
 
 import (
+	"fmt"
+	"strconv"
+
 	"github.com/onflow/cadence"
 )
 
@@ -41,3 +44,159 @@ func NewStakingInfoFromValue(value cadence.Value) map[string]interface{} {
 
 	return stakingInfo
 }
+
+// PlainJSON converts a Cadence value to a value encoded as plain JSON, without the type information of JSON-Cadence.
+//
+// Composites are converted to objects with the field names as keys and dictionaries to objects with
+// keys in their string form. Integers up to 32 bits are converted to numbers, while larger integers and
+// fixed-point numbers are converted to strings to keep their precision. Optionals are converted to the
+// inner value or null, paths to their string form and capabilities to objects with the path, address and borrow type.
+func PlainJSON(value cadence.Value) interface{} {
+	switch v := value.(type) {
+	case nil, cadence.Void:
+		return nil
+	case cadence.Optional:
+		return PlainJSON(v.Value)
+	case cadence.Bool:
+		return bool(v)
+	case cadence.String:
+		return string(v)
+	case cadence.Bytes:
+		return fmt.Sprintf("%x", []byte(v))
+	case cadence.Address:
+		return "0x" + v.Hex()
+	case cadence.Int8:
+		return int8(v)
+	case cadence.Int16:
+		return int16(v)
+	case cadence.Int32:
+		return int32(v)
+	case cadence.UInt8:
+		return uint8(v)
+	case cadence.UInt16:
+		return uint16(v)
+	case cadence.UInt32:
+		return uint32(v)
+	case cadence.Word8:
+		return uint8(v)
+	case cadence.Word16:
+		return uint16(v)
+	case cadence.Word32:
+		return uint32(v)
+	case cadence.Int64:
+		return strconv.FormatInt(int64(v), 10)
+	case cadence.UInt64:
+		return strconv.FormatUint(uint64(v), 10)
+	case cadence.Word64:
+		return strconv.FormatUint(uint64(v), 10)
+	case cadence.Int:
+		return v.Value.String()
+	case cadence.Int128:
+		return v.Value.String()
+	case cadence.Int256:
+		return v.Value.String()
+	case cadence.UInt:
+		return v.Value.String()
+	case cadence.UInt128:
+		return v.Value.String()
+	case cadence.UInt256:
+		return v.Value.String()
+	case cadence.Fix64, cadence.UFix64:
+		return v.String()
+	case cadence.Array:
+		values := make([]interface{}, 0, len(v.Values))
+		for _, element := range v.Values {
+			values = append(values, PlainJSON(element))
+		}
+		return values
+	case cadence.Dictionary:
+		values := make(map[string]interface{}, len(v.Pairs))
+		for _, pair := range v.Pairs {
+			values[plainKey(pair.Key)] = PlainJSON(pair.Value)
+		}
+		return values
+	case cadence.Struct:
+		return plainComposite(compositeFields(v.StructType), v.Fields)
+	case cadence.Resource:
+		return plainComposite(compositeFields(v.ResourceType), v.Fields)
+	case cadence.Event:
+		return plainComposite(compositeFields(v.EventType), v.Fields)
+	case cadence.Contract:
+		return plainComposite(compositeFields(v.ContractType), v.Fields)
+	case cadence.Enum:
+		return plainComposite(compositeFields(v.EnumType), v.Fields)
+	case cadence.Path:
+		return v.String()
+	case cadence.Link:
+		return map[string]interface{}{
+			"targetPath": v.TargetPath.String(),
+			"borrowType": v.BorrowType,
+		}
+	case cadence.Capability:
+		return map[string]interface{}{
+			"path":       v.Path.String(),
+			"address":    "0x" + v.Address.Hex(),
+			"borrowType": v.BorrowType,
+		}
+	case cadence.TypeValue:
+		return v.StaticType
+	}
+
+	return value.String()
+}
+
+// plainKey converts a dictionary key to its string form used as an object key.
+func plainKey(key cadence.Value) string {
+	switch plain := PlainJSON(key).(type) {
+	case string:
+		return plain
+	default:
+		return fmt.Sprintf("%v", plain)
+	}
+}
+
+// compositeFields returns the field names of the composite type, nil if the type is unknown.
+func compositeFields(compositeType interface{}) []cadence.Field {
+	switch t := compositeType.(type) {
+	case *cadence.StructType:
+		if t != nil {
+			return t.Fields
+		}
+	case *cadence.ResourceType:
+		if t != nil {
+			return t.Fields
+		}
+	case *cadence.EventType:
+		if t != nil {
+			return t.Fields
+		}
+	case *cadence.ContractType:
+		if t != nil {
+			return t.Fields
+		}
+	case *cadence.EnumType:
+		if t != nil {
+			return t.Fields
+		}
+	}
+
+	return nil
+}
+
+// plainComposite converts the composite fields to an object, or to an array if the field names are unknown.
+func plainComposite(fields []cadence.Field, values []cadence.Value) interface{} {
+	if len(fields) != len(values) {
+		plain := make([]interface{}, 0, len(values))
+		for _, value := range values {
+			plain = append(plain, PlainJSON(value))
+		}
+		return plain
+	}
+
+	plain := make(map[string]interface{}, len(values))
+	for i, value := range values {
+		plain[fields[i].Identifier] = PlainJSON(value)
+	}
+
+	return plain
+}
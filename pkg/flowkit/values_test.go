@@ -0,0 +1,109 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+)
+
+func TestPlainJSON(t *testing.T) {
+	address := cadence.BytesToAddress([]byte{0x01, 0xcf, 0x0e, 0x2f, 0x2f, 0x71, 0x54, 0x50})
+	balance, _ := cadence.NewUFix64("1.5")
+	price, _ := cadence.NewFix64("-0.25")
+	big256, _ := new(big.Int).SetString("115792089237316195423570985008687907853269984665640564039457584007913129639935", 10)
+
+	tests := []struct {
+		name  string
+		value cadence.Value
+		json  string
+	}{
+		{"Void", cadence.NewVoid(), `null`},
+		{"Bool", cadence.NewBool(true), `true`},
+		{"String", cadence.NewString("foo"), `"foo"`},
+		{"Address", address, `"0x01cf0e2f2f715450"`},
+		{"Small Integer", cadence.NewUInt8(42), `42`},
+		{"Negative Integer", cadence.NewInt32(-42), `-42`},
+		{"UInt64", cadence.NewUInt64(18446744073709551615), `"18446744073709551615"`},
+		{"Big Integer", cadence.NewUInt256FromBig(big256), `"115792089237316195423570985008687907853269984665640564039457584007913129639935"`},
+		{"Int", cadence.NewInt(-7), `"-7"`},
+		{"UFix64", balance, `"1.50000000"`},
+		{"Fix64", price, `"-0.25000000"`},
+		{"Optional Nil", cadence.NewOptional(nil), `null`},
+		{"Optional", cadence.NewOptional(cadence.NewString("foo")), `"foo"`},
+		{"Array", cadence.NewArray([]cadence.Value{cadence.NewInt8(1), cadence.NewOptional(nil)}), `[1,null]`},
+		{
+			"Dictionary",
+			cadence.NewDictionary([]cadence.KeyValuePair{
+				{Key: cadence.NewString("a"), Value: balance},
+				{Key: address, Value: cadence.NewBool(false)},
+				{Key: cadence.NewUInt8(3), Value: cadence.NewString("c")},
+			}),
+			`{"0x01cf0e2f2f715450":false,"3":"c","a":"1.50000000"}`,
+		},
+		{"Path", cadence.Path{Domain: "storage", Identifier: "vault"}, `"/storage/vault"`},
+		{
+			"Capability",
+			cadence.Capability{
+				Path:       cadence.Path{Domain: "public", Identifier: "receiver"},
+				Address:    address,
+				BorrowType: "&AnyResource",
+			},
+			`{"address":"0x01cf0e2f2f715450","borrowType":"\u0026AnyResource","path":"/public/receiver"}`,
+		},
+		{"Type", cadence.TypeValue{StaticType: "Int"}, `"Int"`},
+		{
+			"Struct",
+			cadence.NewStruct([]cadence.Value{
+				cadence.NewString("Alice"),
+				cadence.NewOptional(cadence.NewArray([]cadence.Value{balance})),
+			}).WithType(&cadence.StructType{
+				QualifiedIdentifier: "Person",
+				Fields: []cadence.Field{
+					{Identifier: "name", Type: cadence.StringType{}},
+					{Identifier: "balances", Type: cadence.OptionalType{Type: cadence.VariableSizedArrayType{ElementType: cadence.UFix64Type{}}}},
+				},
+			}),
+			`{"balances":["1.50000000"],"name":"Alice"}`,
+		},
+		{
+			"Event",
+			cadence.NewEvent([]cadence.Value{address}).WithType(&cadence.EventType{
+				QualifiedIdentifier: "flow.AccountCreated",
+				Fields:              []cadence.Field{{Identifier: "address", Type: cadence.AddressType{}}},
+			}),
+			`{"address":"0x01cf0e2f2f715450"}`,
+		},
+		{"Struct Without Type", cadence.NewStruct([]cadence.Value{cadence.NewUInt8(1)}), `[1]`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := json.Marshal(flowkit.PlainJSON(test.value))
+			assert.NoError(t, err)
+			assert.Equal(t, test.json, string(out))
+		})
+	}
+}
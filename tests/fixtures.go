@@ -19,10 +19,14 @@
 package tests
 
 import (
+	"context"
+
 	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/test"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
 )
 
 var accounts = test.AccountGenerator()
@@ -35,6 +39,23 @@ func NewAccountWithAddress(address string) *flow.Account {
 	return account
 }
 
+// NewAccountWithKey returns an account with the public key of the provided account key at its index.
+func NewAccountWithKey(address string, key flowkit.AccountKey) *flow.Account {
+	account := NewAccountWithAddress(address)
+	publicKey, _ := key.PublicKey(context.Background())
+
+	account.Keys = []*flow.AccountKey{
+		flow.NewAccountKey().
+			SetPublicKey(publicKey).
+			SetSigAlgo(key.SigAlgo()).
+			SetHashAlgo(key.HashAlgo()).
+			SetWeight(flow.AccountKeyWeightThreshold),
+	}
+	account.Keys[0].Index = key.Index()
+
+	return account
+}
+
 func NewTransaction() *flow.Transaction {
 	return transactions.New()
 }
@@ -182,6 +182,17 @@ var TransactionSimple = resource{
 	`),
 }
 
+var TransactionTwoAuthorizers = resource{
+	Filename: "transactionTwoAuthorizers.cdc",
+	Source: []byte(`
+		transaction() {
+			prepare(first: AuthAccount, second: AuthAccount) {
+				log(first.address.toString().concat(",").concat(second.address.toString()))
+			}
+		}
+	`),
+}
+
 var ScriptWithError = resource{
 	Filename: "scriptError.cdc",
 	Source: []byte(`
@@ -211,6 +222,32 @@ var ScriptImport = resource{
 	`),
 }
 
+var ContractPeople = resource{
+	Name:     "People",
+	Filename: "contractPeople.cdc",
+	Source: []byte(`
+		pub contract People {
+			pub struct Person {
+				pub let name: String
+				init(name: String) {
+					self.name = name
+				}
+			}
+		}
+	`),
+}
+
+var ScriptPersonArg = resource{
+	Filename: "scriptPerson.cdc",
+	Source: []byte(`
+		import People from "./contractPeople.cdc"
+
+		pub fun main(person: People.Person): String {
+		  return "Hello ".concat(person.name)
+		}
+	`),
+}
+
 var resources = []resource{
 	ContractHelloString,
 	TransactionArgString,
@@ -218,10 +255,13 @@ var resources = []resource{
 	ContractSimple,
 	ContractSimpleUpdated,
 	TransactionSimple,
+	TransactionTwoAuthorizers,
 	ScriptImport,
 	ContractA,
 	ContractB,
 	ContractC,
+	ContractPeople,
+	ScriptPersonArg,
 }
 
 func ReaderWriter() afero.Afero {